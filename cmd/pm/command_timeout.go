@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+// defaultCommandTimeout bounds a CLI command when command_timeout_seconds is
+// not set
+const defaultCommandTimeout = 30 * time.Second
+
+// errOperationTimedOut is the cause of a command context canceled for
+// running past its timeout
+var errOperationTimedOut = errors.New("operation timed out")
+
+// runningTimeout is the running command's timeout, paused while a prompt
+// waits for an answer
+var runningTimeout struct {
+	timer   *time.Timer
+	timeout time.Duration
+}
+
+// commandTimeout returns how long a CLI command may run
+func commandTimeout(cfg *domain.Config) time.Duration {
+	if cfg.CommandTimeoutSeconds > 0 {
+		return time.Duration(cfg.CommandTimeoutSeconds) * time.Second
+	}
+	return defaultCommandTimeout
+}
+
+// withCommandTimeout returns a context canceled with errOperationTimedOut
+// once timeout has passed, not counting time spent at prompts
+func withCommandTimeout(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(parent)
+	timer := time.AfterFunc(timeout, func() { cancel(errOperationTimedOut) })
+	runningTimeout.timer = timer
+	runningTimeout.timeout = timeout
+
+	return ctx, func() {
+		timer.Stop()
+		runningTimeout.timer = nil
+		cancel(context.Canceled)
+	}
+}
+
+// pauseCommandTimeout stops the command's timeout while the user answers a
+// prompt. The returned function restarts it with the full timeout.
+func pauseCommandTimeout() func() {
+	timer, timeout := runningTimeout.timer, runningTimeout.timeout
+	if timer == nil || !timer.Stop() {
+		// No timeout running, or it already fired
+		return func() {}
+	}
+	return func() { timer.Reset(timeout) }
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+func TestConfigSetRejectsNonBooleans(t *testing.T) {
+	cfg := &domain.Config{ShowArchivedProjectTasks: true}
+
+	err := setConfig(cfg, []string{"show_archived_project_tasks", "yes please"})
+	if err == nil {
+		t.Fatal("Expected a non-boolean value to be rejected")
+	}
+	if kind, code := classifyExit(err); kind != "validation" || code != exitValidation {
+		t.Errorf("Expected validation with exit code %d, got %s with %d", exitValidation, kind, code)
+	}
+	if !cfg.ShowArchivedProjectTasks {
+		t.Error("Expected the setting to be left unchanged")
+	}
+}
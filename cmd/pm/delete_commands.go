@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+// deletionImpact counts the rows a cascading delete removes. Deleting a task
+// also deletes its subtasks, and time entries go with their task or project.
+type deletionImpact struct {
+	Tasks       int
+	TimeEntries int
+}
+
+// taskDeletionImpact counts what deleting roots removes, following subtasks
+// and, when projectID is set, time entries logged directly on that project
+func taskDeletionImpact(ctx context.Context, taskRepo domain.TaskRepository, timeEntryRepo domain.TimeEntryRepository, roots []*domain.Task, projectID string) (deletionImpact, error) {
+	taskIDs := make(map[string]bool)
+	queue := make([]*domain.Task, len(roots))
+	copy(queue, roots)
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if taskIDs[current.ID] {
+			continue
+		}
+		taskIDs[current.ID] = true
+
+		subtasks, err := taskRepo.GetSubtasks(ctx, current.ID)
+		if err != nil {
+			return deletionImpact{}, fmt.Errorf("failed to get subtasks: %w", err)
+		}
+		queue = append(queue, subtasks...)
+	}
+
+	entryIDs := make(map[string]bool)
+	for taskID := range taskIDs {
+		entries, err := timeEntryRepo.GetByTask(ctx, taskID)
+		if err != nil {
+			return deletionImpact{}, fmt.Errorf("failed to get time entries: %w", err)
+		}
+		for _, entry := range entries {
+			entryIDs[entry.ID] = true
+		}
+	}
+
+	if projectID != "" {
+		entries, err := timeEntryRepo.GetByProject(ctx, projectID)
+		if err != nil {
+			return deletionImpact{}, fmt.Errorf("failed to get time entries: %w", err)
+		}
+		for _, entry := range entries {
+			entryIDs[entry.ID] = true
+		}
+	}
+
+	return deletionImpact{Tasks: len(taskIDs), TimeEntries: len(entryIDs)}, nil
+}
+
+// projectDeletionImpact counts what deleting the project with projectID removes
+func projectDeletionImpact(ctx context.Context, taskRepo domain.TaskRepository, timeEntryRepo domain.TimeEntryRepository, projectID string) (deletionImpact, error) {
+	tasks, err := taskRepo.GetByProject(ctx, projectID)
+	if err != nil {
+		return deletionImpact{}, fmt.Errorf("failed to get project tasks: %w", err)
+	}
+	return taskDeletionImpact(ctx, taskRepo, timeEntryRepo, tasks, projectID)
+}
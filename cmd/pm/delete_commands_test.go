@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+	"github.com/adriannajera/project-manager-cli/internal/repository/memory"
+	"github.com/adriannajera/project-manager-cli/internal/service/project"
+	"github.com/adriannajera/project-manager-cli/internal/service/task"
+)
+
+// captureStdout returns what fn prints to stdout
+func captureStdout(t *testing.T, fn func() error) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	fnErr := fn()
+	w.Close()
+	out, _ := io.ReadAll(r)
+	if fnErr != nil {
+		t.Fatalf("Command failed: %v", fnErr)
+	}
+	return string(out)
+}
+
+func TestDeleteDryRunReportsAndDeletesNothing(t *testing.T) {
+	store := memory.NewStore()
+	taskRepo := memory.NewTaskRepository(store)
+	projectRepo := memory.NewProjectRepository(store)
+	entryRepo := memory.NewTimeEntryRepository(store)
+	taskService := task.NewService(taskRepo, nil)
+	projectService := project.NewService(projectRepo, nil)
+	ctx := context.Background()
+
+	website := domain.NewProject("Website", "")
+	projectRepo.Create(ctx, website)
+	parent := domain.NewTask("Launch", "")
+	parent.ProjectID = website.ID
+	child := domain.NewTask("Write copy", "")
+	child.ProjectID = website.ID
+	child.ParentID = &parent.ID
+	taskRepo.Create(ctx, parent)
+	taskRepo.Create(ctx, child)
+
+	for _, entry := range []*domain.TimeEntry{domain.NewTimeEntry(child.ID, "", ""), domain.NewTimeEntry("", website.ID, "")} {
+		entry.Stop()
+		entryRepo.Create(ctx, entry)
+	}
+
+	out := captureStdout(t, func() error {
+		return deleteTask(ctx, taskService, taskRepo, entryRepo, []string{parent.ID, "--dry-run"})
+	})
+	if want := `deleting task "Launch" would remove 1 subtasks and 1 time entries`; !strings.Contains(out, want) {
+		t.Errorf("Expected %q, got %q", want, out)
+	}
+
+	out = captureStdout(t, func() error {
+		return deleteProject(ctx, projectService, taskRepo, entryRepo, []string{website.ID, "--dry-run"})
+	})
+	if want := `deleting project "Website" would remove 2 tasks and 2 time entries`; !strings.Contains(out, want) {
+		t.Errorf("Expected %q, got %q", want, out)
+	}
+
+	if _, err := projectRepo.GetByID(ctx, website.ID); err != nil {
+		t.Errorf("Expected the project to be kept, got %v", err)
+	}
+	if tasks, _ := taskRepo.List(ctx, domain.TaskFilter{}); len(tasks) != 2 {
+		t.Errorf("Expected both tasks to be kept, got %d", len(tasks))
+	}
+	if entries, _ := entryRepo.List(ctx, domain.TimeEntryFilter{}); len(entries) != 2 {
+		t.Errorf("Expected both time entries to be kept, got %d", len(entries))
+	}
+}
+
+func TestDeleteRejectsUnknownFlags(t *testing.T) {
+	store := memory.NewStore()
+	taskRepo := memory.NewTaskRepository(store)
+	projectRepo := memory.NewProjectRepository(store)
+	entryRepo := memory.NewTimeEntryRepository(store)
+	ctx := context.Background()
+
+	kept := domain.NewTask("Keep me", "")
+	taskRepo.Create(ctx, kept)
+
+	err := deleteTask(ctx, task.NewService(taskRepo, nil), taskRepo, entryRepo, []string{kept.ID, "--dryrun"})
+	if kind, code := classifyExit(err); kind != "validation" || code != exitValidation {
+		t.Errorf("Expected a validation error for a misspelled flag, got %v", err)
+	}
+	if _, err := taskRepo.GetByID(ctx, kept.ID); err != nil {
+		t.Errorf("Expected the task to be kept, got %v", err)
+	}
+
+	err = deleteProject(ctx, project.NewService(projectRepo, nil), taskRepo, entryRepo, []string{"--force"})
+	if kind, code := classifyExit(err); kind != "validation" || code != exitValidation {
+		t.Errorf("Expected a validation error for an unknown flag, got %v", err)
+	}
+}
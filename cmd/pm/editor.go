@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// editorCommand returns the editor to run: the editor config setting, then
+// $VISUAL, then $EDITOR, then vi
+func editorCommand(configured string) string {
+	for _, editor := range []string{configured, os.Getenv("VISUAL"), os.Getenv("EDITOR")} {
+		if strings.TrimSpace(editor) != "" {
+			return editor
+		}
+	}
+	return "vi"
+}
+
+// openInEditor runs the editor on path and waits for it to exit, pausing
+// the command timeout meanwhile
+func openInEditor(editor, path string) error {
+	// The editor setting may carry arguments, such as "code --wait"
+	args := append(strings.Fields(editor), path)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	resume := pauseCommandTimeout()
+	defer resume()
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %s failed: %w", args[0], err)
+	}
+	return nil
+}
+
+// editText opens text in the editor and returns what was saved, with line
+// endings normalized and trailing blank space trimmed. The command timeout
+// is paused while the editor is open.
+func editText(editor, name, text string) (string, error) {
+	file, err := os.CreateTemp("", "pm-"+name+"-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	if _, err := file.WriteString(text); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	if err := openInEditor(editor, file.Name()); err != nil {
+		return "", err
+	}
+
+	edited, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited file: %w", err)
+	}
+
+	content := strings.ReplaceAll(string(edited), "\r\n", "\n")
+	return strings.TrimRight(content, " \t\n"), nil
+}
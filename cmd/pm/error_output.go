@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+	"github.com/adriannajera/project-manager-cli/internal/instance"
+)
+
+// Exit codes let scripts tell kinds of failure apart; anything not listed
+// exits with exitError
+const (
+	exitError      = 1
+	exitNotFound   = 3
+	exitValidation = 4
+	exitConflict   = 5
+)
+
+// errUnknownFlag marks a flag a command doesn't recognize, a usage mistake
+// reported like other validation failures
+var errUnknownFlag = errors.New("unknown flag")
+
+// errorFormat is how a failed command is reported on stderr: "text" or
+// "json", set with the global --output flag
+var errorFormat = "text"
+
+// errorKinds maps the domain sentinels to a kind name and exit code
+var errorKinds = []struct {
+	err  error
+	kind string
+	code int
+}{
+	{domain.ErrTaskNotFound, "not_found", exitNotFound},
+	{domain.ErrProjectNotFound, "not_found", exitNotFound},
+	{domain.ErrTimeEntryNotFound, "not_found", exitNotFound},
+	{domain.ErrParentNotFound, "not_found", exitNotFound},
+	{domain.ErrNoActiveTimeEntry, "not_found", exitNotFound},
+	{domain.ErrMetadataNotFound, "not_found", exitNotFound},
+	{domain.ErrInvalidDueDate, "validation", exitValidation},
+	{domain.ErrInvalidStatus, "validation", exitValidation},
+	{domain.ErrInvalidPriority, "validation", exitValidation},
+	{domain.ErrInvalidEstimate, "validation", exitValidation},
+	{domain.ErrEmptyTitle, "validation", exitValidation},
+	{domain.ErrEmptyName, "validation", exitValidation},
+	{domain.ErrEmptyComment, "validation", exitValidation},
+	{domain.ErrInvalidTaskID, "validation", exitValidation},
+	{domain.ErrInvalidProjectID, "validation", exitValidation},
+	{domain.ErrAmbiguousID, "validation", exitValidation},
+	{domain.ErrTaskOrCategory, "validation", exitValidation},
+	{domain.ErrReasonNotBlocked, "validation", exitValidation},
+	{domain.ErrEmptyMetadataKey, "validation", exitValidation},
+	{domain.ErrEmptyAttachment, "validation", exitValidation},
+	{domain.ErrInvalidConfig, "validation", exitValidation},
+	{errUnknownFlag, "validation", exitValidation},
+	{domain.ErrDuplicateProject, "conflict", exitConflict},
+	{domain.ErrActiveTimeEntry, "conflict", exitConflict},
+	{domain.ErrCircularDependency, "conflict", exitConflict},
+	{instance.ErrRunning, "conflict", exitConflict},
+}
+
+// classifyExit returns the kind of failure err is and the exit code for it
+func classifyExit(err error) (string, int) {
+	for _, k := range errorKinds {
+		if errors.Is(err, k.err) {
+			return k.kind, k.code
+		}
+	}
+	return "error", exitError
+}
+
+// reportError writes err to w in the configured format and returns the exit
+// code for it
+func reportError(w io.Writer, err error) int {
+	kind, code := classifyExit(err)
+
+	if errorFormat == "json" {
+		out := struct {
+			Error    string `json:"error"`
+			Kind     string `json:"kind"`
+			ExitCode int    `json:"exit_code"`
+		}{err.Error(), kind, code}
+		if encodeErr := json.NewEncoder(w).Encode(out); encodeErr == nil {
+			return code
+		}
+	}
+
+	fmt.Fprintf(w, "Error: %v\n", err)
+	return code
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adriannajera/project-manager-cli/pkg/config"
+)
+
+// inMemory starts the dashboard on an empty in-memory store instead of the
+// database, set with the global --in-memory flag
+var inMemory bool
+
+// applyGlobalFlags consumes the global flags that precede the command and
+// returns the remaining arguments, program name included
+func applyGlobalFlags(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return args, nil
+	}
+
+	rest := args[1:]
+	for len(rest) > 0 && strings.HasPrefix(rest[0], "--") {
+		if rest[0] == "--in-memory" {
+			inMemory = true
+			rest = rest[1:]
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(rest[0], "=")
+		if name != "--config-dir" && name != "--profile" && name != "--output" {
+			// Not a global flag; leave it for the command (e.g. --help)
+			break
+		}
+
+		if !hasValue {
+			if len(rest) < 2 {
+				return nil, fmt.Errorf("%s requires a value", name)
+			}
+			value = rest[1]
+			rest = rest[1:]
+		}
+		if value == "" {
+			return nil, fmt.Errorf("%s requires a value", name)
+		}
+		rest = rest[1:]
+
+		switch name {
+		case "--config-dir":
+			config.SetConfigDir(value)
+		case "--profile":
+			if err := config.SetProfile(value); err != nil {
+				return nil, err
+			}
+		case "--output":
+			if value != "text" && value != "json" {
+				return nil, fmt.Errorf("invalid --output %q (use text or json)", value)
+			}
+			errorFormat = value
+		}
+	}
+
+	return append([]string{args[0]}, rest...), nil
+}
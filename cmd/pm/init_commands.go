@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+	"github.com/adriannajera/project-manager-cli/internal/repository/sqlite"
+	"github.com/adriannajera/project-manager-cli/internal/service/project"
+	"github.com/adriannajera/project-manager-cli/internal/ui"
+	"github.com/adriannajera/project-manager-cli/pkg/config"
+)
+
+// isFirstRun reports whether pm should offer setup before starting: no config
+// has been written yet and someone is at the terminal to answer
+func isFirstRun() bool {
+	if config.Exists() {
+		return false
+	}
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// runInit walks through the main settings, saves them, and offers to create a
+// first project
+func runInit(args []string) error {
+	for _, arg := range args {
+		if arg == "help" || arg == "--help" || arg == "-h" {
+			return showInitHelp()
+		}
+	}
+
+	w := &setupWizard{reader: bufio.NewReader(os.Stdin)}
+
+	cfg := config.Default()
+	if config.Exists() {
+		reconfigure, err := w.askYesNo(fmt.Sprintf("Config already exists at %s. Reconfigure?", config.Path()), false)
+		if err != nil || !reconfigure {
+			return err
+		}
+		if cfg, err = config.Load(); err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+	}
+
+	fmt.Println("Project Manager setup. Press enter to keep the value in brackets.")
+	fmt.Println()
+
+	var err error
+	if cfg.DatabasePath, err = w.ask("Database location", cfg.DatabasePath); err != nil {
+		return err
+	}
+	if cfg.GitIntegration, err = w.askYesNo("Enable git integration?", cfg.GitIntegration); err != nil {
+		return err
+	}
+	if cfg.DefaultProject, err = w.ask("Default project (blank for none)", cfg.DefaultProject); err != nil {
+		return err
+	}
+
+	themeNames := make([]string, 0, len(config.ThemePresets))
+	for name := range config.ThemePresets {
+		themeNames = append(themeNames, name)
+	}
+	sort.Strings(themeNames)
+	theme, err := w.askChoice("Color theme", themeNames, "default")
+	if err != nil {
+		return err
+	}
+	cfg.Theme = config.ThemePresets[theme]
+
+	iconStyle := cfg.IconStyle
+	if iconStyle == "" {
+		iconStyle = string(ui.IconStyleASCII)
+	}
+	if cfg.IconStyle, err = w.askChoice("Icon style", []string{"ascii", "emoji", "nerdfont"}, iconStyle); err != nil {
+		return err
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	fmt.Printf("\nSaved config to %s\n", config.Path())
+
+	createProject, err := w.askYesNo("Create an initial project?", true)
+	if err != nil || !createProject {
+		return err
+	}
+	name, err := w.ask("Project name", cfg.DefaultProject)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		fmt.Println("No name given, skipping project creation.")
+		return nil
+	}
+
+	return createInitialProject(cfg, name)
+}
+
+// createInitialProject creates the named project in the configured database
+func createInitialProject(cfg *domain.Config, name string) error {
+	db, err := sqlite.NewDB(cfg.DatabasePath)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	projectService := project.NewService(sqlite.NewProjectRepository(db), cfg.ProjectColors)
+	created, err := projectService.CreateProject(context.Background(), project.CreateProjectInput{Name: name})
+	if errors.Is(err, domain.ErrDuplicateProject) {
+		fmt.Printf("Project %q already exists.\n", name)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+
+	fmt.Printf("Created project: %s (ID: %s)\n", created.Name, created.ID)
+	return nil
+}
+
+// setupWizard reads answers from one reader so buffered input is not lost
+// between questions
+type setupWizard struct {
+	reader *bufio.Reader
+}
+
+// ask prompts for a value, returning def when the answer is blank
+func (w *setupWizard) ask(label, def string) (string, error) {
+	if def != "" {
+		fmt.Printf("%s [%s]: ", label, def)
+	} else {
+		fmt.Printf("%s: ", label)
+	}
+
+	input, err := w.reader.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && input != "") {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return def, nil
+	}
+	return input, nil
+}
+
+// askYesNo prompts for a yes/no answer, returning def when the answer is blank
+func (w *setupWizard) askYesNo(label string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+
+	for {
+		input, err := w.ask(fmt.Sprintf("%s (%s)", label, hint), "")
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(input) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Println("Please answer y or n.")
+	}
+}
+
+// askChoice prompts until the answer is one of choices
+func (w *setupWizard) askChoice(label string, choices []string, def string) (string, error) {
+	for {
+		input, err := w.ask(fmt.Sprintf("%s (%s)", label, strings.Join(choices, "/")), def)
+		if err != nil {
+			return "", err
+		}
+		input = strings.ToLower(input)
+		for _, choice := range choices {
+			if input == choice {
+				return choice, nil
+			}
+		}
+		fmt.Printf("Please choose one of: %s\n", strings.Join(choices, ", "))
+	}
+}
+
+func showInitHelp() error {
+	helpText := `Init Command
+
+Interactively set the database location, git integration, default project,
+color theme, and icon style, then optionally create a first project.
+
+USAGE:
+  pm init
+
+Running pm with no arguments before any config exists starts this setup
+automatically. Scripts that run a command directly get the defaults instead.
+`
+	fmt.Println(helpText)
+	return nil
+}
@@ -3,19 +3,29 @@ package main
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/adriannajera/project-manager-cli/internal/domain"
+	"github.com/adriannajera/project-manager-cli/internal/hooks"
+	"github.com/adriannajera/project-manager-cli/internal/instance"
+	"github.com/adriannajera/project-manager-cli/internal/repository/memory"
 	"github.com/adriannajera/project-manager-cli/internal/repository/sqlite"
 	"github.com/adriannajera/project-manager-cli/internal/service/task"
 	"github.com/adriannajera/project-manager-cli/internal/service/project"
 	"github.com/adriannajera/project-manager-cli/internal/service/git"
 	timeService "github.com/adriannajera/project-manager-cli/internal/service/time"
 	"github.com/adriannajera/project-manager-cli/internal/service/export"
+	"github.com/adriannajera/project-manager-cli/internal/service/stats"
+	"github.com/adriannajera/project-manager-cli/internal/service/review"
+	"github.com/adriannajera/project-manager-cli/internal/ui"
 	"github.com/adriannajera/project-manager-cli/internal/ui/models"
 	"github.com/adriannajera/project-manager-cli/pkg/config"
 	"gopkg.in/yaml.v3"
@@ -23,24 +33,62 @@ import (
 
 func main() {
 	if err := run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(reportError(os.Stderr, err))
 	}
 }
 
 func run() error {
+	args, err := applyGlobalFlags(os.Args)
+	if err != nil {
+		return err
+	}
+	os.Args = args
+
+	// Version needs neither config nor database, so it works on broken setups
+	if len(os.Args) > 1 && os.Args[1] == "version" {
+		return showVersion(os.Args[2:])
+	}
+
+	// Setup writes the config, so it runs before anything loads it
+	if len(os.Args) > 1 && os.Args[1] == "init" {
+		return runInit(os.Args[2:])
+	}
+	if len(os.Args) == 1 && !inMemory && isFirstRun() {
+		if err := runInit(nil); err != nil {
+			return err
+		}
+		fmt.Println()
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	ui.SetTimeFormats(cfg.DateFormat, cfg.TimeFormat)
+
+	// Profiles are managed from the config alone
+	if len(os.Args) > 1 && os.Args[1] == "profile" {
+		return handleProfileCommand(cfg, os.Args[2:])
+	}
+
+	// A scratch session keeps everything in memory and leaves the database
+	// alone
+	if inMemory {
+		if len(os.Args) > 1 {
+			return fmt.Errorf("--in-memory only applies to the interactive dashboard; a command's changes would be lost when it exits")
+		}
+		store := memory.NewStore()
+		return runTUI(store, memory.NewTaskRepository(store), memory.NewProjectRepository(store), memory.NewTimeEntryRepository(store), git.NewGitRepository(), cfg, "")
+	}
+
 	// Initialize database
 	db, err := sqlite.NewDB(cfg.DatabasePath)
 	if err != nil {
-		return fmt.Errorf("failed to initialize database: %w", err)
+		return databaseOpenError(cfg.DatabasePath, err)
 	}
-	
+
 	defer db.Close()
 
 	// Initialize repositories
@@ -53,43 +101,207 @@ func run() error {
 
 	// Check if we should run CLI commands or TUI
 	if len(os.Args) > 1 {
-		return runCLI(taskRepo, projectRepo, timeEntryRepo, gitRepo, cfg)
+		return runCLI(db, taskRepo, projectRepo, timeEntryRepo, gitRepo, cfg)
 	}
 
 	// Run TUI application
-	return runTUI(taskRepo, projectRepo, timeEntryRepo, gitRepo)
+	return runTUI(db, taskRepo, projectRepo, timeEntryRepo, gitRepo, cfg, dashboardSocket(cfg))
 }
 
-func runCLI(taskRepo *sqlite.TaskRepository, projectRepo *sqlite.ProjectRepository, timeEntryRepo *sqlite.TimeEntryRepository, gitRepo *git.GitRepository, cfg *domain.Config) error {
-	// Initialize services
+// databaseOpenError explains a database that could not be opened, with what
+// to do about it when the file is locked or corrupt
+func databaseOpenError(path string, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrDatabaseLocked):
+		return fmt.Errorf("database %s is in use by another process; close the other pm instance or program using it and try again: %w", path, err)
+	case errors.Is(err, domain.ErrDatabaseCorrupt):
+		return fmt.Errorf("database %s is damaged; restore it from a backup copy, or move it aside to start with an empty database: %w", path, err)
+	default:
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+}
+
+// timeOptions builds the time tracking options from the config
+func timeOptions(cfg *domain.Config) (timeService.Options, error) {
+	roundingMode, err := timeService.ParseRoundingMode(cfg.TimeRoundingMode)
+	if err != nil {
+		return timeService.Options{}, fmt.Errorf("invalid time_rounding_mode in config: %w", err)
+	}
+
+	weekStart, err := timeService.ParseWeekStart(cfg.WeekStart)
+	if err != nil {
+		return timeService.Options{}, fmt.Errorf("invalid week_start in config: %w", err)
+	}
+
+	location, err := timeService.ParseTimezone(cfg.Timezone)
+	if err != nil {
+		return timeService.Options{}, fmt.Errorf("invalid timezone in config: %w", err)
+	}
+
+	return timeService.Options{
+		MaxTimerDuration: time.Duration(cfg.MaxTimerHours) * time.Hour,
+		Rounding: timeService.Rounding{
+			Increment: time.Duration(cfg.TimeRounding) * time.Minute,
+			Mode:      roundingMode,
+		},
+		WeekStart: weekStart,
+		Location:  location,
+	}, nil
+}
+
+// dashboardSocket returns the instance socket of the dashboard for the
+// configured database
+func dashboardSocket(cfg *domain.Config) string {
+	return instance.SocketPath(config.ConfigDir(), cfg.DatabasePath)
+}
+
+// newTimeService returns a time service for the commands that track or
+// report time, passing timer events to notifier when there is one
+func newTimeService(db *sqlite.DB, taskRepo *sqlite.TaskRepository, projectRepo *sqlite.ProjectRepository, timeEntryRepo *sqlite.TimeEntryRepository, cfg *domain.Config, notifier hooks.Group) (*timeService.Service, error) {
+	timeOpts, err := timeOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	timeSvc := timeService.NewService(timeEntryRepo, taskRepo, db, timeOpts)
+	timeSvc.SetProjectRepository(projectRepo)
+	if notifier != nil {
+		timeSvc.SetNotifier(notifier)
+	}
+	return timeSvc, nil
+}
+
+// newTaskService returns the task service with the options the config turns
+// on, for the CLI and the dashboard alike
+func newTaskService(taskRepo domain.TaskRepository, projectRepo domain.ProjectRepository, gitRepo domain.GitRepository, cfg *domain.Config) (*task.Service, error) {
 	taskService := task.NewService(taskRepo, gitRepo)
-	projectService := project.NewService(projectRepo)
+	if cfg.AutoCompleteProjects {
+		taskService.EnableProjectAutoCompletion(projectRepo)
+	}
+	if cfg.RejectPastDue {
+		taskService.RejectPastDueDates()
+	}
+	if err := enableIDScheme(taskService, projectRepo, cfg); err != nil {
+		return nil, err
+	}
+	return taskService, nil
+}
+
+// enableIDScheme turns on sequential task references when the configured
+// task_id_scheme asks for them
+func enableIDScheme(taskService *task.Service, projectRepo domain.ProjectRepository, cfg *domain.Config) error {
+	scheme, err := task.ParseIDScheme(cfg.TaskIDScheme)
+	if err != nil {
+		return err
+	}
+	if scheme == task.IDSchemeSequential {
+		taskService.EnableSequentialIDs(projectRepo)
+	}
+	return nil
+}
+
+func runCLI(db *sqlite.DB, taskRepo *sqlite.TaskRepository, projectRepo *sqlite.ProjectRepository, timeEntryRepo *sqlite.TimeEntryRepository, gitRepo *git.GitRepository, cfg *domain.Config) (err error) {
+	// Initialize services
+	taskService, err := newTaskService(taskRepo, projectRepo, gitRepo, cfg)
+	if err != nil {
+		return err
+	}
+	projectService := project.NewService(projectRepo, cfg.ProjectColors)
+
+	notifier, err := newNotifier(cfg, os.Stderr)
+	if err != nil {
+		return err
+	}
+	if notifier != nil {
+		taskService.SetNotifier(notifier)
+		defer notifier.Wait(notifyTimeout)
+	}
+	if cfg.AutoCompleteProjects {
+		taskService.SetNotifier(projectAnnouncer{next: notifier})
+	}
+
+	// The server runs until interrupted, so its requests are bounded one by
+	// one instead
+	if os.Args[1] == "serve" {
+		timeSvc, err := newTimeService(db, taskRepo, projectRepo, timeEntryRepo, cfg, notifier)
+		if err != nil {
+			return err
+		}
+		// Show the API's changes in a dashboard open on the same database
+		notifyDashboard := func() {
+			instance.NotifyReload(dashboardSocket(cfg))
+		}
+		return handleServeCommand(taskService, projectService, timeSvc, commandTimeout(cfg), notifyDashboard, os.Args[2:])
+	}
+
+	// Show changes in a dashboard open on the same database. Read-only
+	// commands leave the change count alone and don't disturb it.
+	if before, countErr := db.TotalChanges(context.Background()); countErr == nil {
+		defer func() {
+			if after, err := db.TotalChanges(context.Background()); err == nil && after != before {
+				instance.NotifyReload(dashboardSocket(cfg))
+			}
+		}()
+	}
+
+	// Bound the command so a stalled database fails instead of hanging
+	timeout := commandTimeout(cfg)
+	ctx, cancel := withCommandTimeout(context.Background(), timeout)
+	defer cancel()
+	defer func() {
+		if err != nil && errors.Is(context.Cause(ctx), errOperationTimedOut) {
+			err = fmt.Errorf("%w after %s (raise command_timeout_seconds if the database is on slow storage)", errOperationTimedOut, timeout)
+		}
+	}()
 
 	// Simple command routing
 	command := os.Args[1]
 
 	switch command {
 	case "task":
-		return handleTaskCommand(taskService, taskRepo, projectRepo, os.Args[2:])
+		return handleTaskCommand(ctx, db, taskService, taskRepo, projectRepo, timeEntryRepo, cfg, notifier, os.Args[2:])
 	case "project":
-		return handleProjectCommand(projectService, os.Args[2:])
+		timeOpts, err := timeOptions(cfg)
+		if err != nil {
+			return err
+		}
+		statsService := stats.NewService(taskRepo, projectRepo, timeEntryRepo, timeOpts)
+		return handleProjectCommand(ctx, projectService, statsService, taskService, taskRepo, timeEntryRepo, os.Args[2:])
 	case "time":
-		timeSvc := timeService.NewService(timeEntryRepo, taskRepo)
-		return handleTimeCommand(timeSvc, os.Args[2:])
+		timeSvc, err := newTimeService(db, taskRepo, projectRepo, timeEntryRepo, cfg, notifier)
+		if err != nil {
+			return err
+		}
+		return handleTimeCommand(ctx, timeSvc, taskService, os.Args[2:])
 	case "export":
+		timeOpts, err := timeOptions(cfg)
+		if err != nil {
+			return err
+		}
 		exportService := export.NewService(taskRepo, projectRepo, timeEntryRepo)
-		return handleExportCommand(exportService, os.Args[2:])
+		return handleExportCommand(ctx, exportService, projectRepo, timeOpts.Location, os.Args[2:])
 	case "workspace":
-		return handleWorkspaceCommand(taskRepo, os.Args[2:])
+		return handleWorkspaceCommand(ctx, taskRepo, os.Args[2:])
+	case "tag":
+		return handleTagCommand(ctx, taskService, os.Args[2:])
+	case "stats":
+		timeOpts, err := timeOptions(cfg)
+		if err != nil {
+			return err
+		}
+		statsService := stats.NewService(taskRepo, projectRepo, timeEntryRepo, timeOpts)
+		return handleStatsCommand(ctx, statsService, os.Args[2:])
+	case "review":
+		timeSvc, err := newTimeService(db, taskRepo, projectRepo, timeEntryRepo, cfg, notifier)
+		if err != nil {
+			return err
+		}
+		return handleReviewCommand(ctx, review.NewService(taskService, timeSvc), timeSvc, os.Args[2:])
 	case "config":
 		return handleConfigCommand(cfg, os.Args[2:])
 	case "git":
 		return handleGitCommand(gitRepo, os.Args[2:])
 	case "prune":
-		return handlePruneCommand(taskRepo, projectRepo, timeEntryRepo)
-	case "version":
-		fmt.Println("Project Manager CLI v1.0.0")
-		return nil
+		return handlePruneCommand(ctx, taskRepo, projectRepo, timeEntryRepo)
 	case "help", "--help", "-h":
 		return showHelp()
 	default:
@@ -97,69 +309,319 @@ func runCLI(taskRepo *sqlite.TaskRepository, projectRepo *sqlite.ProjectReposito
 	}
 }
 
-func runTUI(taskRepo *sqlite.TaskRepository, projectRepo *sqlite.ProjectRepository, timeEntryRepo *sqlite.TimeEntryRepository, gitRepo *git.GitRepository) error {
+// runTUI runs the dashboard. lockPath is the instance socket that keeps it
+// the only dashboard on the database, or empty for a scratch store.
+func runTUI(transactor domain.Transactor, taskRepo domain.TaskRepository, projectRepo domain.ProjectRepository, timeEntryRepo domain.TimeEntryRepository, gitRepo domain.GitRepository, cfg *domain.Config, lockPath string) error {
+	if _, err := ui.SetIconStyle(cfg.IconStyle); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, using ascii icons\n", err)
+	}
+
 	// Create the Bubble Tea application
 	app := models.NewAppModel(taskRepo, projectRepo, timeEntryRepo, gitRepo)
+	taskSvc, err := newTaskService(taskRepo, projectRepo, gitRepo, cfg)
+	if err != nil {
+		return err
+	}
+	app.SetTaskService(taskSvc)
+	app.SetTemplates(cfg.Templates)
+	app.SetEditor(cfg.Editor)
+	app.SetShowArchivedProjectTasks(cfg.ShowArchivedProjectTasks)
+	app.SetRefreshInterval(time.Duration(cfg.RefreshSeconds) * time.Second)
+
+	// Start the task list with the filter left on last session
+	state, err := config.LoadTUIState()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, starting without a saved filter\n", err)
+		state = &config.TUIState{}
+	}
+	app.SetSavedFilter(state.StatusFilter, func(statuses []domain.TaskStatus) error {
+		state.StatusFilter = statuses
+		return config.SaveTUIState(state)
+	})
+
+	timeOpts, err := timeOptions(cfg)
+	if err != nil {
+		return err
+	}
+	timeSvc := timeService.NewService(timeEntryRepo, taskRepo, transactor, timeOpts)
+	timeSvc.SetProjectRepository(projectRepo)
+
+	// Notification failures go to a log file so they don't corrupt the screen
+	if len(cfg.Hooks) > 0 || cfg.WebhookURL != "" {
+		logFile, err := os.OpenFile(filepath.Join(config.ConfigDir(), "hooks.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to open hook log: %w", err)
+		}
+		defer logFile.Close()
+
+		notifier, err := newNotifier(cfg, logFile)
+		if err != nil {
+			return err
+		}
+		taskSvc.SetNotifier(notifier)
+		timeSvc.SetNotifier(notifier)
+		defer notifier.Wait(notifyTimeout)
+	}
+	app.SetTimeService(timeSvc)
+
+	// One dashboard per database, since two would overwrite each other's
+	// edits; CLI commands tell it to reload through the lock's socket
+	var reloads <-chan struct{}
+	if lockPath != "" {
+		lock, err := instance.Acquire(lockPath, instance.LockPath(cfg.DatabasePath))
+		if errors.Is(err, instance.ErrRunning) {
+			return fmt.Errorf("%w; close it first, since two dashboards on one database overwrite each other's edits", err)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v, not checking for other dashboards\n", err)
+		} else {
+			defer lock.Release()
+			reloads = lock.Reloads()
+		}
+	}
+
+	// Opting in to priority aging sweeps overdue tasks as the dashboard opens
+	if cfg.PriorityAgingDays > 0 {
+		if _, err := taskSvc.AgeOverdueTasks(context.Background(), agingInterval(cfg.PriorityAgingDays), time.Now(), false); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to age overdue tasks: %v\n", err)
+		}
+	}
 
 	// Start the TUI
 	p := tea.NewProgram(app, tea.WithAltScreen())
-	_, err := p.Run()
+	if reloads != nil {
+		go func() {
+			for range reloads {
+				p.Send(models.ExternalChangeMsg{})
+			}
+		}()
+	}
+	_, err = p.Run()
 	return err
 }
 
-func handleTaskCommand(taskService *task.Service, taskRepo *sqlite.TaskRepository, projectRepo *sqlite.ProjectRepository, args []string) error {
+// projectAnnouncer tells the user when completing a task also completed its
+// project, passing every event on to next
+type projectAnnouncer struct {
+	next domain.Notifier
+}
+
+func (a projectAnnouncer) Notify(event domain.Event) {
+	if event.Name == domain.EventProjectCompleted && event.Project != nil {
+		fmt.Printf("Project %s completed: all of its tasks are done\n", event.Project.Name)
+	}
+	a.next.Notify(event)
+}
+
+// notifyTimeout bounds how long pm waits for running hooks and webhooks
+// before exiting
+const notifyTimeout = 10 * time.Second
+
+// newNotifier returns the configured hooks and webhook as one notifier, or
+// nil when neither is configured
+func newNotifier(cfg *domain.Config, log io.Writer) (hooks.Group, error) {
+	var group hooks.Group
+	if len(cfg.Hooks) > 0 {
+		if err := hooks.Validate(cfg.Hooks); err != nil {
+			return nil, fmt.Errorf("invalid hooks in config: %w", err)
+		}
+		group = append(group, hooks.NewRunner(cfg.Hooks, log))
+	}
+	if cfg.WebhookURL != "" {
+		if err := hooks.ValidateWebhookURL(cfg.WebhookURL); err != nil {
+			return nil, fmt.Errorf("invalid config: %w", err)
+		}
+		group = append(group, hooks.NewWebhook(cfg.WebhookURL, log))
+	}
+	return group, nil
+}
+
+func handleTaskCommand(ctx context.Context, db *sqlite.DB, taskService *task.Service, taskRepo *sqlite.TaskRepository, projectRepo *sqlite.ProjectRepository, timeEntryRepo *sqlite.TimeEntryRepository, cfg *domain.Config, notifier hooks.Group, args []string) error {
 	if len(args) == 0 {
 		return showTaskHelp()
 	}
 
-	ctx := context.Background()
 	subcommand := args[0]
 
+	// Commands that take a task ID accept any unambiguous prefix of it
+	if index := taskIDArgIndex(args); index > 0 {
+		if strings.HasPrefix(args[index], "-") {
+			return fmt.Errorf("%w: task %s takes the task ID before any flags", domain.ErrInvalidTaskID, subcommand)
+		}
+		taskID, err := taskService.ResolveID(ctx, args[index])
+		if err != nil {
+			return err
+		}
+		args[index] = taskID
+	}
+
 	switch subcommand {
 	case "help", "--help", "-h":
 		return showTaskHelp()
 	case "list", "ls":
-		return listTasks(ctx, taskService, projectRepo, args[1:])
+		return listTasks(ctx, taskService, projectRepo, cfg, args[1:])
 	case "add", "create":
 		if len(args) < 2 {
 			return fmt.Errorf("task add requires a title")
 		}
-		return addTask(ctx, taskService, projectRepo, args[1:])
+		return addTask(ctx, taskService, projectRepo, cfg.Templates, editorCommand(cfg.Editor), args[1:])
 	case "update":
 		if len(args) < 2 {
 			return fmt.Errorf("task update requires a task ID")
 		}
-		return updateTask(ctx, taskService, projectRepo, args[1:])
+		return updateTask(ctx, taskService, projectRepo, editorCommand(cfg.Editor), args[1:])
 	case "complete":
 		if len(args) < 2 {
 			return fmt.Errorf("task complete requires a task ID")
 		}
-		return completeTask(ctx, taskService, args[1])
+		return completeTask(ctx, taskService, args[1:])
+	case "start":
+		if len(args) < 2 {
+			return fmt.Errorf("task start requires a task ID")
+		}
+		return startTask(ctx, taskService, args[1])
+	case "block":
+		if len(args) < 2 {
+			return fmt.Errorf("task block requires a task ID")
+		}
+		reason, err := parseBlockReason(args[2:])
+		if err != nil {
+			return err
+		}
+		return blockTask(ctx, taskService, args[1], reason)
+	case "snooze":
+		if len(args) < 3 {
+			return fmt.Errorf("task snooze requires a task ID and an amount or date (e.g. 3d, 1w, next monday)")
+		}
+		return snoozeTask(ctx, taskService, args[1], strings.Join(args[2:], " "))
+	case "tag":
+		return handleTaskTagCommand(ctx, taskService, args[1:])
+	case "clone":
+		if len(args) < 2 {
+			return fmt.Errorf("task clone requires a task ID")
+		}
+		return cloneTask(ctx, taskService, args[1:])
 	case "delete", "rm":
 		if len(args) < 2 {
 			return fmt.Errorf("task delete requires a task ID")
 		}
-		return deleteTask(ctx, taskService, args[1])
+		return deleteTask(ctx, taskService, taskRepo, timeEntryRepo, args[1:])
+	case "search":
+		if len(args) < 2 {
+			return fmt.Errorf("task search requires a query")
+		}
+		return searchTasks(ctx, taskService, args[1:])
+	case "tree":
+		return showTaskTree(ctx, taskService, args[1:])
+	case "recent":
+		return recentTasks(ctx, taskService, args[1:])
+	case "blocked":
+		return listBlockedTasks(ctx, taskService)
+	case "next":
+		return nextTask(ctx, db, taskService, taskRepo, projectRepo, timeEntryRepo, cfg, notifier, args[1:])
+	case "age":
+		return ageTasks(ctx, taskService, cfg, args[1:])
 	case "note":
-		return handleTaskNoteCommand(taskRepo, args[1:])
+		return handleTaskNoteCommand(ctx, taskRepo, editorCommand(cfg.Editor), args[1:])
+	case "meta":
+		return handleTaskMetaCommand(ctx, taskService, args[1:])
+	case "attach":
+		return attachToTask(ctx, taskService, args[1:])
+	case "attachments":
+		if len(args) < 2 {
+			return fmt.Errorf("task attachments requires a task ID")
+		}
+		return listTaskAttachments(ctx, taskService, args[1])
+	case "show":
+		if len(args) < 2 {
+			return fmt.Errorf("task show requires a task ID")
+		}
+		return showTask(ctx, taskService, projectRepo, args[1])
+	case "comment":
+		if len(args) < 3 {
+			return fmt.Errorf("task comment requires a task ID and text")
+		}
+		return commentOnTask(ctx, taskService, args[1], strings.Join(args[2:], " "))
+	case "log":
+		if len(args) < 2 {
+			return fmt.Errorf("task log requires a task ID")
+		}
+		timeSvc, err := newTimeService(db, taskRepo, projectRepo, timeEntryRepo, cfg, notifier)
+		if err != nil {
+			return err
+		}
+		return showTaskLog(ctx, review.NewService(taskService, timeSvc), timeSvc, args[1])
 	default:
 		return fmt.Errorf("unknown task subcommand: %s", subcommand)
 	}
 }
 
-func handleProjectCommand(projectService *project.Service, args []string) error {
+// taskCountSummary describes how many of the listed tasks are in each
+// status, such as "12 tasks: 3 todo, 2 doing, 6 done, 1 blocked"
+func taskCountSummary(tasks []*domain.Task) string {
+	counts := make(map[domain.TaskStatus]int)
+	for _, t := range tasks {
+		counts[t.Status]++
+	}
+
+	noun := "tasks"
+	if len(tasks) == 1 {
+		noun = "task"
+	}
+
+	var parts []string
+	for _, status := range []domain.TaskStatus{domain.StatusBacklog, domain.StatusTodo, domain.StatusDoing, domain.StatusDone, domain.StatusBlocked} {
+		if counts[status] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[status], status))
+		}
+	}
+	return fmt.Sprintf("%d %s: %s", len(tasks), noun, strings.Join(parts, ", "))
+}
+
+// taskIDArgIndex returns the position of the task ID in task subcommand
+// args, or -1 when the subcommand takes none
+func taskIDArgIndex(args []string) int {
+	switch args[0] {
+	case "update", "complete", "start", "block", "snooze", "clone", "tree", "show", "comment", "attachments", "log":
+		// The ID comes before any flags, some of which take values
+		if len(args) > 1 {
+			return 1
+		}
+	case "delete", "rm", "attach":
+		// These only take switches, so the ID is the first other argument
+		for i := 1; i < len(args); i++ {
+			if !strings.HasPrefix(args[i], "-") {
+				return i
+			}
+		}
+	case "note":
+		if len(args) > 2 && (args[1] == "link" || args[1] == "unlink" || args[1] == "show" || args[1] == "refresh" || args[1] == "edit") {
+			return 2
+		}
+	case "tag":
+		if len(args) > 2 && (args[1] == "add" || args[1] == "rm" || args[1] == "remove") {
+			return 2
+		}
+	case "meta":
+		if len(args) > 2 && (args[1] == "set" || args[1] == "get" || args[1] == "list" || args[1] == "ls" || args[1] == "unset" || args[1] == "rm") {
+			return 2
+		}
+	}
+	return -1
+}
+
+func handleProjectCommand(ctx context.Context, projectService *project.Service, statsSvc *stats.Service, taskService *task.Service, taskRepo *sqlite.TaskRepository, timeEntryRepo *sqlite.TimeEntryRepository, args []string) error {
 	if len(args) == 0 {
 		return showProjectHelp()
 	}
 
-	ctx := context.Background()
 	subcommand := args[0]
 
 	switch subcommand {
 	case "help", "--help", "-h":
 		return showProjectHelp()
 	case "list", "ls":
-		return listProjects(ctx, projectService)
+		return listProjects(ctx, projectService, args[1:])
 	case "add", "create":
 		if len(args) < 2 {
 			return fmt.Errorf("project add requires a name")
@@ -169,27 +631,57 @@ func handleProjectCommand(projectService *project.Service, args []string) error
 		if len(args) < 2 {
 			return fmt.Errorf("project delete requires a project ID")
 		}
-		return deleteProject(ctx, projectService, args[1])
+		return deleteProject(ctx, projectService, taskRepo, timeEntryRepo, args[1:])
+	case "stats":
+		return showProjectStats(ctx, projectService, statsSvc, args[1:])
+	case "archive":
+		if len(args) < 2 {
+			return fmt.Errorf("project archive requires a project ID")
+		}
+		return archiveProject(ctx, projectService, args[1])
+	case "activate", "unarchive":
+		if len(args) < 2 {
+			return fmt.Errorf("project activate requires a project ID")
+		}
+		return activateProject(ctx, projectService, args[1])
+	case "tag":
+		return tagProjectTasks(ctx, projectService, taskService, args[1:])
 	default:
 		return fmt.Errorf("unknown project subcommand: %s", subcommand)
 	}
 }
 
-func handleTimeCommand(timeSvc *timeService.Service, args []string) error {
+func handleTimeCommand(ctx context.Context, timeSvc *timeService.Service, taskService *task.Service, args []string) error {
 	if len(args) == 0 {
 		return showTimeHelp()
 	}
 
-	ctx := context.Background()
 	subcommand := args[0]
 
+	// --task accepts any unambiguous ID prefix; unknown IDs are left for the
+	// subcommand to report
+	for i := 1; i < len(args)-1; i++ {
+		if args[i] != "--task" {
+			continue
+		}
+		taskID, err := taskService.ResolveID(ctx, args[i+1])
+		if errors.Is(err, domain.ErrAmbiguousID) {
+			return err
+		}
+		if err == nil {
+			args[i+1] = taskID
+		}
+	}
+
 	switch subcommand {
 	case "help", "--help", "-h":
 		return showTimeHelp()
 	case "start":
 		return startTimeTracking(ctx, timeSvc, args[1:])
 	case "stop":
-		return stopTimeTracking(ctx, timeSvc)
+		return stopTimeTracking(ctx, timeSvc, args[1:])
+	case "delete", "rm":
+		return deleteTimeEntries(ctx, timeSvc, args[1:])
 	case "report":
 		return generateTimeReport(ctx, timeSvc, args[1:])
 	case "list":
@@ -199,66 +691,97 @@ func handleTimeCommand(timeSvc *timeService.Service, args []string) error {
 	}
 }
 
-func listTasks(ctx context.Context, taskService *task.Service, projectRepo *sqlite.ProjectRepository, args []string) error {
+func listTasks(ctx context.Context, taskService *task.Service, projectRepo *sqlite.ProjectRepository, cfg *domain.Config, args []string) error {
 	// Parse flags
 	options := task.ListOptions{}
 	minimal := false
+	countOnly := false
+	includeArchived := cfg.ShowArchivedProjectTasks
 	for i := 0; i < len(args); i++ {
 		if args[i] == "--project" && i+1 < len(args) {
-			projectNameOrID := args[i+1]
-			// Try to look up project by name first
-			if proj, err := projectRepo.GetByName(ctx, projectNameOrID); err == nil {
-				options.ProjectID = proj.ID
-			} else {
-				// If not found by name, assume it's an ID
-				options.ProjectID = projectNameOrID
-			}
+			options.ProjectID = filterProjectID(ctx, projectRepo, args[i+1])
 			i++ // Skip the next argument as it's the value
 		} else if args[i] == "--workspace" && i+1 < len(args) {
 			options.Workspace = args[i+1]
 			i++ // Skip the next argument as it's the value
 		} else if args[i] == "--status" && i+1 < len(args) {
-			statusStr := args[i+1]
-			var status domain.TaskStatus
-			switch statusStr {
-			case "todo":
-				status = domain.StatusTodo
-			case "doing":
-				status = domain.StatusDoing
-			case "done":
-				status = domain.StatusDone
-			case "blocked":
-				status = domain.StatusBlocked
-			default:
-				return fmt.Errorf("invalid status: %s (must be todo, doing, done, or blocked)", statusStr)
+			status, err := parseTaskStatus(args[i+1])
+			if err != nil {
+				return err
 			}
 			options.Status = append(options.Status, status)
 			i++ // Skip the next argument as it's the value
+		} else if args[i] == "--priority" && i+1 < len(args) {
+			// Accept a comma-separated set such as high,critical
+			for _, name := range strings.Split(args[i+1], ",") {
+				priority, err := domain.ParsePriority(name)
+				if err != nil {
+					return fmt.Errorf("%w: %s (must be low, normal, high, or critical)", domain.ErrInvalidPriority, name)
+				}
+				options.Priority = append(options.Priority, priority)
+			}
+			i++ // Skip the next argument as it's the value
+		} else if (args[i] == "--due-before" || args[i] == "--due-after") && i+1 < len(args) {
+			date, err := taskService.ParseDate(args[i+1])
+			if err != nil {
+				return fmt.Errorf("invalid %s date %q: %w", args[i], args[i+1], err)
+			}
+			// Both bounds cover the whole day they name
+			day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+			if args[i] == "--due-before" {
+				end := day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+				options.DueBefore = &end
+			} else {
+				options.DueAfter = &day
+			}
+			i++ // Skip the next argument as it's the value
 		} else if args[i] == "--minimal" {
 			minimal = true
+		} else if args[i] == "--count" {
+			countOnly = true
+		} else if args[i] == "--overdue-first" {
+			options.OverdueFirst = true
+		} else if args[i] == "--include-archived" {
+			includeArchived = true
 		}
 	}
 
+	// Naming a project shows its tasks even when it is archived
+	options.ExcludeArchivedProjects = !includeArchived && options.ProjectID == ""
+
 	tasks, err := taskService.ListTasks(ctx, options)
 	if err != nil {
 		return fmt.Errorf("failed to list tasks: %w", err)
 	}
 
+	if countOnly {
+		fmt.Println(len(tasks))
+		return nil
+	}
+
 	if len(tasks) == 0 {
 		fmt.Println("No tasks found")
 		return nil
 	}
 
-	fmt.Println("Tasks:")
+	// Look up every listed project at once rather than one per task
+	var projects map[string]*domain.Project
+	if !minimal {
+		projectIDs := make([]string, 0, len(tasks))
+		for _, t := range tasks {
+			projectIDs = append(projectIDs, t.ProjectID)
+		}
+		if projects, err = projectRepo.GetByIDs(ctx, projectIDs); err != nil {
+			return fmt.Errorf("failed to get projects: %w", err)
+		}
+	}
+
+	fmt.Println(taskCountSummary(tasks))
 	for _, t := range tasks {
-		status := "[ ]"
-		switch t.Status {
-		case "doing":
-			status = "[~]"
-		case "done":
-			status = "[x]"
-		case "blocked":
-			status = "[!]"
+		status := taskStatusMarker(t.Status)
+		overdue := ""
+		if t.IsOverdue() {
+			overdue = " " + overdueMarker()
 		}
 
 		if minimal {
@@ -267,7 +790,7 @@ func listTasks(ctx context.Context, taskService *task.Service, projectRepo *sqli
 			if t.Changelist != "" {
 				changelistStr = t.Changelist
 			}
-			fmt.Printf("  %s %s cl:%s (%s)\n", status, t.Title, changelistStr, t.ID)
+			fmt.Printf("  %s %s cl:%s (%s)%s\n", status, t.Title, changelistStr, t.DisplayID(), overdue)
 		} else {
 			// Full format with all details
 			priority := ""
@@ -282,14 +805,12 @@ func listTasks(ctx context.Context, taskService *task.Service, projectRepo *sqli
 				priority = "CRIT"
 			}
 
-			fmt.Printf("  %s [%s] %s (%s)\n", status, priority, t.Title, t.ID)
+			fmt.Printf("  %s [%s] %s (%s)%s\n", status, priority, t.Title, t.DisplayID(), overdue)
 
 			// Get project name if task has a project
 			projectName := ""
-			if t.ProjectID != "" {
-				if proj, err := projectRepo.GetByID(ctx, t.ProjectID); err == nil {
-					projectName = proj.Name
-				}
+			if proj, ok := projects[t.ProjectID]; ok {
+				projectName = proj.Name
 			}
 			fmt.Printf("     * Project: %s\n", projectName)
 
@@ -310,7 +831,7 @@ func listTasks(ctx context.Context, taskService *task.Service, projectRepo *sqli
 			// Show completed date if task is done
 			completedStr := ""
 			if t.CompletedAt != nil {
-				completedStr = t.CompletedAt.Format("2006-01-02 15:04:05")
+				completedStr = ui.FormatDateTime(*t.CompletedAt)
 			}
 			fmt.Printf("     * completed: %s\n", completedStr)
 
@@ -326,92 +847,529 @@ func listTasks(ctx context.Context, taskService *task.Service, projectRepo *sqli
 	return nil
 }
 
-func addTask(ctx context.Context, taskService *task.Service, projectRepo *sqlite.ProjectRepository, args []string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("task add requires a title")
+func searchTasks(ctx context.Context, taskService *task.Service, args []string) error {
+	useFTS := false
+	var terms []string
+	for _, arg := range args {
+		if arg == "--fts" {
+			useFTS = true
+		} else {
+			terms = append(terms, arg)
+		}
 	}
 
-	input := task.CreateTaskInput{
-		Title: args[0],
+	query := strings.Join(terms, " ")
+	if query == "" {
+		return fmt.Errorf("task search requires a query")
 	}
 
-	// Parse flags
-	for i := 1; i < len(args); i++ {
-		switch args[i] {
-		case "--priority":
-			if i+1 < len(args) {
-				i++
-				switch args[i] {
-				case "low":
-					input.Priority = 1
-				case "medium":
-					input.Priority = 2
-				case "high":
-					input.Priority = 3
-				}
-			}
-		case "--tags":
-			if i+1 < len(args) {
-				i++
-				input.Tags = strings.Split(args[i], ",")
-			}
-		case "--changelist", "--cl":
-			if i+1 < len(args) {
-				i++
-				input.Changelist = args[i]
-			}
-		case "--workspace", "--ws":
-			if i+1 < len(args) {
-				i++
-				input.Workspace = args[i]
-			}
-		case "--due":
-			if i+1 < len(args) {
-				i++
-				input.DueDate = args[i]
-			}
-		case "--project":
-			if i+1 < len(args) {
-				i++
-				projectNameOrID := args[i]
-				// Try to look up project by name first
-				if proj, err := projectRepo.GetByName(ctx, projectNameOrID); err == nil {
-					input.ProjectID = proj.ID
-				} else if proj, err := projectRepo.GetByID(ctx, projectNameOrID); err == nil {
-					// Try by ID if name lookup failed
-					input.ProjectID = proj.ID
-				} else {
-					// Project doesn't exist, leave ProjectID empty
-					// This prevents foreign key constraint violations
-					fmt.Fprintf(os.Stderr, "Warning: project '%s' not found, creating task without project\n", projectNameOrID)
-				}
-			}
-		case "--description":
-			if i+1 < len(args) {
-				i++
-				input.Description = args[i]
-			}
-		}
+	var tasks []*domain.Task
+	var err error
+	if useFTS {
+		tasks, err = taskService.SearchTasks(ctx, query)
+	} else {
+		tasks, err = taskService.ListTasks(ctx, task.ListOptions{Search: query})
 	}
-
-	createdTask, err := taskService.CreateTask(ctx, input)
 	if err != nil {
-		return fmt.Errorf("failed to create task: %w", err)
+		return fmt.Errorf("failed to search tasks: %w", err)
+	}
+
+	if len(tasks) == 0 {
+		fmt.Println("No tasks found")
+		return nil
+	}
+
+	fmt.Println("Tasks:")
+	for _, t := range tasks {
+		fmt.Printf("  %s %s (%s)\n", taskStatusMarker(t.Status), t.Title, t.DisplayID())
 	}
 
-	fmt.Printf("Created task: %s (ID: %s)\n", createdTask.Title, createdTask.ID)
 	return nil
 }
 
-func updateTask(ctx context.Context, taskService *task.Service, projectRepo *sqlite.ProjectRepository, args []string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("task update requires a task ID")
+// listBlockedTasks lists blocked tasks, most urgent first, with why each is
+// blocked
+func listBlockedTasks(ctx context.Context, taskService *task.Service) error {
+	tasks, err := taskService.ListBlockedTasks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list blocked tasks: %w", err)
 	}
 
-	taskID := args[0]
+	if len(tasks) == 0 {
+		fmt.Println("No blocked tasks")
+		return nil
+	}
+
+	fmt.Printf("Blocked tasks (%d):\n", len(tasks))
+	for _, t := range tasks {
+		fmt.Printf("  %s [%s] %s (%s)\n", taskStatusMarker(t.Status), t.Priority, t.Title, t.DisplayID())
+		// Without a recorded reason the description usually says why
+		if reason := t.BlockedReason(); reason != "" {
+			fmt.Printf("     Reason: %s\n", reason)
+		} else if t.Description != "" {
+			fmt.Printf("     %s\n", strings.SplitN(t.Description, "\n", 2)[0])
+		}
+	}
+
+	return nil
+}
+
+// nextTask prints the task to work on next and, with --start, starts a
+// timer on it, which also moves it to doing
+func nextTask(ctx context.Context, db *sqlite.DB, taskService *task.Service, taskRepo *sqlite.TaskRepository, projectRepo *sqlite.ProjectRepository, timeEntryRepo *sqlite.TimeEntryRepository, cfg *domain.Config, notifier hooks.Group, args []string) error {
+	start := false
+	for _, arg := range args {
+		switch arg {
+		case "--start":
+			start = true
+		default:
+			return fmt.Errorf("unknown task next flag: %s", arg)
+		}
+	}
+
+	t, err := taskService.NextTask(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find the next task: %w", err)
+	}
+	if t == nil {
+		fmt.Println("No open tasks")
+		return nil
+	}
+
+	fmt.Printf("Next: %s (%s)\n", t.Title, t.DisplayID())
+	fmt.Printf("  Priority: %s  Status: %s", t.Priority, t.Status)
+	if t.DueDate != nil {
+		fmt.Printf("  Due: %s", formatDueDate(*t.DueDate))
+		if t.IsOverdue() {
+			fmt.Print(" " + overdueMarker())
+		}
+	}
+	fmt.Println()
+
+	if !start {
+		return nil
+	}
+
+	timeSvc, err := newTimeService(db, taskRepo, projectRepo, timeEntryRepo, cfg, notifier)
+	if err != nil {
+		return err
+	}
+	entry, err := timeSvc.StartTimeTracking(ctx, timeService.StartTimeEntryInput{TaskID: t.ID})
+	if err != nil {
+		return fmt.Errorf("failed to start time tracking: %w", err)
+	}
+	fmt.Printf("Started tracking time for task %s (Entry ID: %s)\n", t.DisplayID(), entry.ID)
+	return nil
+}
+
+// ageTasks raises the priority of overdue tasks, one level per
+// priority_aging_days (or --every) days overdue
+func ageTasks(ctx context.Context, taskService *task.Service, cfg *domain.Config, args []string) error {
+	days := cfg.PriorityAgingDays
+	dryRun := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--dry-run":
+			dryRun = true
+		case "--every":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--every requires a number of days")
+			}
+			i++
+			every, err := strconv.Atoi(args[i])
+			if err != nil || every <= 0 {
+				return fmt.Errorf("--every must be a positive number of days")
+			}
+			days = every
+		default:
+			return fmt.Errorf("unknown task age flag: %s", args[i])
+		}
+	}
+	if days <= 0 {
+		return fmt.Errorf("priority aging is off; set priority_aging_days or pass --every <days>")
+	}
+
+	aged, err := taskService.AgeOverdueTasks(ctx, agingInterval(days), time.Now(), dryRun)
+	if err != nil {
+		return fmt.Errorf("failed to age tasks: %w", err)
+	}
+	if len(aged) == 0 {
+		fmt.Println("No overdue tasks to raise")
+		return nil
+	}
+
+	verb := "Raised"
+	if dryRun {
+		verb = "Would raise"
+	}
+	for _, a := range aged {
+		fmt.Printf("%s %s (%s) from %s to %s, %d days overdue\n",
+			verb, a.Task.Title, a.Task.DisplayID(), a.From, a.To, int(a.Overdue.Hours()/24))
+	}
+	return nil
+}
+
+// agingInterval is how long a task must stay overdue to be raised a level
+func agingInterval(days int) time.Duration {
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// defaultRecentLimit is how many tasks pm task recent lists without --limit
+const defaultRecentLimit = 10
+
+// recentTasks lists the most recently changed tasks, to pick up where work
+// left off
+func recentTasks(ctx context.Context, taskService *task.Service, args []string) error {
+	limit := defaultRecentLimit
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--limit":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--limit requires a number of tasks")
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("--limit requires a positive number of tasks")
+			}
+			limit = n
+		default:
+			return fmt.Errorf("unknown flag for task recent: %s", args[i])
+		}
+	}
+
+	tasks, err := taskService.ListTasks(ctx, task.ListOptions{
+		SortBy:                  domain.SortByUpdated,
+		Limit:                   limit,
+		ExcludeArchivedProjects: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	if len(tasks) == 0 {
+		fmt.Println("No tasks found")
+		return nil
+	}
+
+	fmt.Println("Recently updated:")
+	for _, t := range tasks {
+		fmt.Printf("  %s %s (%s) %s\n", taskStatusMarker(t.Status), t.Title, t.DisplayID(), ui.FormatDateTime(t.UpdatedAt))
+	}
+
+	return nil
+}
+
+// parseTaskStatus parses a --status value
+func parseTaskStatus(value string) (domain.TaskStatus, error) {
+	switch value {
+	case "todo":
+		return domain.StatusTodo, nil
+	case "doing":
+		return domain.StatusDoing, nil
+	case "done":
+		return domain.StatusDone, nil
+	case "blocked":
+		return domain.StatusBlocked, nil
+	default:
+		return "", fmt.Errorf("%w: %s (must be todo, doing, done, or blocked)", domain.ErrInvalidStatus, value)
+	}
+}
+
+// filterProjectID looks a --project filter value up by name, treating it as
+// an ID when no project has that name
+func filterProjectID(ctx context.Context, projectRepo *sqlite.ProjectRepository, nameOrID string) string {
+	if proj, err := projectRepo.GetByName(ctx, nameOrID); err == nil {
+		return proj.ID
+	}
+	return nameOrID
+}
+
+// parseDayFlag parses the YYYY-MM-DD value of a --since or --until flag as
+// the start of that day in loc
+func parseDayFlag(flag, value string, loc *time.Location) (time.Time, error) {
+	day, err := time.ParseInLocation("2006-01-02", value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s date %q (use YYYY-MM-DD)", flag, value)
+	}
+	return day, nil
+}
+
+// showTaskTree prints the given task, or every top-level task, with its
+// subtasks indented beneath it
+func showTaskTree(ctx context.Context, taskService *task.Service, args []string) error {
+	var roots []*domain.Task
+	if len(args) > 0 {
+		root, err := taskService.GetTask(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get task: %w", err)
+		}
+		roots = []*domain.Task{root}
+	} else {
+		tasks, err := taskService.ListTasks(ctx, task.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+		for _, t := range tasks {
+			if t.ParentID == nil {
+				roots = append(roots, t)
+			}
+		}
+	}
+
+	if len(roots) == 0 {
+		fmt.Println("No tasks found")
+		return nil
+	}
+
+	visited := make(map[string]bool)
+	for _, root := range roots {
+		fmt.Printf("%s %s (%s)\n", taskStatusMarker(root.Status), root.Title, root.DisplayID())
+		visited[root.ID] = true
+		if err := printSubtaskTree(ctx, taskService, root.ID, "", visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// printSubtaskTree prints the subtasks of parentID beneath it, skipping any
+// task already printed so a corrupt hierarchy cannot loop forever
+func printSubtaskTree(ctx context.Context, taskService *task.Service, parentID, indent string, visited map[string]bool) error {
+	subtasks, err := taskService.GetSubtasks(ctx, parentID)
+	if err != nil {
+		return fmt.Errorf("failed to get subtasks: %w", err)
+	}
+
+	for i, subtask := range subtasks {
+		if visited[subtask.ID] {
+			continue
+		}
+		visited[subtask.ID] = true
+
+		branch, childIndent := "├── ", "│   "
+		if i == len(subtasks)-1 {
+			branch, childIndent = "└── ", "    "
+		}
+
+		fmt.Printf("%s%s%s %s (%s)\n", indent, branch, taskStatusMarker(subtask.Status), subtask.Title, subtask.DisplayID())
+		if err := printSubtaskTree(ctx, taskService, subtask.ID, indent+childIndent, visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// taskStatusMarker returns the plain-text checkbox used for a status in CLI output
+func taskStatusMarker(status domain.TaskStatus) string {
+	switch status {
+	case domain.StatusDoing:
+		return "[~]"
+	case domain.StatusDone:
+		return "[x]"
+	case domain.StatusBlocked:
+		return "[!]"
+	default:
+		return "[ ]"
+	}
+}
+
+// overdueMarker flags an overdue task in listings, in red when writing to a
+// terminal and NO_COLOR is not set
+func overdueMarker() string {
+	if os.Getenv("NO_COLOR") != "" || !stdoutIsTerminal() {
+		return "(OVERDUE)"
+	}
+	return "\033[31m(OVERDUE)\033[0m"
+}
+
+// stdoutIsTerminal reports whether output goes to a terminal rather than a
+// pipe or file
+func stdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func addTask(ctx context.Context, taskService *task.Service, projectRepo *sqlite.ProjectRepository, templates map[string]domain.TaskTemplate, editor string, args []string) error {
+	// Pull out --template, --parse, and --edit first, since they may come
+	// before the title
+	var template *domain.TaskTemplate
+	var templateName string
+	parse := false
+	editDescription := false
+	remaining := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--parse" {
+			parse = true
+			continue
+		}
+		if args[i] == "--edit" {
+			editDescription = true
+			continue
+		}
+		if args[i] == "--template" && i+1 < len(args) {
+			i++
+			tmpl, ok := templates[args[i]]
+			if !ok {
+				return fmt.Errorf("unknown template: %s", args[i])
+			}
+			template, templateName = &tmpl, args[i]
+			continue
+		}
+		remaining = append(remaining, args[i])
+	}
+	args = remaining
+
+	if len(args) == 0 {
+		return fmt.Errorf("task add requires a title")
+	}
+
+	if parse && template != nil {
+		return fmt.Errorf("--parse cannot be combined with --template")
+	}
+
+	input := task.CreateTaskInput{
+		Title: args[0],
+	}
+
+	// Read #tag, !priority, @project and due:<date> out of the title;
+	// explicit flags below take precedence
+	if parse {
+		parsed, projectName, err := task.ParseTaskSyntax(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to parse task: %w", err)
+		}
+		input = parsed
+		if projectName != "" {
+			input.ProjectID = resolveProjectID(ctx, projectRepo, projectName)
+		}
+	}
+
+	// Apply template defaults; explicit flags below take precedence
+	if template != nil {
+		input.Title = template.ApplyTitle(args[0])
+		input.Tags = append([]string{}, template.Tags...)
+		priority, ok, err := template.ParsePriority(templateName)
+		if err != nil {
+			return err
+		}
+		if ok {
+			input.Priority = priority
+		}
+		if template.Project != "" {
+			input.ProjectID = resolveProjectID(ctx, projectRepo, template.Project)
+		}
+	}
+
+	// Parse flags
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "--priority":
+			if i+1 < len(args) {
+				i++
+				switch args[i] {
+				case "low":
+					input.Priority = 1
+				case "medium":
+					input.Priority = 2
+				case "high":
+					input.Priority = 3
+				}
+			}
+		case "--tags":
+			if i+1 < len(args) {
+				i++
+				// Template tags are kept alongside the ones given here
+				input.Tags = append(input.Tags, strings.Split(args[i], ",")...)
+			}
+		case "--changelist", "--cl":
+			if i+1 < len(args) {
+				i++
+				input.Changelist = args[i]
+			}
+		case "--workspace", "--ws":
+			if i+1 < len(args) {
+				i++
+				input.Workspace = args[i]
+			}
+		case "--due":
+			if i+1 < len(args) {
+				i++
+				input.DueDate = args[i]
+			}
+		case "--project":
+			if i+1 < len(args) {
+				i++
+				input.ProjectID = resolveProjectID(ctx, projectRepo, args[i])
+			}
+		case "--description":
+			if i+1 < len(args) {
+				i++
+				input.Description = args[i]
+			}
+		case "--estimate":
+			if i+1 < len(args) {
+				i++
+				input.Estimate = args[i]
+			}
+		case "--allow-past":
+			input.AllowPastDue = true
+		case "--parent":
+			if i+1 < len(args) {
+				i++
+				parentID := args[i]
+				input.ParentID = &parentID
+			}
+		}
+	}
+
+	// Write the description in the editor, starting from any --description
+	if editDescription {
+		description, err := editText(editor, "description", input.Description)
+		if err != nil {
+			return err
+		}
+		input.Description = description
+	}
+
+	createdTask, err := taskService.CreateTask(ctx, input)
+	if errors.Is(err, domain.ErrInvalidDueDate) {
+		return fmt.Errorf("failed to create task: %w (use --allow-past to keep it)", err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create task: %w", err)
+	}
+
+	fmt.Printf("Created task: %s (ID: %s)\n", createdTask.Title, taskIDLabel(createdTask))
+	return nil
+}
+
+// resolveProjectID looks up a project by name, then by ID. Unknown projects
+// resolve to an empty ID with a warning, which prevents foreign key
+// constraint violations.
+func resolveProjectID(ctx context.Context, projectRepo *sqlite.ProjectRepository, projectNameOrID string) string {
+	if proj, err := projectRepo.GetByName(ctx, projectNameOrID); err == nil {
+		return proj.ID
+	}
+	if proj, err := projectRepo.GetByID(ctx, projectNameOrID); err == nil {
+		return proj.ID
+	}
+	fmt.Fprintf(os.Stderr, "Warning: project '%s' not found, creating task without project\n", projectNameOrID)
+	return ""
+}
+
+func updateTask(ctx context.Context, taskService *task.Service, projectRepo *sqlite.ProjectRepository, editor string, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("task update requires a task ID")
+	}
+
+	taskID := args[0]
 	input := task.UpdateTaskInput{
 		ID: taskID,
 	}
+	editDescription := false
 
 	// Parse flags
 	for i := 1; i < len(args); i++ {
@@ -442,7 +1400,7 @@ func updateTask(ctx context.Context, taskService *task.Service, projectRepo *sql
 				case "blocked":
 					status = domain.StatusBlocked
 				default:
-					return fmt.Errorf("invalid status: %s (must be todo, doing, done, or blocked)", args[i])
+					return fmt.Errorf("%w: %s (must be todo, doing, done, or blocked)", domain.ErrInvalidStatus, args[i])
 				}
 				input.Status = &status
 			}
@@ -460,7 +1418,7 @@ func updateTask(ctx context.Context, taskService *task.Service, projectRepo *sql
 				case "critical":
 					priority = domain.PriorityCritical
 				default:
-					return fmt.Errorf("invalid priority: %s (must be low, normal, high, or critical)", args[i])
+					return fmt.Errorf("%w: %s (must be low, normal, high, or critical)", domain.ErrInvalidPriority, args[i])
 				}
 				input.Priority = &priority
 			}
@@ -482,6 +1440,22 @@ func updateTask(ctx context.Context, taskService *task.Service, projectRepo *sql
 				dueDate := args[i]
 				input.DueDate = &dueDate
 			}
+		case "--estimate":
+			if i+1 < len(args) {
+				i++
+				estimate := args[i]
+				input.Estimate = &estimate
+			}
+		case "--allow-past":
+			input.AllowPastDue = true
+		case "--edit":
+			editDescription = true
+		case "--reason":
+			if i+1 < len(args) {
+				i++
+				reason := args[i]
+				input.BlockedReason = &reason
+			}
 		case "--project":
 			if i+1 < len(args) {
 				i++
@@ -501,7 +1475,27 @@ func updateTask(ctx context.Context, taskService *task.Service, projectRepo *sql
 		}
 	}
 
+	// Edit the current description, or the one given with --description
+	if editDescription {
+		current := input.Description
+		if current == nil {
+			existing, err := taskService.GetTask(ctx, taskID)
+			if err != nil {
+				return err
+			}
+			current = &existing.Description
+		}
+		description, err := editText(editor, "description", *current)
+		if err != nil {
+			return err
+		}
+		input.Description = &description
+	}
+
 	updatedTask, err := taskService.UpdateTask(ctx, input)
+	if errors.Is(err, domain.ErrInvalidDueDate) {
+		return fmt.Errorf("failed to update task: %w (use --allow-past to keep it)", err)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to update task: %w", err)
 	}
@@ -510,7 +1504,29 @@ func updateTask(ctx context.Context, taskService *task.Service, projectRepo *sql
 	return nil
 }
 
-func completeTask(ctx context.Context, taskService *task.Service, taskID string) error {
+func completeTask(ctx context.Context, taskService *task.Service, args []string) error {
+	taskID := args[0]
+	withSubtasks := false
+	for _, arg := range args[1:] {
+		if arg == "--with-subtasks" {
+			withSubtasks = true
+		}
+	}
+
+	if withSubtasks {
+		subtasks, err := taskService.CompleteTaskWithSubtasks(ctx, taskID)
+		if err != nil {
+			return fmt.Errorf("failed to complete task: %w", err)
+		}
+		fmt.Printf("Task %s completed along with %d subtask(s)\n", taskID, len(subtasks))
+		return nil
+	}
+
+	// Warn rather than fail when open subtasks are left behind
+	if open, err := taskService.GetOpenSubtasks(ctx, taskID); err == nil && len(open) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: task has %d open subtask(s); use --with-subtasks to complete them too\n", len(open))
+	}
+
 	err := taskService.CompleteTask(ctx, taskID)
 	if err != nil {
 		return fmt.Errorf("failed to complete task: %w", err)
@@ -520,7 +1536,201 @@ func completeTask(ctx context.Context, taskService *task.Service, taskID string)
 	return nil
 }
 
-func deleteTask(ctx context.Context, taskService *task.Service, taskID string) error {
+func startTask(ctx context.Context, taskService *task.Service, taskID string) error {
+	if err := taskService.StartTask(ctx, taskID); err != nil {
+		return fmt.Errorf("failed to start task: %w", err)
+	}
+
+	fmt.Printf("Task %s started\n", taskID)
+	return nil
+}
+
+// parseBlockReason reads the reason given to pm task block, either as the
+// words after the task ID or with --reason <text>
+func parseBlockReason(args []string) (string, error) {
+	var words []string
+	flagReason := ""
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--reason":
+			if i+1 >= len(args) {
+				return "", fmt.Errorf("--reason requires the reason the task is blocked")
+			}
+			i++
+			flagReason = args[i]
+		case strings.HasPrefix(args[i], "--"):
+			return "", fmt.Errorf("unknown task block flag: %s", args[i])
+		default:
+			words = append(words, args[i])
+		}
+	}
+	if flagReason != "" && len(words) > 0 {
+		return "", fmt.Errorf("give the reason either after the task ID or with --reason, not both")
+	}
+	if flagReason != "" {
+		return flagReason, nil
+	}
+	return strings.Join(words, " "), nil
+}
+
+func blockTask(ctx context.Context, taskService *task.Service, taskID, reason string) error {
+	if err := taskService.BlockTask(ctx, taskID, strings.TrimSpace(reason)); err != nil {
+		return fmt.Errorf("failed to block task: %w", err)
+	}
+
+	fmt.Printf("Task %s blocked\n", taskID)
+	return nil
+}
+
+func snoozeTask(ctx context.Context, taskService *task.Service, taskID, until string) error {
+	result, err := taskService.SnoozeTask(ctx, taskID, until)
+	if err != nil {
+		return fmt.Errorf("failed to snooze task: %w", err)
+	}
+
+	previous := "no due date"
+	if result.PreviousDue != nil {
+		previous = formatDueDate(*result.PreviousDue)
+	}
+	fmt.Printf("Snoozed %s (%s): %s -> %s\n", result.Task.Title, result.Task.DisplayID(), previous, formatDueDate(*result.Task.DueDate))
+	return nil
+}
+
+// formatDueDate shows a due date, with its time of day unless it is midnight
+func formatDueDate(due time.Time) string {
+	if due.Hour() == 0 && due.Minute() == 0 {
+		return ui.FormatDate(due)
+	}
+	return ui.FormatDateTime(due)
+}
+
+func cloneTask(ctx context.Context, taskService *task.Service, args []string) error {
+	taskID := args[0]
+	withSubtasks := false
+	for _, arg := range args[1:] {
+		if arg == "--with-subtasks" {
+			withSubtasks = true
+		}
+	}
+
+	clone, err := taskService.CloneTask(ctx, taskID, withSubtasks)
+	if err != nil {
+		return fmt.Errorf("failed to clone task: %w", err)
+	}
+
+	fmt.Printf("Cloned task: %s (ID: %s)\n", clone.Title, taskIDLabel(clone))
+	return nil
+}
+
+// taskIDLabel returns a task's full ID, preceded by its sequential reference
+// when it has one
+func taskIDLabel(t *domain.Task) string {
+	if t.Ref != "" {
+		return t.Ref + ", " + t.ID
+	}
+	return t.ID
+}
+
+// showTask prints a task's details followed by its comments
+func showTask(ctx context.Context, taskService *task.Service, projectRepo *sqlite.ProjectRepository, taskID string) error {
+	t, err := taskService.GetTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	fmt.Printf("%s (%s)\n", t.Title, taskIDLabel(t))
+	fmt.Printf("  Status:    %s\n", t.Status)
+	if reason := t.BlockedReason(); reason != "" {
+		fmt.Printf("  Blocked:   %s\n", reason)
+	}
+	fmt.Printf("  Priority:  %s\n", t.Priority)
+	if t.ProjectID != "" {
+		projectName := t.ProjectID
+		if proj, err := projectRepo.GetByID(ctx, t.ProjectID); err == nil {
+			projectName = proj.Name
+		}
+		fmt.Printf("  Project:   %s\n", projectName)
+	}
+	if len(t.Tags) > 0 {
+		fmt.Printf("  Tags:      %s\n", strings.Join(t.Tags, ", "))
+	}
+	if t.DueDate != nil {
+		fmt.Printf("  Due:       %s\n", ui.FormatDate(*t.DueDate))
+	}
+	if t.Changelist != "" {
+		fmt.Printf("  cl:        %s\n", t.Changelist)
+	}
+	for i, ref := range t.Attachments() {
+		label := ""
+		if i == 0 {
+			label = "Attached:"
+		}
+		fmt.Printf("  %-10s %s\n", label, attachmentLabel(ref))
+	}
+	fmt.Printf("  Created:   %s\n", ui.FormatDateTime(t.CreatedAt))
+	if t.CompletedAt != nil {
+		fmt.Printf("  Completed: %s\n", ui.FormatDateTime(*t.CompletedAt))
+	}
+	if t.Description != "" {
+		fmt.Printf("\n%s\n", t.Description)
+	}
+
+	comments, err := taskService.ListComments(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if len(comments) == 0 {
+		return nil
+	}
+
+	fmt.Printf("\nComments (%d):\n", len(comments))
+	for _, c := range comments {
+		fmt.Printf("  [%s] %s\n", ui.FormatDateTime(c.CreatedAt), c.Body)
+	}
+	return nil
+}
+
+func commentOnTask(ctx context.Context, taskService *task.Service, taskID, body string) error {
+	if _, err := taskService.AddComment(ctx, taskID, body); err != nil {
+		return err
+	}
+
+	fmt.Printf("Added comment to task %s\n", taskID)
+	return nil
+}
+
+func deleteTask(ctx context.Context, taskService *task.Service, taskRepo domain.TaskRepository, timeEntryRepo domain.TimeEntryRepository, args []string) error {
+	var taskID string
+	dryRun := false
+	for _, arg := range args {
+		switch arg {
+		case "--dry-run":
+			dryRun = true
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return fmt.Errorf("%w for task delete: %s", errUnknownFlag, arg)
+			}
+			taskID = arg
+		}
+	}
+	if taskID == "" {
+		return fmt.Errorf("task delete requires a task ID")
+	}
+
+	if dryRun {
+		t, err := taskService.GetTask(ctx, taskID)
+		if err != nil {
+			return fmt.Errorf("failed to get task: %w", err)
+		}
+		impact, err := taskDeletionImpact(ctx, taskRepo, timeEntryRepo, []*domain.Task{t}, "")
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Dry run: deleting task %q would remove %d subtasks and %d time entries\n",
+			t.Title, impact.Tasks-1, impact.TimeEntries)
+		return nil
+	}
+
 	err := taskService.DeleteTask(ctx, taskID)
 	if err != nil {
 		return fmt.Errorf("failed to delete task: %w", err)
@@ -530,12 +1740,27 @@ func deleteTask(ctx context.Context, taskService *task.Service, taskID string) e
 	return nil
 }
 
-func listProjects(ctx context.Context, projectService *project.Service) error {
+func listProjects(ctx context.Context, projectService *project.Service, args []string) error {
+	countOnly := false
+	for _, arg := range args {
+		switch arg {
+		case "--count":
+			countOnly = true
+		default:
+			return fmt.Errorf("unknown project list flag: %s", arg)
+		}
+	}
+
 	projects, err := projectService.ListProjects(ctx, project.ListOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to list projects: %w", err)
 	}
 
+	if countOnly {
+		fmt.Println(len(projects))
+		return nil
+	}
+
 	if len(projects) == 0 {
 		fmt.Println("No projects found")
 		return nil
@@ -554,32 +1779,147 @@ func addProject(ctx context.Context, projectService *project.Service, name strin
 		Name: name,
 	}
 
-	createdProject, err := projectService.CreateProject(ctx, input)
+	createdProject, err := projectService.CreateProject(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+
+	fmt.Printf("Created project: %s (ID: %s)\n", createdProject.Name, createdProject.ID)
+	return nil
+}
+
+func deleteProject(ctx context.Context, projectService *project.Service, taskRepo domain.TaskRepository, timeEntryRepo domain.TimeEntryRepository, args []string) error {
+	var projectID string
+	dryRun := false
+	skipConfirm := false
+	for _, arg := range args {
+		switch arg {
+		case "--dry-run":
+			dryRun = true
+		case "--yes", "-y":
+			skipConfirm = true
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return fmt.Errorf("%w for project delete: %s", errUnknownFlag, arg)
+			}
+			projectID = arg
+		}
+	}
+	if projectID == "" {
+		return fmt.Errorf("project delete requires a project ID")
+	}
+
+	p, err := projectService.GetProject(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	impact, err := projectDeletionImpact(ctx, taskRepo, timeEntryRepo, p.ID)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		fmt.Printf("Dry run: deleting project %q would remove %d tasks and %d time entries\n",
+			p.Name, impact.Tasks, impact.TimeEntries)
+		return nil
+	}
+
+	// The cascade is silent, so spell it out before anything is removed
+	if !skipConfirm && (impact.Tasks > 0 || impact.TimeEntries > 0) {
+		prompt := fmt.Sprintf("Deleting project %q will delete %d tasks and %d time entries. Continue?",
+			p.Name, impact.Tasks, impact.TimeEntries)
+		if confirmed, err := confirm(prompt); err != nil || !confirmed {
+			fmt.Println("Delete cancelled")
+			return err
+		}
+	}
+
+	if err := projectService.DeleteProject(ctx, p.ID); err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	fmt.Printf("Project %s deleted along with %d tasks and %d time entries\n", p.Name, impact.Tasks, impact.TimeEntries)
+	return nil
+}
+
+// tagProjectTasks adds or removes a tag on every task in a project
+func tagProjectTasks(ctx context.Context, projectService *project.Service, taskService *task.Service, args []string) error {
+	var projectNameOrID, addTag, removeTag string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--add":
+			if i+1 < len(args) {
+				i++
+				addTag = args[i]
+			}
+		case "--remove", "--rm":
+			if i+1 < len(args) {
+				i++
+				removeTag = args[i]
+			}
+		default:
+			projectNameOrID = args[i]
+		}
+	}
+	if projectNameOrID == "" {
+		return fmt.Errorf("project tag requires a project name or ID")
+	}
+	if (addTag == "") == (removeTag == "") {
+		return fmt.Errorf("project tag requires either --add <tag> or --remove <tag>")
+	}
+
+	// Resolve by name first, then by ID
+	proj, err := projectService.GetProjectByName(ctx, projectNameOrID)
+	if err != nil {
+		proj, err = projectService.GetProject(ctx, projectNameOrID)
+		if err != nil {
+			return fmt.Errorf("project '%s' not found: %w", projectNameOrID, domain.ErrProjectNotFound)
+		}
+	}
+
+	if addTag != "" {
+		count, err := taskService.AddProjectTag(ctx, proj.ID, addTag)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Tagged %d task(s) in %s with %s\n", count, proj.Name, addTag)
+		return nil
+	}
+
+	count, err := taskService.RemoveProjectTag(ctx, proj.ID, removeTag)
 	if err != nil {
-		return fmt.Errorf("failed to create project: %w", err)
+		return err
+	}
+	fmt.Printf("Removed tag %s from %d task(s) in %s\n", removeTag, count, proj.Name)
+	return nil
+}
+
+func archiveProject(ctx context.Context, projectService *project.Service, projectID string) error {
+	if err := projectService.ArchiveProject(ctx, projectID); err != nil {
+		return fmt.Errorf("failed to archive project: %w", err)
 	}
 
-	fmt.Printf("Created project: %s (ID: %s)\n", createdProject.Name, createdProject.ID)
+	fmt.Printf("Project %s archived; its tasks are hidden from task lists (use --include-archived to see them)\n", projectID)
 	return nil
 }
 
-func deleteProject(ctx context.Context, projectService *project.Service, projectID string) error {
-	err := projectService.DeleteProject(ctx, projectID)
-	if err != nil {
-		return fmt.Errorf("failed to delete project: %w", err)
+func activateProject(ctx context.Context, projectService *project.Service, projectID string) error {
+	if err := projectService.ActivateProject(ctx, projectID); err != nil {
+		return fmt.Errorf("failed to activate project: %w", err)
 	}
 
-	fmt.Printf("Project %s deleted (associated tasks and time entries also deleted)\n", projectID)
+	fmt.Printf("Project %s activated\n", projectID)
 	return nil
 }
 
 // Time tracking handlers
 func startTimeTracking(ctx context.Context, timeSvc *timeService.Service, args []string) error {
 	if len(args) < 1 {
-		return fmt.Errorf("time start requires --task flag")
+		return fmt.Errorf("time start requires --task or --category flag")
 	}
 
 	var taskID string
+	var category string
 	var description string
 
 	for i := 0; i < len(args); i++ {
@@ -589,6 +1929,11 @@ func startTimeTracking(ctx context.Context, timeSvc *timeService.Service, args [
 				i++
 				taskID = args[i]
 			}
+		case "--category":
+			if i+1 < len(args) {
+				i++
+				category = args[i]
+			}
 		case "--description":
 			if i+1 < len(args) {
 				i++
@@ -597,139 +1942,384 @@ func startTimeTracking(ctx context.Context, timeSvc *timeService.Service, args [
 		}
 	}
 
-	if taskID == "" {
-		return fmt.Errorf("--task flag is required")
+	if (taskID == "") == (category == "") {
+		return fmt.Errorf("exactly one of --task or --category is required")
 	}
 
 	entry, err := timeSvc.StartTimeTracking(ctx, timeService.StartTimeEntryInput{
 		TaskID:      taskID,
+		Category:    category,
 		Description: description,
 	})
+	if errors.Is(err, domain.ErrTaskNotFound) {
+		return fmt.Errorf("%w: no task with ID %s (run 'pm task list' to see task IDs)", domain.ErrTaskNotFound, taskID)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to start time tracking: %w", err)
 	}
 
+	if category != "" {
+		fmt.Printf("Started tracking %s time (Entry ID: %s)\n", entry.Category, entry.ID)
+		return nil
+	}
 	fmt.Printf("Started tracking time for task %s (Entry ID: %s)\n", taskID, entry.ID)
 	return nil
 }
 
-func stopTimeTracking(ctx context.Context, timeSvc *timeService.Service) error {
-	entry, err := timeSvc.StopTimeTracking(ctx)
+func stopTimeTracking(ctx context.Context, timeSvc *timeService.Service, args []string) error {
+	capAtMax := false
+	for _, arg := range args {
+		if arg == "--cap" {
+			capAtMax = true
+		}
+	}
+
+	var entry *domain.TimeEntry
+	var err error
+	if capAtMax {
+		entry, err = timeSvc.StopTimeTrackingCapped(ctx)
+	} else {
+		entry, err = timeSvc.StopTimeTracking(ctx)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to stop time tracking: %w", err)
 	}
 
 	duration := timeSvc.FormatDuration(entry.GetDuration())
 	fmt.Printf("Stopped tracking time. Duration: %s (Entry ID: %s)\n", duration, entry.ID)
+
+	if capAtMax && entry.GetDuration() == timeSvc.MaxTimerDuration() {
+		fmt.Println("The entry ran past max_timer_hours and was capped at the limit.")
+	} else if timeSvc.ExceedsMaxDuration(entry) {
+		fmt.Printf("Warning: this timer ran longer than %s and may have been left running.\n",
+			timeSvc.FormatDuration(timeSvc.MaxTimerDuration()))
+		fmt.Println("Use 'pm time stop --cap' next time to end such an entry at the limit.")
+	}
 	return nil
 }
 
-func generateTimeReport(ctx context.Context, timeSvc *timeService.Service, args []string) error {
-	var report *timeService.TimeReport
-	var err error
+func deleteTimeEntries(ctx context.Context, timeSvc *timeService.Service, args []string) error {
+	var entryID, taskID string
+	all := false
+	skipConfirm := false
+
+	// Parse flags
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--task":
+			if i+1 < len(args) {
+				i++
+				taskID = args[i]
+			}
+		case "--all":
+			all = true
+		case "--yes", "-y":
+			skipConfirm = true
+		default:
+			entryID = args[i]
+		}
+	}
+
+	if taskID != "" {
+		if !all {
+			return fmt.Errorf("deleting by task requires --all")
+		}
+
+		entries, err := timeSvc.GetTimeEntriesByTask(ctx, taskID)
+		if err != nil {
+			return fmt.Errorf("failed to list time entries: %w", err)
+		}
+		if len(entries) == 0 {
+			fmt.Printf("No time entries found for task %s\n", taskID)
+			return nil
+		}
+
+		var total time.Duration
+		for _, entry := range entries {
+			total += entry.GetDuration()
+		}
+		if !skipConfirm {
+			prompt := fmt.Sprintf("Delete %d time entries (%s) for task %s?", len(entries), timeSvc.FormatDuration(total), taskID)
+			if confirmed, err := confirm(prompt); err != nil || !confirmed {
+				fmt.Println("Delete cancelled")
+				return err
+			}
+		}
+
+		deleted, err := timeSvc.DeleteTimeEntriesByTask(ctx, taskID)
+		if err != nil {
+			return fmt.Errorf("failed to delete time entries: %w", err)
+		}
+		fmt.Printf("Deleted %d time entries for task %s\n", deleted, taskID)
+		return nil
+	}
+
+	if entryID == "" {
+		return fmt.Errorf("time delete requires an entry ID or --task <task-id> --all")
+	}
+
+	entry, err := timeSvc.GetTimeEntry(ctx, entryID)
+	if err != nil {
+		return err
+	}
+	if !skipConfirm {
+		prompt := fmt.Sprintf("Delete time entry %s (%s, started %s)?", entry.ID,
+			timeSvc.FormatDuration(entry.GetDuration()), ui.FormatDateTime(entry.StartTime.In(timeSvc.Location())))
+		if confirmed, err := confirm(prompt); err != nil || !confirmed {
+			fmt.Println("Delete cancelled")
+			return err
+		}
+	}
+
+	if err := timeSvc.DeleteTimeEntry(ctx, entry.ID); err != nil {
+		return err
+	}
+	fmt.Printf("Time entry %s deleted\n", entry.ID)
+	return nil
+}
+
+// confirm asks a yes/no question on stdin; anything but y or yes declines
+func confirm(prompt string) (bool, error) {
+	defer pauseCommandTimeout()()
+	fmt.Printf("%s [y/N]: ", prompt)
+
+	reader := bufio.NewReader(os.Stdin)
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		return false, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	input = strings.ToLower(strings.TrimSpace(input))
+	return input == "y" || input == "yes", nil
+}
 
+func generateTimeReport(ctx context.Context, timeSvc *timeService.Service, args []string) error {
 	// Parse flags
-	reportType := "today"
+	period := timeService.ReportToday
+	rounding := timeSvc.Rounding()
+	var reportOptions timeService.ReportOptions
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
+		case "--include-active":
+			reportOptions.IncludeActive = true
 		case "--today":
-			reportType = "today"
+			period = timeService.ReportToday
 		case "--week":
-			reportType = "week"
+			period = timeService.ReportWeek
 		case "--month":
-			reportType = "month"
+			period = timeService.ReportMonth
 		case "--yesterday":
-			reportType = "yesterday"
+			period = timeService.ReportYesterday
+		case "--round":
+			if i+1 < len(args) {
+				i++
+				minutes, err := strconv.Atoi(args[i])
+				if err != nil || minutes < 0 {
+					return fmt.Errorf("--round requires a number of minutes")
+				}
+				rounding.Increment = time.Duration(minutes) * time.Minute
+			}
+		case "--round-mode":
+			if i+1 < len(args) {
+				i++
+				mode, err := timeService.ParseRoundingMode(args[i])
+				if err != nil {
+					return err
+				}
+				rounding.Mode = mode
+			}
 		}
 	}
 
-	switch reportType {
-	case "today":
-		report, err = timeSvc.GetTodayReport(ctx)
-	case "yesterday":
-		now := time.Now()
-		yesterday := now.AddDate(0, 0, -1)
-		startOfDay := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 0, 0, 0, 0, yesterday.Location())
-		endOfDay := startOfDay.Add(24 * time.Hour)
-		report, err = timeSvc.GenerateReport(ctx, startOfDay, endOfDay)
-	case "week":
-		report, err = timeSvc.GetWeekReport(ctx)
-	case "month":
-		report, err = timeSvc.GetMonthReport(ctx)
-	}
-
+	// The report prints only totals, so the database sums the entries
+	start, end := timeSvc.ReportRange(period)
+	report, err := timeSvc.GenerateSummaryReport(ctx, start, end, reportOptions)
 	if err != nil {
 		return fmt.Errorf("failed to generate report: %w", err)
 	}
+	report.Round(rounding)
 
 	fmt.Printf("Time Tracking Report\n")
 	fmt.Printf("===================\n")
-	fmt.Printf("Total Duration: %s\n\n", timeSvc.FormatDuration(report.TotalDuration))
+	fmt.Printf("Total Duration: %s\n", timeSvc.FormatDuration(report.TotalDuration))
+	if report.Rounding.Increment > 0 {
+		fmt.Printf("Rounded %s to %s per task\n", report.Rounding.Mode, timeSvc.FormatDuration(report.Rounding.Increment))
+	}
+	if report.Running != nil {
+		fmt.Printf("Not counted: the timer running since %s (%s so far); use --include-active to count it\n",
+			ui.FormatTime(report.Running.StartTime.In(timeSvc.Location())), timeSvc.FormatDuration(report.Running.GetDuration()))
+	}
+	fmt.Println()
 
 	if len(report.ByTask) > 0 {
 		fmt.Println("By Task:")
 		for _, taskReport := range report.ByTask {
+			if taskReport.Estimate > 0 {
+				fmt.Printf("  %s: %s (estimate %s, %s)\n",
+					taskReport.TaskTitle,
+					timeSvc.FormatDuration(taskReport.TotalDuration),
+					timeSvc.FormatDuration(taskReport.Estimate),
+					formatVariance(timeSvc, taskReport.Variance),
+				)
+				continue
+			}
 			fmt.Printf("  %s: %s\n", taskReport.TaskTitle, timeSvc.FormatDuration(taskReport.TotalDuration))
 		}
 	}
 
+	if len(report.ByCategory) > 0 {
+		fmt.Println("By Category:")
+		for _, categoryReport := range report.ByCategory {
+			fmt.Printf("  %s: %s\n", categoryReport.Category, timeSvc.FormatDuration(categoryReport.TotalDuration))
+		}
+	}
+
+	if len(report.ByProject) > 0 {
+		fmt.Println("By Project:")
+		for _, projectReport := range report.ByProject {
+			name := projectReport.ProjectName
+			if projectReport.ProjectID == "" {
+				name = "(no project)"
+			}
+			fmt.Printf("  %s: %s\n", name, timeSvc.FormatDuration(projectReport.TotalDuration))
+		}
+	}
+
 	return nil
 }
 
+// formatVariance describes how far tracked time is over or under an estimate
+func formatVariance(timeSvc *timeService.Service, variance time.Duration) string {
+	switch {
+	case variance > 0:
+		return timeSvc.FormatDuration(variance) + " over"
+	case variance < 0:
+		return timeSvc.FormatDuration(-variance) + " under"
+	default:
+		return "on estimate"
+	}
+}
+
 func listTimeEntries(ctx context.Context, timeSvc *timeService.Service, args []string) error {
-	entries, err := timeSvc.ListTimeEntries(ctx, timeService.ListOptions{})
+	options := timeService.ListOptions{}
+	countOnly := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--since", "--until":
+			if i+1 >= len(args) {
+				return fmt.Errorf("%s requires a date (YYYY-MM-DD)", args[i])
+			}
+			day, err := parseDayFlag(args[i], args[i+1], timeSvc.Location())
+			if err != nil {
+				return err
+			}
+			// Both bounds cover the whole day they name
+			if args[i] == "--since" {
+				options.StartAfter = &day
+			} else {
+				end := day.AddDate(0, 0, 1)
+				options.EndBefore = &end
+			}
+			i++
+		case "--task":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--task requires a task ID")
+			}
+			options.TaskID = args[i+1]
+			i++
+		case "--active":
+			active := true
+			options.Active = &active
+		case "--count":
+			countOnly = true
+		}
+	}
+
+	entries, err := timeSvc.ListTimeEntries(ctx, options)
 	if err != nil {
 		return fmt.Errorf("failed to list time entries: %w", err)
 	}
 
+	if countOnly {
+		fmt.Println(len(entries))
+		return nil
+	}
+
 	if len(entries) == 0 {
 		fmt.Println("No time entries found")
 		return nil
 	}
 
 	fmt.Println("Time Entries:")
+	var total time.Duration
 	for _, entry := range entries {
+		total += entry.GetDuration()
 		status := "Active"
 		duration := "In progress"
 		if entry.EndTime != nil {
 			status = "Completed"
 			duration = timeSvc.FormatDuration(entry.GetDuration())
+		} else if timeSvc.ExceedsMaxDuration(entry) {
+			// A timer this old was most likely forgotten
+			duration = fmt.Sprintf("running %s, over the %s limit",
+				timeSvc.FormatDuration(entry.GetDuration()),
+				timeSvc.FormatDuration(timeSvc.MaxTimerDuration()))
+		}
+		target := "Task: " + entry.TaskID
+		if entry.TaskID == "" {
+			target = "Category: " + entry.Category
 		}
-		fmt.Printf("  [%s] Task: %s | Duration: %s | Started: %s\n",
-			status, entry.TaskID, duration, entry.StartTime.Format("2006-01-02 15:04"))
+		if entry.Description != "" {
+			target += " - " + entry.Description
+		}
+		fmt.Printf("  [%s] %s | %s | Duration: %s | Started: %s\n",
+			status, entry.ID, target, duration, ui.FormatDateTime(entry.StartTime.In(timeSvc.Location())))
 	}
 
+	// Running timers count up to now
+	fmt.Printf("\nTotal: %s across %d entries\n", timeSvc.FormatDuration(total), len(entries))
+
 	return nil
 }
 
 // Export handlers
-func handleExportCommand(exportSvc *export.Service, args []string) error {
+func handleExportCommand(ctx context.Context, exportSvc *export.Service, projectRepo *sqlite.ProjectRepository, loc *time.Location, args []string) error {
 	if len(args) == 0 {
 		return showExportHelp()
 	}
 
-	ctx := context.Background()
 	subcommand := args[0]
 
 	switch subcommand {
 	case "help", "--help", "-h":
 		return showExportHelp()
 	case "tasks":
-		return exportTasks(ctx, exportSvc, args[1:])
+		return exportTasks(ctx, exportSvc, projectRepo, loc, args[1:])
 	case "time":
-		return exportTime(ctx, exportSvc, args[1:])
+		return exportTime(ctx, exportSvc, projectRepo, loc, args[1:])
+	case "projects":
+		return exportProjects(ctx, exportSvc, args[1:])
 	default:
 		return fmt.Errorf("unknown export subcommand: %s", subcommand)
 	}
 }
 
-func exportTasks(ctx context.Context, exportSvc *export.Service, args []string) error {
+func exportTasks(ctx context.Context, exportSvc *export.Service, projectRepo *sqlite.ProjectRepository, loc *time.Location, args []string) error {
 	format := "json"
 	output := ""
+	outputDir := ""
+	allFormats := false
+	filter := domain.TaskFilter{}
+	icalMode := export.ICALModeTodo
+	csvOptions := export.DefaultExportOptions()
 
 	// Parse flags
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
+		case "--delimiter", "--no-header", "--excel":
+			if err := parseCSVFlag(args, &i, &csvOptions); err != nil {
+				return err
+			}
 		case "--format":
 			if i+1 < len(args) {
 				i++
@@ -740,48 +2330,259 @@ func exportTasks(ctx context.Context, exportSvc *export.Service, args []string)
 				i++
 				output = args[i]
 			}
+		case "--output-dir":
+			if i+1 < len(args) {
+				i++
+				outputDir = args[i]
+			}
+		case "--all-formats":
+			allFormats = true
+		case "--mode":
+			if i+1 < len(args) {
+				i++
+				mode, err := export.ParseICALMode(args[i])
+				if err != nil {
+					return err
+				}
+				icalMode = mode
+			}
+		case "--status":
+			if i+1 < len(args) {
+				i++
+				// Accept a comma-separated set such as done,blocked
+				for _, value := range strings.Split(args[i], ",") {
+					status, err := parseTaskStatus(value)
+					if err != nil {
+						return err
+					}
+					filter.Status = append(filter.Status, status)
+				}
+			}
+		case "--project":
+			if i+1 < len(args) {
+				i++
+				filter.ProjectID = filterProjectID(ctx, projectRepo, args[i])
+			}
+		case "--tags":
+			if i+1 < len(args) {
+				i++
+				// A task must carry every tag listed
+				filter.Tags = strings.Split(args[i], ",")
+			}
+		case "--since", "--until":
+			if i+1 >= len(args) {
+				return fmt.Errorf("%s requires a date (YYYY-MM-DD)", args[i])
+			}
+			day, err := parseDayFlag(args[i], args[i+1], loc)
+			if err != nil {
+				return err
+			}
+			// Both bounds cover the whole day they name
+			if args[i] == "--since" {
+				filter.CreatedAfter = &day
+			} else {
+				end := day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+				filter.CreatedBefore = &end
+			}
+			i++
 		}
 	}
 
-	var data []byte
-	var err error
+	formats := []string{format}
+	if allFormats {
+		if outputDir == "" {
+			return fmt.Errorf("--all-formats requires --output-dir")
+		}
+		formats = []string{"json", "csv", "ical"}
+	}
+	if outputDir != "" {
+		if output != "" {
+			return fmt.Errorf("use either --output or --output-dir, not both")
+		}
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
 
-	filter := domain.TaskFilter{}
+	// Every file in one run shares a timestamp so a backup set sorts together
+	stamp := time.Now().In(loc).Format(exportFileTimeLayout)
+	for _, format := range formats {
+		// Every format is written as tasks are read rather than built in memory
+		var write func(ctx context.Context, filter domain.TaskFilter, w io.Writer) error
+		switch format {
+		case "json":
+			write = exportSvc.WriteTasksJSON
+		case "jsonl":
+			write = exportSvc.ExportTasksToJSONL
+		case "csv":
+			write = func(ctx context.Context, filter domain.TaskFilter, w io.Writer) error {
+				return exportSvc.WriteTasksCSV(ctx, filter, csvOptions, w)
+			}
+		case "ical":
+			write = func(ctx context.Context, filter domain.TaskFilter, w io.Writer) error {
+				return exportSvc.WriteTasksICAL(ctx, filter, icalMode, w)
+			}
+		default:
+			return fmt.Errorf("unsupported format: %s (supported: json, jsonl, csv, ical)", format)
+		}
+
+		target := output
+		if outputDir != "" {
+			target = filepath.Join(outputDir, exportFileName("tasks", format, stamp))
+		}
+
+		if err := streamExport(target, func(w io.Writer) error {
+			return write(ctx, filter, w)
+		}); err != nil {
+			return fmt.Errorf("failed to export tasks: %w", err)
+		}
+
+		if target != "" {
+			fmt.Printf("Exported tasks to %s\n", target)
+		}
+	}
+
+	return nil
+}
+
+// exportFileTimeLayout timestamps files written with --output-dir
+const exportFileTimeLayout = "20060102-150405"
+
+// exportFileName names a file written with --output-dir, such as
+// tasks-20251016-093000.ics
+func exportFileName(kind, format, stamp string) string {
+	extension := format
+	if format == "ical" {
+		extension = "ics"
+	}
+	return fmt.Sprintf("%s-%s.%s", kind, stamp, extension)
+}
+
+func exportProjects(ctx context.Context, exportSvc *export.Service, args []string) error {
+	format := "json"
+	output := ""
+	csvOptions := export.DefaultExportOptions()
+
+	// Parse flags
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--delimiter", "--no-header", "--excel":
+			if err := parseCSVFlag(args, &i, &csvOptions); err != nil {
+				return err
+			}
+		case "--format":
+			if i+1 < len(args) {
+				i++
+				format = args[i]
+			}
+		case "--output":
+			if i+1 < len(args) {
+				i++
+				output = args[i]
+			}
+		}
+	}
 
+	filter := domain.ProjectFilter{}
+
+	var write func(ctx context.Context, filter domain.ProjectFilter, w io.Writer) error
 	switch format {
 	case "json":
-		data, err = exportSvc.ExportTasksToJSON(ctx, filter)
+		write = exportSvc.WriteProjectsJSON
 	case "csv":
-		data, err = exportSvc.ExportTasksToCSV(ctx, filter)
-	case "ical":
-		data, err = exportSvc.ExportTasksToICAL(ctx, filter)
+		write = func(ctx context.Context, filter domain.ProjectFilter, w io.Writer) error {
+			return exportSvc.WriteProjectsCSV(ctx, filter, csvOptions, w)
+		}
 	default:
-		return fmt.Errorf("unsupported format: %s (supported: json, csv, ical)", format)
+		return fmt.Errorf("unsupported format: %s (supported: json, csv)", format)
 	}
 
-	if err != nil {
-		return fmt.Errorf("failed to export tasks: %w", err)
+	if err := streamExport(output, func(w io.Writer) error {
+		return write(ctx, filter, w)
+	}); err != nil {
+		return fmt.Errorf("failed to export projects: %w", err)
 	}
 
 	if output != "" {
-		if err := os.WriteFile(output, data, 0644); err != nil {
-			return fmt.Errorf("failed to write output file: %w", err)
+		fmt.Printf("Exported projects to %s\n", output)
+	}
+
+	return nil
+}
+
+// parseCSVFlag applies the --delimiter, --no-header, or --excel flag at
+// args[*i] to options, advancing *i past the flag's value
+func parseCSVFlag(args []string, i *int, options *export.ExportOptions) error {
+	switch args[*i] {
+	case "--no-header":
+		options.IncludeHeader = false
+		return nil
+	case "--excel":
+		excel := export.ExcelExportOptions()
+		options.ByteOrderMark = excel.ByteOrderMark
+		options.TimeLayout = excel.TimeLayout
+		return nil
+	}
+
+	if *i+1 >= len(args) {
+		return fmt.Errorf("--delimiter requires a character")
+	}
+	*i++
+	value := args[*i]
+	if value == "tab" || value == `\t` {
+		value = "\t"
+	}
+	runes := []rune(value)
+	if len(runes) != 1 || runes[0] == '"' || runes[0] == '\r' || runes[0] == '\n' {
+		return fmt.Errorf("invalid --delimiter %q (use a single character such as ';' or tab)", args[*i])
+	}
+	options.Delimiter = runes[0]
+	return nil
+}
+
+// streamExport runs write against the output file, or stdout when output is
+// empty, buffering the many small writes exports make
+func streamExport(output string, write func(w io.Writer) error) error {
+	if output == "" {
+		buffered := bufio.NewWriter(os.Stdout)
+		if err := write(buffered); err != nil {
+			return err
 		}
-		fmt.Printf("Exported tasks to %s\n", output)
-	} else {
-		fmt.Println(string(data))
+		return buffered.Flush()
 	}
 
+	file, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	buffered := bufio.NewWriter(file)
+	if err := write(buffered); err != nil {
+		file.Close()
+		return err
+	}
+	if err := buffered.Flush(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
 	return nil
 }
 
-func exportTime(ctx context.Context, exportSvc *export.Service, args []string) error {
+func exportTime(ctx context.Context, exportSvc *export.Service, projectRepo *sqlite.ProjectRepository, loc *time.Location, args []string) error {
 	format := "json"
 	output := ""
+	filter := domain.TimeEntryFilter{}
+	csvOptions := export.DefaultExportOptions()
 
 	// Parse flags
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
+		case "--delimiter", "--no-header", "--excel":
+			if err := parseCSVFlag(args, &i, &csvOptions); err != nil {
+				return err
+			}
 		case "--format":
 			if i+1 < len(args) {
 				i++
@@ -792,34 +2593,50 @@ func exportTime(ctx context.Context, exportSvc *export.Service, args []string) e
 				i++
 				output = args[i]
 			}
+		case "--project":
+			if i+1 < len(args) {
+				i++
+				filter.ProjectID = filterProjectID(ctx, projectRepo, args[i])
+			}
+		case "--since", "--until":
+			if i+1 >= len(args) {
+				return fmt.Errorf("%s requires a date (YYYY-MM-DD)", args[i])
+			}
+			day, err := parseDayFlag(args[i], args[i+1], loc)
+			if err != nil {
+				return err
+			}
+			// Both bounds cover the whole day they name
+			if args[i] == "--since" {
+				filter.StartAfter = &day
+			} else {
+				end := day.AddDate(0, 0, 1)
+				filter.EndBefore = &end
+			}
+			i++
 		}
 	}
 
-	var data []byte
-	var err error
-
-	filter := domain.TimeEntryFilter{}
-
+	var write func(ctx context.Context, filter domain.TimeEntryFilter, w io.Writer) error
 	switch format {
 	case "json":
-		data, err = exportSvc.ExportTimeEntriesToJSON(ctx, filter)
+		write = exportSvc.WriteTimeEntriesJSON
 	case "csv":
-		data, err = exportSvc.ExportTimeEntriesToCSV(ctx, filter)
+		write = func(ctx context.Context, filter domain.TimeEntryFilter, w io.Writer) error {
+			return exportSvc.WriteTimeEntriesCSV(ctx, filter, csvOptions, w)
+		}
 	default:
 		return fmt.Errorf("unsupported format: %s (supported: json, csv)", format)
 	}
 
-	if err != nil {
+	if err := streamExport(output, func(w io.Writer) error {
+		return write(ctx, filter, w)
+	}); err != nil {
 		return fmt.Errorf("failed to export time entries: %w", err)
 	}
 
 	if output != "" {
-		if err := os.WriteFile(output, data, 0644); err != nil {
-			return fmt.Errorf("failed to write output file: %w", err)
-		}
 		fmt.Printf("Exported time entries to %s\n", output)
-	} else {
-		fmt.Println(string(data))
 	}
 
 	return nil
@@ -870,9 +2687,101 @@ func setConfig(cfg *domain.Config, args []string) error {
 	case "default_project":
 		cfg.DefaultProject = value
 	case "time_format":
+		if err := ui.CheckTimeLayout(value); err != nil {
+			return fmt.Errorf("invalid time_format: %w", err)
+		}
 		cfg.TimeFormat = value
 	case "date_format":
+		if err := ui.CheckTimeLayout(value); err != nil {
+			return fmt.Errorf("invalid date_format: %w", err)
+		}
 		cfg.DateFormat = value
+	case "icon_style":
+		style, err := ui.ParseIconStyle(value)
+		if err != nil {
+			return err
+		}
+		cfg.IconStyle = string(style)
+	case "time_rounding":
+		minutes, err := strconv.Atoi(value)
+		if err != nil || minutes < 0 {
+			return fmt.Errorf("time_rounding must be a number of minutes (0 disables rounding)")
+		}
+		cfg.TimeRounding = minutes
+	case "time_rounding_mode":
+		mode, err := timeService.ParseRoundingMode(value)
+		if err != nil {
+			return err
+		}
+		cfg.TimeRoundingMode = string(mode)
+	case "week_start":
+		weekStart, err := timeService.ParseWeekStart(value)
+		if err != nil {
+			return err
+		}
+		cfg.WeekStart = string(weekStart)
+	case "timezone":
+		if _, err := timeService.ParseTimezone(value); err != nil {
+			return err
+		}
+		cfg.Timezone = value
+	case "show_archived_project_tasks":
+		show, err := parseConfigBool(key, value)
+		if err != nil {
+			return err
+		}
+		cfg.ShowArchivedProjectTasks = show
+	case "auto_complete_projects":
+		enabled, err := parseConfigBool(key, value)
+		if err != nil {
+			return err
+		}
+		cfg.AutoCompleteProjects = enabled
+	case "reject_past_due":
+		enabled, err := parseConfigBool(key, value)
+		if err != nil {
+			return err
+		}
+		cfg.RejectPastDue = enabled
+	case "task_id_scheme":
+		scheme, err := task.ParseIDScheme(value)
+		if err != nil {
+			return err
+		}
+		cfg.TaskIDScheme = string(scheme)
+	case "editor":
+		cfg.Editor = value
+	case "webhook_url":
+		if value != "" {
+			if err := hooks.ValidateWebhookURL(value); err != nil {
+				return err
+			}
+		}
+		cfg.WebhookURL = value
+	case "refresh_seconds":
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			return fmt.Errorf("refresh_seconds must be a number of seconds (0 disables refresh)")
+		}
+		cfg.RefreshSeconds = seconds
+	case "command_timeout_seconds":
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			return fmt.Errorf("command_timeout_seconds must be a number of seconds (0 uses the default of 30)")
+		}
+		cfg.CommandTimeoutSeconds = seconds
+	case "max_timer_hours":
+		hours, err := strconv.Atoi(value)
+		if err != nil || hours <= 0 {
+			return fmt.Errorf("max_timer_hours must be a positive number of hours")
+		}
+		cfg.MaxTimerHours = hours
+	case "priority_aging_days":
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 0 {
+			return fmt.Errorf("priority_aging_days must be a number of days (0 disables aging)")
+		}
+		cfg.PriorityAgingDays = days
 	default:
 		return fmt.Errorf("unknown config key: %s", key)
 	}
@@ -885,6 +2794,16 @@ func setConfig(cfg *domain.Config, args []string) error {
 	return nil
 }
 
+// parseConfigBool reads a true/false config value, refusing anything else
+// rather than treating it as false
+func parseConfigBool(key, value string) (bool, error) {
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s must be true or false", domain.ErrInvalidConfig, key)
+	}
+	return enabled, nil
+}
+
 // Git handlers
 func handleGitCommand(gitRepo *git.GitRepository, args []string) error {
 	if len(args) == 0 {
@@ -940,12 +2859,11 @@ func handleGitHook(gitRepo *git.GitRepository, args []string) error {
 }
 
 // Workspace handlers
-func handleWorkspaceCommand(taskRepo *sqlite.TaskRepository, args []string) error {
+func handleWorkspaceCommand(ctx context.Context, taskRepo *sqlite.TaskRepository, args []string) error {
 	if len(args) == 0 {
 		return showWorkspaceHelp()
 	}
 
-	ctx := context.Background()
 	subcommand := args[0]
 
 	switch subcommand {
@@ -994,9 +2912,7 @@ func listWorkspaces(ctx context.Context, taskRepo *sqlite.TaskRepository) error
 }
 
 // Prune handler
-func handlePruneCommand(taskRepo *sqlite.TaskRepository, projectRepo *sqlite.ProjectRepository, timeEntryRepo *sqlite.TimeEntryRepository) error {
-	ctx := context.Background()
-
+func handlePruneCommand(ctx context.Context, taskRepo *sqlite.TaskRepository, projectRepo *sqlite.ProjectRepository, timeEntryRepo *sqlite.TimeEntryRepository) error {
 	// Get counts for confirmation message
 	tasks, err := taskRepo.List(ctx, domain.TaskFilter{})
 	if err != nil {
@@ -1022,8 +2938,10 @@ func handlePruneCommand(taskRepo *sqlite.TaskRepository, projectRepo *sqlite.Pro
 	fmt.Print("Are you sure you want to continue? Type 'yes' to confirm: ")
 
 	// Read user input
+	resume := pauseCommandTimeout()
 	reader := bufio.NewReader(os.Stdin)
 	input, err := reader.ReadString('\n')
+	resume()
 	if err != nil {
 		return fmt.Errorf("failed to read input: %w", err)
 	}
@@ -1073,27 +2991,91 @@ SUBCOMMANDS:
   add, create        Create a new task
   update             Update an existing task
   complete           Mark a task as complete
-  delete, rm         Delete a task
+  start              Mark a task as in progress (doing)
+  block              Mark a task as blocked, optionally with the reason
+  snooze             Push a task's due date back by an amount (3d, 1w, 12h) or to a date
+  clone              Copy a task as a new todo task titled "<title> (copy)"
+  tag                Add or remove tags (see 'pm task tag help')
+  delete, rm         Delete a task and its subtasks (--dry-run shows what would go)
+  search             Search tasks by title and description
+  tree               Show tasks and their subtasks as a tree
+  recent             List the most recently updated tasks (--limit N, default 10)
+  blocked            List blocked tasks, most urgent first, with why they are blocked
+  next               Suggest the task to work on next (--start starts a timer on it)
+  age                Raise overdue tasks' priority a level per interval overdue (--dry-run, --every <days>)
+  show               Show a task's details and comments
+  log                Show a task's history: creation, time tracked, comments, notes, completion
+  comment            Add a comment to a task
   note               Manage note links (see 'pm task note help')
+  meta               Set, get, and list custom metadata (see 'pm task meta help')
+  attach             Attach a URL or file to a task (--no-check skips the file check)
+  attachments        List a task's attached URLs and files
 
 EXAMPLES:
   pm task add "Fix bug" --priority high --project MyProject
   pm task list --status todo --project MyProject
+  pm task list --priority critical --status doing
+  pm task list --due-after today --due-before "next friday"
   pm task list --minimal
+  pm task list --status doing --count
+  pm task list --overdue-first
   pm task update <id> --status doing
   pm task complete <id>
+  pm task complete <id> --with-subtasks
+  pm task start <id>
+  pm task block <id> "Waiting on API keys"
+  pm task block <id> --reason "Waiting on API keys"
+  pm task snooze <id> 3d
+  pm task snooze <id> next monday
+  pm task clone <id> --with-subtasks
   pm task delete <id>
+  pm task delete <id> --dry-run
+  pm task search "login bug"
+  pm task search login --fts
+  pm task tree
+  pm task tree <id>
+  pm task recent --limit 5
+  pm task update <id> --status blocked --reason "Waiting on API keys"
+  pm task blocked
+  pm task next
+  pm task next --start
+  pm task age --dry-run
+  pm task age --every 3
+  pm task attach <id> https://github.com/org/repo/pull/42
+  pm task attach <id> ./docs/design.md
+  pm task attachments <id>
+  pm task log <id>
+  pm task add --template bug "Crash on startup"
+  pm task add "Write tests" --parent <id>
+  pm task update <id> --edit
+  pm task add --parse "Fix login #bug !high @website due:next-friday"
+  pm task show <id>
+  pm task comment <id> "Repro only on arm64"
   pm task note link <task-id> <note-id>
+  pm task meta set <id> ticket JIRA-1042
 
 FLAGS:
   --project <name>         Filter/assign by project name or ID
   --status <status>        Filter/set status (todo, doing, done, blocked)
-  --priority <priority>    Set priority (low, normal, high, critical)
+  --priority <priority>    Set priority (low, normal, high, critical); list takes a comma-separated set
+  --due-before <date>      List tasks due on or before a date (YYYY-MM-DD or "next friday")
+  --due-after <date>       List tasks due on or after a date
   --workspace <name>       Filter/assign workspace
   --changelist, --cl       Set changelist
   --tags <tag1,tag2>       Set tags (comma-separated)
   --description <text>     Set description
+  --template <name>        Start from a template defined in the config file
+  --parse                  Read #tag, !priority, @project and due:<date> out of the title (add only)
+  --parent <task-id>       Create the task as a subtask of another (add only)
+  --estimate <duration>    Set a time estimate (e.g. 2h, 1h30m; "" clears on update)
+  --reason <text>          Record why a blocked task is blocked (update only)
+  --allow-past             Accept a due date before today when reject_past_due is set
+  --edit                   Write the description in $EDITOR (or the editor config setting)
+  --with-subtasks          Also complete all open subtasks (complete) or copy the subtree (clone)
   --minimal                Show minimal output format
+  --count                  Print only the number of matching tasks (list only)
+  --overdue-first          List overdue tasks before the rest (list only)
+  --fts                    Use the ranked full-text index when searching
 `
 	fmt.Println(helpText)
 	return nil
@@ -1106,14 +3088,27 @@ USAGE:
   pm project [subcommand] [flags]
 
 SUBCOMMANDS:
-  list, ls           List all projects
+  list, ls           List all projects (--count prints only how many)
   add, create        Create a new project
-  delete, rm         Delete a project
+  delete, rm         Delete a project with its tasks and time entries, after confirmation
+                     (--yes skips the prompt, --dry-run only shows what would go)
+  stats              Show task counts, tracked time, and overdue work
+  archive            Archive a project, hiding its tasks from task lists
+  activate           Make an archived project active again
+  tag                Add (--add <tag>) or remove (--remove <tag>) a tag on all of a project's tasks
 
 EXAMPLES:
   pm project add "MyProject"
   pm project list
+  pm project list --count
   pm project delete <id>
+  pm project delete <id> --dry-run
+  pm project delete <id> --yes
+  pm project stats MyProject
+  pm project stats <id> --json
+  pm project archive <id>
+  pm project tag MyProject --add release-2.1
+  pm project tag MyProject --remove release-2.1
 `
 	fmt.Println(helpText)
 	return nil
@@ -1126,20 +3121,32 @@ USAGE:
   pm time [subcommand] [flags]
 
 SUBCOMMANDS:
-  start              Start time tracking for a task
-  stop               Stop active time tracking
+  start              Start time tracking for a task or a category of work
+  stop               Stop active time tracking (--cap ends overlong timers at max_timer_hours)
   report             Generate time report
-  list               List time entries
+  list               List time entries (--since/--until YYYY-MM-DD, --task, --active, --count)
+  delete             Delete a time entry, or all entries for a task
 
 EXAMPLES:
   pm time start --task <task-id>
   pm time start --task <task-id> --description "Working on feature"
+  pm time start --category meetings --description "Sprint planning"
   pm time stop
+  pm time stop --cap
   pm time report --today
   pm time report --week
   pm time report --month
   pm time report --yesterday
+  pm time report --week --round 15
+  pm time report --month --round 30 --round-mode up
+  pm time report --include-active
   pm time list
+  pm time list --since 2025-10-01 --until 2025-10-31
+  pm time list --task <task-id>
+  pm time list --active
+  pm time list --since 2025-10-01 --count
+  pm time delete <entry-id>
+  pm time delete --task <task-id> --all --yes
 `
 	fmt.Println(helpText)
 	return nil
@@ -1170,17 +3177,44 @@ USAGE:
 SUBCOMMANDS:
   tasks              Export tasks
   time               Export time entries
+  projects           Export projects
 
 EXAMPLES:
   pm export tasks --format json
   pm export tasks --format csv --output tasks.csv
+  pm export tasks --format jsonl --output tasks.jsonl
   pm export tasks --format ical --output tasks.ical
+  pm export tasks --format ical --mode event --output due.ics
   pm export time --format json
   pm export time --format csv --output time.csv
+  pm export projects --format csv --output projects.csv
+  pm export tasks --status done --project web-app --format csv
+  pm export tasks --tags bug,urgent --since 2025-10-01 --until 2025-10-31
+  pm export time --project web-app --since 2025-10-01 --format csv
+  pm export time --format csv --delimiter ';' --no-header >> timesheet.csv
+  pm export tasks --all-formats --output-dir ./backup
 
 FLAGS:
-  --format <format>  Export format (json, csv, ical for tasks; json, csv for time)
+  --format <format>  Export format (json, jsonl, csv, ical for tasks; json, csv for
+                     time and projects)
   --output <file>    Output file path (prints to stdout if not specified)
+  --output-dir <dir> Write to a timestamped file such as tasks-20251016-093000.csv
+                     in this directory, creating it if needed (tasks only)
+  --project <name>   Only export this project's tasks or time entries
+  --since <date>     Only export items from this day on (YYYY-MM-DD)
+  --until <date>     Only export items up to the end of this day (YYYY-MM-DD)
+  --delimiter <char> CSV field separator, such as ';' or tab (default ',')
+  --no-header        Leave out the CSV header row, e.g. to append to a file
+  --excel            Start CSV with a UTF-8 BOM and write ISO 8601 timestamps
+
+TASK FLAGS:
+  --mode <mode>      iCal only: todo exports every task as a to-do (default),
+                     event exports tasks with due dates as calendar events
+  --status <list>    Only export tasks with these statuses (e.g. done,blocked)
+  --tags <list>      Only export tasks carrying all of these tags
+  --all-formats      Write JSON, CSV, and iCal files at once (needs --output-dir)
+
+Tasks are matched by creation date and time entries by start and end time.
 `
 	fmt.Println(helpText)
 	return nil
@@ -1202,12 +3236,46 @@ EXAMPLES:
   pm config set default_project MyProject
   pm config set time_format "15:04:05"
   pm config set date_format "2006-01-02"
+  pm config set icon_style emoji
+  pm config set max_timer_hours 10
+  pm config set refresh_seconds 5
+  pm config set command_timeout_seconds 60
+  pm config set time_rounding 15
+  pm config set week_start sunday
+  pm config set timezone Europe/Berlin
+  pm config set show_archived_project_tasks true
+  pm config set auto_complete_projects true
+  pm config set reject_past_due true
+  pm config set task_id_scheme sequential
+  pm config set webhook_url https://example.com/pm-hook
+  pm config set priority_aging_days 3
 
 AVAILABLE KEYS:
   git_integration    Enable/disable git integration (true/false)
   default_project    Set default project name
-  time_format        Set time display format
-  date_format        Set date display format
+  time_format        Set how times are shown, as a Go layout (default 15:04)
+  date_format        Set how dates are shown, as a Go layout (default 2006-01-02)
+  icon_style         Set TUI icons: ascii, emoji, or nerdfont
+  max_timer_hours    Flag timers running longer than this (default 12)
+  time_rounding      Round report totals to this many minutes (0 disables)
+  time_rounding_mode Round to the nearest increment, or always up or down
+  week_start         First day of weekly reports: monday or sunday
+  timezone           IANA time zone for report day boundaries (default local)
+  show_archived_project_tasks  List tasks of archived projects (true/false)
+  reject_past_due    Refuse due dates before today unless --allow-past (true/false)
+  editor             Command for --edit and notes, e.g. "code --wait" (default $VISUAL, $EDITOR, vi)
+  webhook_url        POST completed tasks to this URL ("" turns it off)
+  priority_aging_days  Raise overdue tasks a priority level per this many days (0 turns it off)
+
+TEMPLATES:
+  Task templates are defined under 'templates' in the config file:
+
+    templates:
+      bug:
+        title_prefix: "Bug: "
+        tags: [bug]
+        priority: high
+        project: MyProject
 `
 	fmt.Println(helpText)
 	return nil
@@ -1234,45 +3302,77 @@ func showHelp() error {
 	helpText := `Project Manager CLI - Task and Time Management
 
 USAGE:
-  pm [command] [subcommand] [flags]
+  pm [--config-dir <dir>] [--profile <name>] [--output text|json] [command] [subcommand] [flags]
+
+GLOBAL FLAGS:
+  --config-dir <dir>   Keep config.yaml and the database in <dir> (or set PM_CONFIG_DIR)
+  --profile <name>     Use a named profile's database (or set PM_PROFILE)
+  --output json        Report errors as a JSON object on stderr
+  --in-memory          Start the dashboard on an empty scratch store that is discarded on exit
+
+EXIT CODES:
+  0 success, 1 other errors, 3 not found, 4 invalid input, 5 conflict
 
 COMMANDS:
   task        Manage tasks
   project     Manage projects
   workspace   Manage workspaces
+  tag         List and rename tags across tasks
   time        Track time
   export      Export data
+  stats       Show productivity metrics
+  review      Sum up the week: completed, overdue, tracked time, new tasks
+  serve       Serve an HTTP JSON API (--addr, --read-only; see 'pm serve help')
   config      Manage configuration
+  profile     List profiles (separate databases)
   git         Git integration
   prune       Delete all data (with confirmation)
-  version     Show version
+  init        Interactive setup (runs automatically on first launch)
+  version     Show version and build details (--json for machine-readable output)
   help        Show this help
 
 TASK COMMANDS:
-  pm task add <title> [--priority high|medium|low] [--project <name>] [--tags tag1,tag2] [--cl <changelist>]
-  pm task list [--status todo|doing|done] [--project <name>]
+  pm task add <title> [--priority high|medium|low] [--project <name>] [--tags tag1,tag2] [--cl <changelist>] [--parent <id>]
+  pm task list [--status todo|doing|done] [--priority high,critical] [--project <name>]
+               [--due-before <date>] [--due-after <date>] [--overdue-first] [--count]
   pm task update <id> [--title <title>] [--status todo|doing|done|blocked] [--priority low|normal|high|critical]
   pm task complete <id>
-  pm task delete <id>
+  pm task clone <id> [--with-subtasks]
+  pm task delete <id> [--dry-run]
+  pm task tag add|rm <id> <tag>
+
+TAG COMMANDS:
+  pm tag list
+  pm tag rename <old> <new>
 
 PROJECT COMMANDS:
   pm project add <name>
-  pm project list
-  pm project delete <id>
+  pm project list [--count]
+  pm project delete <id> [--dry-run] [--yes]
+  pm project stats <name|id> [--json]
+  pm project archive <id>
+  pm project activate <id>
+  pm project tag <name|id> --add <tag> | --remove <tag>
 
 WORKSPACE COMMANDS:
   pm workspace list
 
 TIME COMMANDS:
   pm time start --task <task-id> [--description <desc>]
-  pm time stop
-  pm time report [--today|--week|--month|--yesterday]
-  pm time list
+  pm time start --category <name> [--description <desc>]
+  pm time stop [--cap]
+  pm time report [--today|--week|--month|--yesterday] [--round <minutes>] [--round-mode <nearest|up|down>] [--include-active]
+  pm time list [--since <date>] [--until <date>] [--task <task-id>] [--active] [--count]
+  pm time delete <entry-id> [--yes]
+  pm time delete --task <task-id> --all [--yes]
 
 EXPORT COMMANDS:
   pm export tasks --format <json|csv|ical> [--output <file>]
   pm export time --format <json|csv> [--output <file>]
 
+STATS COMMANDS:
+  pm stats [--json]
+
 CONFIG COMMANDS:
   pm config show
   pm config set <key> <value>
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+	"github.com/adriannajera/project-manager-cli/pkg/config"
+)
+
+// handleProfileCommand runs profile subcommands; none of them need the database
+func handleProfileCommand(cfg *domain.Config, args []string) error {
+	if len(args) == 0 {
+		return showProfileHelp()
+	}
+
+	switch args[0] {
+	case "help", "--help", "-h":
+		return showProfileHelp()
+	case "list", "ls":
+		return listProfiles(cfg)
+	default:
+		return fmt.Errorf("unknown profile subcommand: %s", args[0])
+	}
+}
+
+func listProfiles(cfg *domain.Config) error {
+	names, err := config.ListProfiles(cfg)
+	if err != nil {
+		return err
+	}
+
+	active := cfg.ActiveProfile
+	if active == "" {
+		active = config.DefaultProfile
+	}
+
+	for _, name := range names {
+		marker := " "
+		if name == active {
+			marker = "*"
+		}
+		fmt.Printf("%s %s\n", marker, name)
+	}
+	return nil
+}
+
+func showProfileHelp() error {
+	helpText := `Profile Commands
+
+Profiles keep separate databases (and default projects) for separate
+contexts, such as work and personal tasks.
+
+USAGE:
+  pm profile list
+  pm --profile <name> <command>
+
+SELECTING A PROFILE:
+  --profile <name>     Use the named profile for this command
+  PM_PROFILE=<name>    Use the named profile for every command in this shell
+
+A profile's database defaults to profiles/<name>.db in the data directory and
+is created on first use. Override it, or the default project, in config.yaml:
+
+  profiles:
+    work:
+      database_path: /path/to/work.db
+      default_project: backend
+
+EXAMPLES:
+  pm profile list
+  pm --profile work task add "Review PR"
+  PM_PROFILE=personal pm task list
+`
+	fmt.Println(helpText)
+	return nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/adriannajera/project-manager-cli/internal/service/review"
+	timeService "github.com/adriannajera/project-manager-cli/internal/service/time"
+	"github.com/adriannajera/project-manager-cli/internal/ui"
+)
+
+func handleReviewCommand(ctx context.Context, reviewSvc *review.Service, timeSvc *timeService.Service, args []string) error {
+	period := timeService.ReportWeek
+	for _, arg := range args {
+		switch arg {
+		case "help", "--help", "-h":
+			return showReviewHelp()
+		case "--week":
+			period = timeService.ReportWeek
+		case "--month":
+			period = timeService.ReportMonth
+		default:
+			return fmt.Errorf("unknown review flag: %s", arg)
+		}
+	}
+
+	r, err := reviewSvc.GenerateReview(ctx, period)
+	if err != nil {
+		return fmt.Errorf("failed to generate review: %w", err)
+	}
+
+	loc := timeSvc.Location()
+	fmt.Printf("Review: %s - %s\n", ui.FormatDay(r.Start), ui.FormatDay(r.End.AddDate(0, 0, -1)))
+	fmt.Printf("==================\n")
+
+	fmt.Printf("\nCompleted (%d):\n", len(r.Completed))
+	for _, t := range r.Completed {
+		completed := t.CompletedAt.In(loc)
+		fmt.Printf("  %s %s  %s (%s)\n", ui.FormatDay(completed), ui.FormatTime(completed), t.Title, t.DisplayID())
+	}
+
+	fmt.Printf("\nStill overdue (%d):\n", len(r.Overdue))
+	for _, t := range r.Overdue {
+		fmt.Printf("  %s  %s (%s)\n", ui.FormatDate(t.DueDate.In(loc)), t.Title, t.DisplayID())
+	}
+
+	fmt.Printf("\nTime tracked: %s\n", timeSvc.FormatDuration(r.TotalTracked))
+	for _, projectReport := range r.Tracked {
+		name := projectReport.ProjectName
+		if projectReport.ProjectID == "" {
+			name = "(no project)"
+		}
+		fmt.Printf("  %s: %s\n", name, timeSvc.FormatDuration(projectReport.TotalDuration))
+	}
+	if r.Running != nil {
+		fmt.Printf("  Not counted: the timer running since %s (%s so far)\n",
+			ui.FormatTime(r.Running.StartTime.In(loc)), timeSvc.FormatDuration(r.Running.GetDuration()))
+	}
+
+	fmt.Printf("\nCreated (%d):\n", len(r.Created))
+	for _, t := range r.Created {
+		fmt.Printf("  %s %s (%s)\n", taskStatusMarker(t.Status), t.Title, t.DisplayID())
+	}
+
+	return nil
+}
+
+// showTaskLog prints everything recorded against a task, oldest first
+func showTaskLog(ctx context.Context, reviewSvc *review.Service, timeSvc *timeService.Service, taskID string) error {
+	t, log, err := reviewSvc.TaskLog(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	loc := timeSvc.Location()
+	fmt.Printf("Log for %s (%s):\n", t.Title, t.DisplayID())
+	for _, entry := range log {
+		fmt.Printf("  %s  %s\n", ui.FormatDateTime(entry.At.In(loc)), taskLogLine(entry, timeSvc))
+	}
+	return nil
+}
+
+// taskLogLine describes one task log entry
+func taskLogLine(entry review.LogEntry, timeSvc *timeService.Service) string {
+	var line string
+	switch entry.Kind {
+	case review.LogCreated:
+		return "Created"
+	case review.LogCompleted:
+		return "Completed"
+	case review.LogComment:
+		return "Comment: " + entry.Text
+	case review.LogNoteCreated:
+		return "Note linked"
+	case review.LogNoteUpdated:
+		return "Note updated"
+	case review.LogTime:
+		if entry.Entry.IsActive() {
+			line = fmt.Sprintf("Timer running (%s so far)", timeSvc.FormatDuration(entry.Entry.GetDuration()))
+		} else {
+			line = "Tracked " + timeSvc.FormatDuration(entry.Entry.GetDuration())
+		}
+	}
+	if entry.Text != "" {
+		line += ": " + entry.Text
+	}
+	return line
+}
+
+func showReviewHelp() error {
+	helpText := `Review
+
+USAGE:
+  pm review [flags]
+
+Sums up a period for a retrospective: the tasks completed in it and when,
+the tasks still overdue, the time tracked per project, and the tasks created.
+Periods follow the time zone and week start of the time reports.
+
+FLAGS:
+  --week     Review this week (default)
+  --month    Review this month
+`
+	fmt.Println(helpText)
+	return nil
+}
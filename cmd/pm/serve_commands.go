@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/server"
+	"github.com/adriannajera/project-manager-cli/internal/service/project"
+	"github.com/adriannajera/project-manager-cli/internal/service/task"
+	timeService "github.com/adriannajera/project-manager-cli/internal/service/time"
+)
+
+// defaultServeAddr keeps the API on this machine unless --addr says otherwise,
+// since it has no authentication
+const defaultServeAddr = "127.0.0.1:8080"
+
+// handleServeCommand serves the HTTP API until interrupted. Each request gets
+// requestTimeout, as a CLI command would, and onChange is called after each
+// one that changed data.
+func handleServeCommand(taskService *task.Service, projectService *project.Service, timeSvc *timeService.Service, requestTimeout time.Duration, onChange func(), args []string) error {
+	addr := defaultServeAddr
+	options := server.Options{RequestTimeout: requestTimeout, OnChange: onChange}
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "help", "--help", "-h":
+			return showServeHelp()
+		case "--addr":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--addr requires an address, such as :8080")
+			}
+			i++
+			addr = args[i]
+		case "--read-only":
+			options.ReadOnly = true
+		default:
+			return fmt.Errorf("unknown serve flag: %s", args[i])
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	mode := ""
+	if options.ReadOnly {
+		mode = " (read-only)"
+	}
+	fmt.Fprintf(os.Stderr, "Serving the pm API on http://%s%s; press Ctrl+C to stop\n", addr, mode)
+
+	if err := server.New(taskService, projectService, timeSvc, options).ListenAndServe(ctx, addr); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Server stopped")
+	return nil
+}
+
+func showServeHelp() error {
+	helpText := `Serve
+
+USAGE:
+  pm serve [flags]
+
+Serves tasks, projects, and time tracking as an HTTP JSON API for editor
+plugins and dashboards, using the same database as the CLI. A pm dashboard
+open on the database reloads after each change. Ctrl+C stops the server
+after the requests in flight finish.
+
+FLAGS:
+  --addr <host:port>   Address to listen on (default 127.0.0.1:8080)
+  --read-only          Refuse every request that would change data
+
+ENDPOINTS:
+  GET  /api/tasks                  List tasks (?status=todo,doing ?project= ?tag= ?search= ?limit=)
+  POST /api/tasks                  Create a task {"title", "priority", "project_id", "due_date", ...}
+  GET  /api/tasks/{id}             Get a task by ID, ID prefix, or reference
+  POST /api/tasks/{id}/start       Mark a task doing
+  POST /api/tasks/{id}/complete    Mark a task done
+  GET  /api/projects               List projects (?status=active)
+  POST /api/projects               Create a project {"name", "description", "color"}
+  GET  /api/projects/{id}          Get a project
+  GET  /api/time-entries           List time entries (?task= ?project= ?limit=)
+  GET  /api/timer                  The running timer, or null
+  POST /api/timer/start            Start a timer {"task_id"} or {"category"}
+  POST /api/timer/stop             Stop the running timer
+
+Requests that change data must have Content-Type: application/json.
+Requests from web pages on other sites, and ones naming a host other than
+localhost or the listen address, are refused.
+
+The API has no authentication: anyone who can reach the address can read
+and change your data. Listen on all interfaces (--addr :8080) only on a
+trusted network.
+`
+	fmt.Println(helpText)
+	return nil
+}
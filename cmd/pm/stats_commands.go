@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+	"github.com/adriannajera/project-manager-cli/internal/service/project"
+	"github.com/adriannajera/project-manager-cli/internal/service/stats"
+	"github.com/adriannajera/project-manager-cli/internal/ui"
+)
+
+func handleStatsCommand(ctx context.Context, statsSvc *stats.Service, args []string) error {
+	asJSON := false
+	for _, arg := range args {
+		switch arg {
+		case "help", "--help", "-h":
+			return showStatsHelp()
+		case "--json":
+			asJSON = true
+		default:
+			return fmt.Errorf("unknown stats flag: %s", arg)
+		}
+	}
+
+	report, err := statsSvc.GenerateReport(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to generate stats: %w", err)
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal stats: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printStatsReport(report)
+	return nil
+}
+
+func printStatsReport(report *stats.Report) {
+	fmt.Printf("Productivity Stats\n")
+	fmt.Printf("==================\n")
+	fmt.Printf("Tasks completed this week: %d (since %s)\n", report.CompletedThisWeek, ui.FormatDay(report.WeekStart))
+	fmt.Printf("Tasks completed overall:   %d of %d\n", report.CompletedTasks, report.TotalTasks)
+	if report.CompletedTasks > 0 {
+		fmt.Printf("Average completion time:   %s\n", formatStatsDuration(report.AverageCompletionTime))
+	} else {
+		fmt.Printf("Average completion time:   -\n")
+	}
+	fmt.Printf("Tracked this week:         %.1fh\n", report.TrackedHoursThisWeek)
+	fmt.Printf("Tracked overall:           %.1fh\n", report.TrackedHours)
+
+	if len(report.Projects) == 0 {
+		return
+	}
+
+	fmt.Println("\nCompletion by project:")
+	for _, project := range report.Projects {
+		fmt.Printf("  %-24s %3d/%-3d %5.1f%%\n",
+			project.ProjectName,
+			project.CompletedTasks,
+			project.TotalTasks,
+			project.CompletionRate*100,
+		)
+	}
+}
+
+func showProjectStats(ctx context.Context, projectService *project.Service, statsSvc *stats.Service, args []string) error {
+	var projectNameOrID string
+	asJSON := false
+	for _, arg := range args {
+		if arg == "--json" {
+			asJSON = true
+			continue
+		}
+		projectNameOrID = arg
+	}
+	if projectNameOrID == "" {
+		return fmt.Errorf("project stats requires a project name or ID")
+	}
+
+	// Resolve by name first, then by ID
+	proj, err := projectService.GetProjectByName(ctx, projectNameOrID)
+	if err != nil {
+		proj, err = projectService.GetProject(ctx, projectNameOrID)
+		if err != nil {
+			return fmt.Errorf("project '%s' not found", projectNameOrID)
+		}
+	}
+
+	health, err := statsSvc.GenerateProjectHealth(ctx, proj.ID)
+	if err != nil {
+		return fmt.Errorf("failed to generate project stats: %w", err)
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(health, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal project stats: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("Project: %s (%s)\n", health.Project.Name, health.Project.Status)
+	fmt.Printf("==================\n")
+	fmt.Printf("Tasks: %d\n", health.TotalTasks)
+	for _, status := range []domain.TaskStatus{
+		domain.StatusBacklog,
+		domain.StatusTodo,
+		domain.StatusDoing,
+		domain.StatusDone,
+		domain.StatusBlocked,
+	} {
+		fmt.Printf("  %-8s %d\n", status, health.StatusCounts[status])
+	}
+	fmt.Printf("Overdue: %d\n", health.Overdue)
+	fmt.Printf("Tracked: %.1fh\n", health.TrackedHours)
+	if oldest := health.OldestOpenTask; oldest != nil {
+		fmt.Printf("Oldest open task: %s (%s, opened %s ago)\n",
+			oldest.Title,
+			oldest.ID,
+			formatStatsDuration(time.Since(oldest.CreatedAt)),
+		)
+	} else {
+		fmt.Printf("Oldest open task: none\n")
+	}
+
+	return nil
+}
+
+// formatStatsDuration formats long durations in days and hours
+func formatStatsDuration(d time.Duration) string {
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh", days, hours)
+	}
+	if hours > 0 {
+		return fmt.Sprintf("%dh %dm", hours, int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
+
+func showStatsHelp() error {
+	helpText := `Productivity Stats
+
+USAGE:
+  pm stats [flags]
+
+Shows tasks completed this week, average time from creation to completion,
+tracked hours, and the completion rate of each project.
+
+FLAGS:
+  --json    Print the report as JSON
+`
+	fmt.Println(helpText)
+	return nil
+}
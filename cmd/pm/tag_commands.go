@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/adriannajera/project-manager-cli/internal/service/task"
+)
+
+// handleTaskTagCommand adds and removes tags on a single task
+func handleTaskTagCommand(ctx context.Context, taskService *task.Service, args []string) error {
+	if len(args) == 0 {
+		return showTagHelp()
+	}
+
+	subcommand := args[0]
+
+	switch subcommand {
+	case "help", "--help", "-h":
+		return showTagHelp()
+	case "add":
+		if len(args) < 3 {
+			return fmt.Errorf("task tag add requires <task-id> and <tag>")
+		}
+		if err := taskService.AddTag(ctx, args[1], args[2]); err != nil {
+			return err
+		}
+		fmt.Printf("Tagged task %s with %s\n", args[1], args[2])
+		return nil
+	case "rm", "remove":
+		if len(args) < 3 {
+			return fmt.Errorf("task tag rm requires <task-id> and <tag>")
+		}
+		if err := taskService.RemoveTag(ctx, args[1], args[2]); err != nil {
+			return err
+		}
+		fmt.Printf("Removed tag %s from task %s\n", args[2], args[1])
+		return nil
+	default:
+		return fmt.Errorf("unknown task tag subcommand: %s", subcommand)
+	}
+}
+
+// handleTagCommand works with tags across all tasks
+func handleTagCommand(ctx context.Context, taskService *task.Service, args []string) error {
+	if len(args) == 0 {
+		return showTagHelp()
+	}
+
+	subcommand := args[0]
+
+	switch subcommand {
+	case "help", "--help", "-h":
+		return showTagHelp()
+	case "list", "ls":
+		return listTags(ctx, taskService)
+	case "rename", "mv":
+		if len(args) < 3 {
+			return fmt.Errorf("tag rename requires <old> and <new>")
+		}
+		return renameTag(ctx, taskService, args[1], args[2])
+	default:
+		return fmt.Errorf("unknown tag subcommand: %s", subcommand)
+	}
+}
+
+func listTags(ctx context.Context, taskService *task.Service) error {
+	tags, err := taskService.ListTags(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(tags) == 0 {
+		fmt.Println("No tags in use")
+		return nil
+	}
+
+	for _, tag := range tags {
+		fmt.Printf("%5d  %s\n", tag.Count, tag.Tag)
+	}
+	return nil
+}
+
+func renameTag(ctx context.Context, taskService *task.Service, oldTag, newTag string) error {
+	count, err := taskService.RenameTag(ctx, oldTag, newTag)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Renamed tag %s to %s on %d task(s)\n", oldTag, newTag, count)
+	return nil
+}
+
+func showTagHelp() error {
+	helpText := `Tag Commands
+
+USAGE:
+  pm task tag add <task-id> <tag>    Add a tag to a task
+  pm task tag rm <task-id> <tag>     Remove a tag from a task
+  pm tag list                        List every tag in use with its task count
+  pm tag rename <old> <new>          Rename a tag on every task that has it
+
+EXAMPLES:
+  pm task tag add <id> urgent
+  pm task tag rm <id> urgent
+  pm tag list
+  pm tag rename bug defect
+`
+	fmt.Println(helpText)
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/adriannajera/project-manager-cli/internal/service/task"
+)
+
+// attachToTask attaches a URL or local file to a task. Local files must exist
+// and are stored by absolute path; --no-check stores the reference as given,
+// which suits ticket IDs and files on other machines.
+func attachToTask(ctx context.Context, taskService *task.Service, args []string) error {
+	var taskID, ref string
+	check := true
+	for _, arg := range args {
+		switch {
+		case arg == "--no-check":
+			check = false
+		case strings.HasPrefix(arg, "-"):
+			return fmt.Errorf("unknown task attach flag: %s", arg)
+		case taskID == "":
+			taskID = arg
+		case ref == "":
+			ref = arg
+		default:
+			return fmt.Errorf("task attach takes one URL or path at a time")
+		}
+	}
+	if taskID == "" || ref == "" {
+		return fmt.Errorf("task attach requires a task ID and a URL or path")
+	}
+
+	// Blank references are left for the service to reject
+	if !isURL(ref) && strings.TrimSpace(ref) != "" {
+		if _, err := os.Stat(ref); err == nil {
+			if abs, err := filepath.Abs(ref); err == nil {
+				ref = abs
+			}
+		} else if check {
+			return fmt.Errorf("no file at %s (use --no-check to attach it anyway)", ref)
+		}
+	}
+
+	added, err := taskService.AttachToTask(ctx, taskID, ref)
+	if err != nil {
+		return err
+	}
+	if !added {
+		fmt.Printf("%s is already attached to task %s\n", ref, taskID)
+		return nil
+	}
+
+	fmt.Printf("Attached %s to task %s\n", ref, taskID)
+	return nil
+}
+
+// listTaskAttachments prints a task's attachments, flagging local files that
+// no longer exist
+func listTaskAttachments(ctx context.Context, taskService *task.Service, taskID string) error {
+	attachments, err := taskService.ListAttachments(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	if len(attachments) == 0 {
+		fmt.Println("No attachments")
+		return nil
+	}
+
+	for _, ref := range attachments {
+		fmt.Println(attachmentLabel(ref))
+	}
+	return nil
+}
+
+// attachmentLabel returns ref, marked when it is an absolute path to a file
+// that has since gone
+func attachmentLabel(ref string) string {
+	if filepath.IsAbs(ref) {
+		if _, err := os.Stat(ref); err != nil {
+			return ref + " (missing)"
+		}
+	}
+	return ref
+}
+
+// isURL reports whether ref is a link rather than a file path
+func isURL(ref string) bool {
+	return strings.Contains(ref, "://") || strings.HasPrefix(ref, "mailto:")
+}
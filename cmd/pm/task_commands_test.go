@@ -0,0 +1,29 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTaskIDArgIndex(t *testing.T) {
+	tests := []struct {
+		args string
+		want int
+	}{
+		{"update abc --title foo", 1},
+		// A flag's value is never taken for the ID
+		{"update --title foo abc", 1},
+		{"show abc", 1},
+		{"tree", -1},
+		{"delete --dry-run abc", 2},
+		{"attach --no-check abc https://example.com", 2},
+		{"note link abc", 2},
+		{"list --status todo", -1},
+	}
+
+	for _, tt := range tests {
+		if got := taskIDArgIndex(strings.Fields(tt.args)); got != tt.want {
+			t.Errorf("taskIDArgIndex(%q) = %d, want %d", tt.args, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/adriannajera/project-manager-cli/internal/service/task"
+)
+
+// handleTaskMetaCommand reads and writes the key/value metadata on a task
+func handleTaskMetaCommand(ctx context.Context, taskService *task.Service, args []string) error {
+	if len(args) == 0 {
+		return showTaskMetaHelp()
+	}
+
+	subcommand := args[0]
+
+	switch subcommand {
+	case "help", "--help", "-h":
+		return showTaskMetaHelp()
+	case "set":
+		if len(args) < 4 {
+			return fmt.Errorf("task meta set requires <task-id>, <key>, and <value>")
+		}
+		value := strings.Join(args[3:], " ")
+		if err := taskService.SetMetadata(ctx, args[1], args[2], value); err != nil {
+			return err
+		}
+		fmt.Printf("Set %s on task %s\n", args[2], args[1])
+		return nil
+	case "get":
+		if len(args) < 3 {
+			return fmt.Errorf("task meta get requires <task-id> and <key>")
+		}
+		value, err := taskService.GetMetadata(ctx, args[1], args[2])
+		if err != nil {
+			return err
+		}
+		fmt.Println(value)
+		return nil
+	case "list", "ls":
+		if len(args) < 2 {
+			return fmt.Errorf("task meta list requires <task-id>")
+		}
+		return listTaskMetadata(ctx, taskService, args[1])
+	case "unset", "rm":
+		if len(args) < 3 {
+			return fmt.Errorf("task meta unset requires <task-id> and <key>")
+		}
+		if err := taskService.UnsetMetadata(ctx, args[1], args[2]); err != nil {
+			return err
+		}
+		fmt.Printf("Removed %s from task %s\n", args[2], args[1])
+		return nil
+	default:
+		return fmt.Errorf("unknown task meta subcommand: %s", subcommand)
+	}
+}
+
+func listTaskMetadata(ctx context.Context, taskService *task.Service, taskID string) error {
+	metadata, err := taskService.ListMetadata(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	if len(metadata) == 0 {
+		fmt.Println("No metadata")
+		return nil
+	}
+
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Printf("%s=%v\n", key, metadata[key])
+	}
+	return nil
+}
+
+func showTaskMetaHelp() error {
+	helpText := `Task Metadata Commands
+
+USAGE:
+  pm task meta [subcommand] [args]
+
+SUBCOMMANDS:
+  set <task-id> <key> <value>    Store a value under a key
+  get <task-id> <key>            Print the value stored under a key
+  list, ls <task-id>             Print every key=value pair, sorted by key
+  unset, rm <task-id> <key>      Remove a key
+
+EXAMPLES:
+  pm task meta set abc123 ticket JIRA-1042
+  pm task meta get abc123 ticket
+  pm task meta list abc123
+  pm task meta unset abc123 ticket
+
+DESCRIPTION:
+  Metadata is a free-form store for scripts and integrations. Values are
+  saved as text. Keys written by pm itself, such as git_branch and
+  blocked_reason, are listed too and can be changed the same way.
+
+  get exits with code 3 when the key is not set.
+`
+	fmt.Println(helpText)
+	return nil
+}
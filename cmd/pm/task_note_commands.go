@@ -5,16 +5,17 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/adriannajera/project-manager-cli/internal/domain"
 	"github.com/adriannajera/project-manager-cli/internal/notes"
 	"github.com/adriannajera/project-manager-cli/internal/repository/sqlite"
+	"github.com/adriannajera/project-manager-cli/internal/ui"
 )
 
-func handleTaskNoteCommand(taskRepo *sqlite.TaskRepository, args []string) error {
+func handleTaskNoteCommand(ctx context.Context, taskRepo *sqlite.TaskRepository, editor string, args []string) error {
 	if len(args) == 0 {
 		return showTaskNoteHelp()
 	}
 
-	ctx := context.Background()
 	subcommand := args[0]
 
 	switch subcommand {
@@ -35,6 +36,16 @@ func handleTaskNoteCommand(taskRepo *sqlite.TaskRepository, args []string) error
 			return fmt.Errorf("task note show requires <task-id>")
 		}
 		return showTaskNote(ctx, taskRepo, args[1])
+	case "refresh":
+		if len(args) < 2 {
+			return fmt.Errorf("task note refresh requires <task-id>")
+		}
+		return refreshTaskNote(ctx, taskRepo, args[1])
+	case "edit":
+		if len(args) < 2 {
+			return fmt.Errorf("task note edit requires <task-id>")
+		}
+		return editTaskNote(ctx, taskRepo, editor, args[1])
 	default:
 		return fmt.Errorf("unknown task note subcommand: %s", subcommand)
 	}
@@ -60,23 +71,87 @@ func linkTaskNote(ctx context.Context, taskRepo *sqlite.TaskRepository, taskID,
 	}
 
 	// Update task with note information
+	attachNote(task, noteMeta)
+
+	// Save the updated task
+	if err := taskRepo.Update(ctx, task); err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+
+	fmt.Printf("Linked note %s to task %s\n", noteID, taskID)
+	fmt.Printf("  Note path: %s\n", notePath)
+	fmt.Printf("  Note created: %s\n", ui.FormatDateTime(noteMeta.CreatedAt))
+	fmt.Printf("  Note updated: %s\n", ui.FormatDateTime(noteMeta.UpdatedAt))
+
+	return nil
+}
+
+// attachNote records the note's ID, path, and timestamps on the task
+func attachNote(task *domain.Task, noteMeta *notes.NoteMetadata) {
 	task.NoteID = &noteMeta.ID
 	task.NotePath = &noteMeta.Path
 	task.HasNote = true
 	task.NoteCreatedAt = &noteMeta.CreatedAt
 	task.NoteUpdatedAt = &noteMeta.UpdatedAt
 	task.UpdatedAt = time.Now()
+}
 
-	// Save the updated task
-	if err := taskRepo.Update(ctx, task); err != nil {
-		return fmt.Errorf("failed to update task: %w", err)
+// editTaskNote opens the task's linked note in the editor, first creating a
+// note linked to the task when it has none or the linked file is gone
+func editTaskNote(ctx context.Context, taskRepo *sqlite.TaskRepository, editor, taskID string) error {
+	task, err := taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
 	}
 
-	fmt.Printf("Linked note %s to task %s\n", noteID, taskID)
-	fmt.Printf("  Note path: %s\n", notePath)
-	fmt.Printf("  Note created: %s\n", noteMeta.CreatedAt.Format("2006-01-02 15:04:05"))
-	fmt.Printf("  Note updated: %s\n", noteMeta.UpdatedAt.Format("2006-01-02 15:04:05"))
+	noteMeta := linkedNote(task)
+	if noteMeta == nil {
+		if task.HasNote {
+			fmt.Printf("Warning: the note linked to task %s was not found; creating a new one\n", taskID)
+		}
+		noteMeta, err = notes.CreateNote(task.Title, []string{task.ID})
+		if err != nil {
+			return fmt.Errorf("failed to create note: %w", err)
+		}
+		fmt.Printf("Created note %s for task %s\n", noteMeta.ID, taskID)
+		fmt.Printf("  Note path: %s\n", noteMeta.Path)
+	}
+
+	// Link new notes, and follow linked notes that have moved
+	if task.NotePath == nil || *task.NotePath != noteMeta.Path {
+		attachNote(task, noteMeta)
+		if err := taskRepo.Update(ctx, task); err != nil {
+			return fmt.Errorf("failed to update task: %w", err)
+		}
+	}
+
+	if err := openInEditor(editor, noteMeta.Path); err != nil {
+		return err
+	}
+
+	_, err = syncNoteUpdatedAt(ctx, taskRepo, task)
+	return err
+}
+
+// linkedNote finds the task's linked note, looking it up by ID when the file
+// has moved, or returns nil when the task has none or it cannot be found
+func linkedNote(task *domain.Task) *notes.NoteMetadata {
+	if !task.HasNote {
+		return nil
+	}
 
+	if task.NotePath != nil {
+		if noteMeta, err := notes.ParseNoteFrontmatter(*task.NotePath); err == nil {
+			return noteMeta
+		}
+	}
+	if task.NoteID != nil {
+		if path, err := notes.FindNoteByID(*task.NoteID); err == nil {
+			if noteMeta, err := notes.ParseNoteFrontmatter(path); err == nil {
+				return noteMeta
+			}
+		}
+	}
 	return nil
 }
 
@@ -126,6 +201,12 @@ func showTaskNote(ctx context.Context, taskRepo *sqlite.TaskRepository, taskID s
 		return nil
 	}
 
+	// Pick up edits made since the note was linked; a missing file leaves
+	// the stored timestamps as they were
+	if _, err := syncNoteUpdatedAt(ctx, taskRepo, task); err != nil {
+		fmt.Printf("Warning: %v\n\n", err)
+	}
+
 	fmt.Println("Linked Note:")
 	if task.NoteID != nil {
 		fmt.Printf("  Note ID: %s\n", *task.NoteID)
@@ -135,15 +216,63 @@ func showTaskNote(ctx context.Context, taskRepo *sqlite.TaskRepository, taskID s
 	}
 	fmt.Printf("  Has Note: %t\n", task.HasNote)
 	if task.NoteCreatedAt != nil {
-		fmt.Printf("  Note Created: %s\n", task.NoteCreatedAt.Format("2006-01-02 15:04:05"))
+		fmt.Printf("  Note Created: %s\n", ui.FormatDateTime(*task.NoteCreatedAt))
 	}
 	if task.NoteUpdatedAt != nil {
-		fmt.Printf("  Note Updated: %s\n", task.NoteUpdatedAt.Format("2006-01-02 15:04:05"))
+		fmt.Printf("  Note Updated: %s\n", ui.FormatDateTime(*task.NoteUpdatedAt))
+	}
+
+	return nil
+}
+
+func refreshTaskNote(ctx context.Context, taskRepo *sqlite.TaskRepository, taskID string) error {
+	task, err := taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if !task.HasNote {
+		return fmt.Errorf("task %s does not have a linked note", taskID)
+	}
+
+	changed, err := syncNoteUpdatedAt(ctx, taskRepo, task)
+	if err != nil {
+		return err
 	}
 
+	if changed {
+		fmt.Printf("Refreshed note for task %s\n", taskID)
+	} else {
+		fmt.Printf("Note for task %s is already up to date\n", taskID)
+	}
+	fmt.Printf("  Note updated: %s\n", ui.FormatDateTime(*task.NoteUpdatedAt))
+
 	return nil
 }
 
+// syncNoteUpdatedAt sets the task's NoteUpdatedAt to the linked file's
+// modification time, saving the task only when it changed
+func syncNoteUpdatedAt(ctx context.Context, taskRepo *sqlite.TaskRepository, task *domain.Task) (bool, error) {
+	if task.NotePath == nil {
+		return false, fmt.Errorf("task %s has no note path recorded", task.ID)
+	}
+
+	modTime, err := notes.GetNoteModTime(*task.NotePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to refresh note: %w", err)
+	}
+
+	if task.NoteUpdatedAt != nil && task.NoteUpdatedAt.Equal(modTime) {
+		return false, nil
+	}
+
+	task.NoteUpdatedAt = &modTime
+	if err := taskRepo.Update(ctx, task); err != nil {
+		return false, fmt.Errorf("failed to update task: %w", err)
+	}
+	return true, nil
+}
+
 func showTaskNoteHelp() error {
 	helpText := `Task Note Management Commands
 
@@ -154,18 +283,28 @@ SUBCOMMANDS:
   link <task-id> <note-id>    Link a note to a task
   unlink <task-id>            Remove note link from a task
   show <task-id>              Show linked note information
+  refresh <task-id>           Update the stored note timestamp from the file
+  edit <task-id>              Open the linked note, creating and linking one first
+                              when the task has none
 
 EXAMPLES:
   pm task note link abc123 def456-789a-bcde-f012-3456789abcde
   pm task note show abc123
+  pm task note refresh abc123
+  pm task note edit abc123
   pm task note unlink abc123
 
 DESCRIPTION:
   Note linking allows you to associate dn-tui debug notes with tasks.
   When you link a note, the task will store the note's ID, path, and
-  creation/modification timestamps.
+  creation/modification timestamps. show and refresh update the stored
+  modification time when the note file has changed since.
 
   The note-id should be a UUID from a dn-tui note's frontmatter.
+
+  edit opens the note in the editor config setting, $VISUAL, or $EDITOR
+  (default vi). A new note goes in $DEBUG_NOTES_DIR (default ~/.debug-notes),
+  headed by the task title, with the task's ID in its frontmatter links.
 `
 	fmt.Println(helpText)
 	return nil
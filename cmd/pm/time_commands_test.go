@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/adriannajera/project-manager-cli/internal/repository/memory"
+	timeService "github.com/adriannajera/project-manager-cli/internal/service/time"
+)
+
+func TestStartTrackingUnknownTaskExitsNotFound(t *testing.T) {
+	store := memory.NewStore()
+	timeSvc := timeService.NewService(memory.NewTimeEntryRepository(store), memory.NewTaskRepository(store), store, timeService.Options{})
+
+	err := startTimeTracking(context.Background(), timeSvc, []string{"--task", "no-such-task"})
+	if err == nil {
+		t.Fatal("Expected tracking an unknown task to fail")
+	}
+	if kind, code := classifyExit(err); kind != "not_found" || code != exitNotFound {
+		t.Errorf("Expected not_found with exit code %d, got %s with %d", exitNotFound, kind, code)
+	}
+	if !strings.Contains(err.Error(), "pm task list") {
+		t.Errorf("Expected a hint to list task IDs, got %q", err)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+)
+
+// Build metadata, set at build time with
+// -ldflags "-X main.Version=... -X main.Commit=... -X main.BuildTime=..."
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+// versionInfo describes the running build
+type versionInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+}
+
+// currentVersion returns the build metadata, falling back to the VCS details
+// the Go toolchain embeds when ldflags were not set
+func currentVersion() versionInfo {
+	info := versionInfo{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range buildInfo.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = setting.Value
+				}
+			}
+		}
+	}
+
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.BuildTime == "" {
+		info.BuildTime = "unknown"
+	}
+	return info
+}
+
+func showVersion(args []string) error {
+	format := "text"
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--json":
+			format = "json"
+		case "--format":
+			if i+1 < len(args) {
+				i++
+				format = args[i]
+			}
+		}
+	}
+
+	info := currentVersion()
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(info)
+	case "text":
+		fmt.Printf("Project Manager CLI %s\n", info.Version)
+		fmt.Printf("  Commit:     %s\n", info.Commit)
+		fmt.Printf("  Built:      %s\n", info.BuildTime)
+		fmt.Printf("  Go version: %s\n", info.GoVersion)
+		fmt.Printf("  Platform:   %s\n", info.Platform)
+		return nil
+	default:
+		return fmt.Errorf("unknown version format: %s (must be text or json)", format)
+	}
+}
@@ -0,0 +1,63 @@
+package clipboard
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// ErrUnavailable is returned when no clipboard tool is installed
+var ErrUnavailable = errors.New("no clipboard tool found (install wl-clipboard, xclip, or xsel)")
+
+// waitDelay is how long Copy waits for the tool's output to close after it
+// exits
+const waitDelay = 500 * time.Millisecond
+
+// Copy puts text on the system clipboard by piping it to the platform's
+// clipboard tool: pbcopy on macOS, clip on Windows, and wl-copy, xclip, or
+// xsel elsewhere, whichever is installed first
+func Copy(text string) error {
+	for _, args := range commands(runtime.GOOS, os.Getenv) {
+		if _, err := exec.LookPath(args[0]); err != nil {
+			continue
+		}
+
+		// xclip, xsel, and wl-copy leave a child running to serve the
+		// clipboard, which keeps stderr open; WaitDelay stops waiting for it
+		var stderr bytes.Buffer
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		cmd.Stderr = &stderr
+		cmd.WaitDelay = waitDelay
+		if err := cmd.Run(); err != nil && !errors.Is(err, exec.ErrWaitDelay) {
+			return fmt.Errorf("%s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+		}
+		return nil
+	}
+	return ErrUnavailable
+}
+
+// commands lists the clipboard tools to try, in order, for an operating
+// system; Wayland's tool only comes first inside a Wayland session
+func commands(goos string, getenv func(string) string) [][]string {
+	switch goos {
+	case "darwin":
+		return [][]string{{"pbcopy"}}
+	case "windows":
+		return [][]string{{"clip"}}
+	}
+
+	x11 := [][]string{
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+	}
+	if getenv("WAYLAND_DISPLAY") == "" {
+		return x11
+	}
+	return append([][]string{{"wl-copy"}}, x11...)
+}
@@ -0,0 +1,52 @@
+package clipboard
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestCommandsPreferWaylandInsideAWaylandSession(t *testing.T) {
+	env := map[string]string{}
+	getenv := func(key string) string { return env[key] }
+
+	if got := commands("darwin", getenv); len(got) != 1 || got[0][0] != "pbcopy" {
+		t.Errorf("Expected pbcopy on macOS, got %v", got)
+	}
+
+	if got := commands("linux", getenv); len(got) != 2 || got[0][0] != "xclip" {
+		t.Errorf("Expected xclip first outside Wayland, got %v", got)
+	}
+
+	env["WAYLAND_DISPLAY"] = "wayland-0"
+	if got := commands("linux", getenv); len(got) != 3 || got[0][0] != "wl-copy" {
+		t.Errorf("Expected wl-copy first inside Wayland, got %v", got)
+	}
+}
+
+func TestCopyDoesNotWaitForTheToolsBackgroundChild(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("uses a shell script standing in for xclip")
+	}
+	dir := t.TempDir()
+	// Like xclip, the script leaves a child holding its output open
+	script := "#!/bin/sh\ncat >/dev/null\nsleep 10 &\n"
+	if err := os.WriteFile(filepath.Join(dir, "xclip"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("WAYLAND_DISPLAY", "")
+
+	done := make(chan error, 1)
+	go func() { done <- Copy("hello") }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Copy failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Copy waited for the background child")
+	}
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Comment is a short dated remark recorded on a task
+type Comment struct {
+	ID        string    `json:"id" db:"id"`
+	TaskID    string    `json:"task_id" db:"task_id"`
+	Body      string    `json:"body" db:"body"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+func NewComment(taskID, body string) *Comment {
+	return &Comment{
+		ID:        uuid.New().String(),
+		TaskID:    taskID,
+		Body:      body,
+		CreatedAt: time.Now(),
+	}
+}
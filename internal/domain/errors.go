@@ -9,15 +9,26 @@ var (
 	ErrInvalidDueDate     = errors.New("due date cannot be in the past")
 	ErrInvalidStatus      = errors.New("invalid status")
 	ErrInvalidPriority    = errors.New("invalid priority")
+	ErrInvalidEstimate    = errors.New("invalid estimate")
 	ErrEmptyTitle         = errors.New("title cannot be empty")
 	ErrEmptyName          = errors.New("name cannot be empty")
+	ErrEmptyComment       = errors.New("comment cannot be empty")
 	ErrDuplicateProject   = errors.New("project with this name already exists")
 	ErrActiveTimeEntry    = errors.New("there is already an active time entry")
 	ErrNoActiveTimeEntry  = errors.New("no active time entry found")
 	ErrCircularDependency = errors.New("circular dependency detected")
 	ErrInvalidTaskID      = errors.New("invalid task ID")
+	ErrParentNotFound     = errors.New("parent task not found")
+	ErrAmbiguousID        = errors.New("ID prefix matches more than one task")
+	ErrTaskOrCategory     = errors.New("time entry needs either a task or a category, not both")
+	ErrReasonNotBlocked   = errors.New("a blocked reason needs the task to be blocked")
+	ErrEmptyMetadataKey   = errors.New("metadata key cannot be empty")
+	ErrMetadataNotFound   = errors.New("metadata key not found")
+	ErrEmptyAttachment    = errors.New("attachment cannot be empty")
 	ErrInvalidProjectID   = errors.New("invalid project ID")
 	ErrDatabaseConnection = errors.New("database connection failed")
+	ErrDatabaseLocked     = errors.New("database is locked by another process")
+	ErrDatabaseCorrupt    = errors.New("database file is corrupt")
 	ErrMigrationFailed    = errors.New("database migration failed")
 	ErrConfigNotFound     = errors.New("configuration file not found")
 	ErrInvalidConfig      = errors.New("invalid configuration")
@@ -0,0 +1,31 @@
+package domain
+
+// EventName identifies a task lifecycle event
+type EventName string
+
+const (
+	EventTaskCreated   EventName = "task_created"
+	EventTaskCompleted EventName = "task_completed"
+	EventTimerStarted  EventName = "timer_started"
+
+	EventProjectCompleted EventName = "project_completed"
+)
+
+// EventNames lists every lifecycle event in the order they are documented
+var EventNames = []EventName{EventTaskCreated, EventTaskCompleted, EventTimerStarted, EventProjectCompleted}
+
+// Event describes a lifecycle change. Task is nil for a timer started on a
+// category; TimeEntry is set only for timer events and Project only for
+// project events.
+type Event struct {
+	Name      EventName
+	Task      *Task
+	TimeEntry *TimeEntry
+	Project   *Project
+}
+
+// Notifier receives lifecycle events after the change is saved. Notify must
+// return quickly and never fail the operation that triggered it.
+type Notifier interface {
+	Notify(event Event)
+}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -16,8 +18,28 @@ type TaskFilter struct {
 	Search    string
 	Limit     int
 	Offset    int
+
+	// CreatedAfter and CreatedBefore bound the creation time, inclusive
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+
+	// ExcludeArchivedProjects hides tasks that belong to archived projects
+	ExcludeArchivedProjects bool
+
+	// SortBy picks the time tasks are listed by, newest first
+	SortBy TaskSort
 }
 
+// TaskSort names the time a task listing is ordered by
+type TaskSort string
+
+const (
+	// SortByCreated lists the newest tasks first; it is the default
+	SortByCreated TaskSort = ""
+	// SortByUpdated lists the most recently changed tasks first
+	SortByUpdated TaskSort = "updated"
+)
+
 type ProjectFilter struct {
 	Status []ProjectStatus
 	Search string
@@ -38,11 +60,27 @@ type TimeEntryFilter struct {
 type TaskRepository interface {
 	Create(ctx context.Context, task *Task) error
 	GetByID(ctx context.Context, id string) (*Task, error)
+	// GetByRef returns the task with a sequential reference such as "WEB-42"
+	GetByRef(ctx context.Context, ref string) (*Task, error)
+	// NextTaskNumber increments and returns the counter for prefix, starting
+	// at 1
+	NextTaskNumber(ctx context.Context, prefix string) (int, error)
 	List(ctx context.Context, filter TaskFilter) ([]*Task, error)
+	// ListEach passes the tasks List would return to fn one at a time,
+	// without loading them all first. fn must not use the repository.
+	ListEach(ctx context.Context, filter TaskFilter, fn func(*Task) error) error
+	// ListWithSubtaskCounts returns the tasks List would return, each with
+	// its number of direct subtasks, in a single query
+	ListWithSubtaskCounts(ctx context.Context, filter TaskFilter) ([]*TaskWithSubtaskCount, error)
 	Update(ctx context.Context, task *Task) error
+	UpdateTasks(ctx context.Context, tasks []*Task) error
 	Delete(ctx context.Context, id string) error
 	GetByProject(ctx context.Context, projectID string) ([]*Task, error)
 	GetSubtasks(ctx context.Context, parentID string) ([]*Task, error)
+	SearchTasks(ctx context.Context, query string) ([]*Task, error)
+	AddComment(ctx context.Context, comment *Comment) error
+	// ListComments returns a task's comments, oldest first
+	ListComments(ctx context.Context, taskID string) ([]*Comment, error)
 }
 
 type ProjectRepository interface {
@@ -52,6 +90,9 @@ type ProjectRepository interface {
 	Update(ctx context.Context, project *Project) error
 	Delete(ctx context.Context, id string) error
 	GetByName(ctx context.Context, name string) (*Project, error)
+	// GetByIDs fetches several projects in one query, keyed by ID; IDs with
+	// no project are left out of the map
+	GetByIDs(ctx context.Context, ids []string) (map[string]*Project, error)
 }
 
 type TimeEntryRepository interface {
@@ -63,6 +104,16 @@ type TimeEntryRepository interface {
 	GetActive(ctx context.Context) (*TimeEntry, error)
 	GetByTask(ctx context.Context, taskID string) ([]*TimeEntry, error)
 	GetByProject(ctx context.Context, projectID string) ([]*TimeEntry, error)
+	// SumDurations totals the completed entries matching filter by task,
+	// category, and project without loading the entries themselves
+	SumDurations(ctx context.Context, filter TimeEntryFilter) ([]*TimeTotal, error)
+}
+
+// Transactor runs fn in a single database transaction. Repository calls made
+// with the context passed to fn join the transaction, and all of their writes
+// are rolled back if fn returns an error or the context is cancelled.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type GitRepository interface {
@@ -81,13 +132,69 @@ type ConfigRepository interface {
 }
 
 type Config struct {
-	DatabasePath   string            `yaml:"database_path"`
-	DefaultProject string            `yaml:"default_project"`
-	GitIntegration bool              `yaml:"git_integration"`
-	TimeFormat     string            `yaml:"time_format"`
-	DateFormat     string            `yaml:"date_format"`
-	Theme          Theme             `yaml:"theme"`
-	Aliases        map[string]string `yaml:"aliases"`
+	DatabasePath             string                  `yaml:"database_path"`
+	DefaultProject           string                  `yaml:"default_project"`
+	GitIntegration           bool                    `yaml:"git_integration"`
+	TimeFormat               string                  `yaml:"time_format"`
+	DateFormat               string                  `yaml:"date_format"`
+	IconStyle                string                  `yaml:"icon_style,omitempty"`
+	ProjectColors            []string                `yaml:"project_colors,omitempty"`
+	MaxTimerHours            int                     `yaml:"max_timer_hours,omitempty"`
+	TimeRounding             int                     `yaml:"time_rounding,omitempty"`
+	TimeRoundingMode         string                  `yaml:"time_rounding_mode,omitempty"`
+	WeekStart                string                  `yaml:"week_start,omitempty"`
+	Timezone                 string                  `yaml:"timezone,omitempty"`
+	ShowArchivedProjectTasks bool                    `yaml:"show_archived_project_tasks,omitempty"`
+	AutoCompleteProjects     bool                    `yaml:"auto_complete_projects,omitempty"`
+	RejectPastDue            bool                    `yaml:"reject_past_due,omitempty"`
+	TaskIDScheme             string                  `yaml:"task_id_scheme,omitempty"`
+	RefreshSeconds           int                     `yaml:"refresh_seconds,omitempty"`
+	CommandTimeoutSeconds    int                     `yaml:"command_timeout_seconds,omitempty"`
+	PriorityAgingDays        int                     `yaml:"priority_aging_days,omitempty"`
+	Theme                    Theme                   `yaml:"theme"`
+	Aliases                  map[string]string       `yaml:"aliases"`
+	Templates                map[string]TaskTemplate `yaml:"templates,omitempty"`
+	Profiles                 map[string]Profile      `yaml:"profiles,omitempty"`
+	Hooks                    map[string]string       `yaml:"hooks,omitempty"`
+	WebhookURL               string                  `yaml:"webhook_url,omitempty"`
+	Editor                   string                  `yaml:"editor,omitempty"`
+	ActiveProfile            string                  `yaml:"-"`
+}
+
+// Profile overrides the database and default project for a named context
+type Profile struct {
+	DatabasePath   string `yaml:"database_path,omitempty"`
+	DefaultProject string `yaml:"default_project,omitempty"`
+}
+
+// TaskTemplate holds defaults applied when creating a task from a template
+type TaskTemplate struct {
+	TitlePrefix string   `yaml:"title_prefix,omitempty"`
+	Tags        []string `yaml:"tags,omitempty"`
+	Priority    string   `yaml:"priority,omitempty"`
+	Project     string   `yaml:"project,omitempty"`
+}
+
+// ApplyTitle prepends the template's title prefix to title
+func (t TaskTemplate) ApplyTitle(title string) string {
+	if t.TitlePrefix == "" || strings.HasPrefix(title, t.TitlePrefix) {
+		return title
+	}
+	return t.TitlePrefix + title
+}
+
+// ParsePriority returns the priority the template called name sets, and
+// false when it sets none. An unknown priority is an ErrInvalidPriority
+// naming the template, rather than falling back to normal.
+func (t TaskTemplate) ParsePriority(name string) (Priority, bool, error) {
+	if t.Priority == "" {
+		return PriorityNormal, false, nil
+	}
+	priority, err := ParsePriority(t.Priority)
+	if err != nil {
+		return PriorityNormal, false, fmt.Errorf("%w: %q in template %s", ErrInvalidPriority, t.Priority, name)
+	}
+	return priority, true, nil
 }
 
 type Theme struct {
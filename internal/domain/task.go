@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -40,6 +41,22 @@ func (p Priority) String() string {
 	}
 }
 
+// ParsePriority converts a priority name such as "high" into a Priority
+func ParsePriority(name string) (Priority, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "low":
+		return PriorityLow, nil
+	case "normal", "medium":
+		return PriorityNormal, nil
+	case "high":
+		return PriorityHigh, nil
+	case "critical":
+		return PriorityCritical, nil
+	default:
+		return PriorityNormal, ErrInvalidPriority
+	}
+}
+
 type Task struct {
 	ID            string                 `json:"id" db:"id"`
 	Title         string                 `json:"title" db:"title"`
@@ -61,6 +78,41 @@ type Task struct {
 	HasNote       bool                   `json:"has_note" db:"has_note"`
 	NoteCreatedAt *time.Time             `json:"note_created_at,omitempty" db:"note_created_at"`
 	NoteUpdatedAt *time.Time             `json:"note_updated_at,omitempty" db:"note_updated_at"`
+	Estimate      time.Duration          `json:"estimate,omitempty" db:"estimate"`
+	Ref           string                 `json:"ref,omitempty" db:"ref"`
+}
+
+// TaskWithSubtaskCount is a task along with how many direct subtasks it has
+type TaskWithSubtaskCount struct {
+	*Task
+	SubtaskCount int
+}
+
+// EstimateVariance returns how far tracked time is over (positive) or under
+// (negative) the task's estimate
+func (t *Task) EstimateVariance(tracked time.Duration) time.Duration {
+	return tracked - t.Estimate
+}
+
+// ShortIDLength is the number of ID characters shown in task listings
+const ShortIDLength = 8
+
+// ShortID returns the prefix of id shown in listings; any unambiguous prefix
+// can be used wherever a task ID is expected
+func ShortID(id string) string {
+	if len(id) <= ShortIDLength {
+		return id
+	}
+	return id[:ShortIDLength]
+}
+
+// DisplayID returns the task's sequential reference, such as "WEB-42", when
+// it has one, and its short ID otherwise
+func (t *Task) DisplayID() string {
+	if t.Ref != "" {
+		return t.Ref
+	}
+	return ShortID(t.ID)
 }
 
 func NewTask(title, description string) *Task {
@@ -95,6 +147,88 @@ func (t *Task) Block() {
 	t.UpdatedAt = time.Now()
 }
 
+// blockedReasonKey is the metadata key recording why a task is blocked
+const blockedReasonKey = "blocked_reason"
+
+// BlockedReason returns why the task is blocked, if that was recorded
+func (t *Task) BlockedReason() string {
+	reason, _ := t.Metadata[blockedReasonKey].(string)
+	return reason
+}
+
+// SetBlockedReason records why the task is blocked; an empty reason clears it
+func (t *Task) SetBlockedReason(reason string) {
+	if reason == "" {
+		delete(t.Metadata, blockedReasonKey)
+		return
+	}
+	if t.Metadata == nil {
+		t.Metadata = make(map[string]interface{})
+	}
+	t.Metadata[blockedReasonKey] = reason
+}
+
+// SetMeta stores value under key in the task's metadata
+func (t *Task) SetMeta(key string, value interface{}) {
+	if t.Metadata == nil {
+		t.Metadata = make(map[string]interface{})
+	}
+	t.Metadata[key] = value
+	t.UpdatedAt = time.Now()
+}
+
+// UnsetMeta removes key from the task's metadata, reporting whether it was
+// there
+func (t *Task) UnsetMeta(key string) bool {
+	if _, ok := t.Metadata[key]; !ok {
+		return false
+	}
+	delete(t.Metadata, key)
+	t.UpdatedAt = time.Now()
+	return true
+}
+
+// attachmentsKey is the metadata key listing the links and files attached to
+// a task
+const attachmentsKey = "attachments"
+
+// Attachments returns the URLs and file paths attached to the task, in the
+// order they were added
+func (t *Task) Attachments() []string {
+	switch value := t.Metadata[attachmentsKey].(type) {
+	case []string:
+		return value
+	case []interface{}:
+		// Metadata read back from JSON holds lists as []interface{}
+		attachments := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				attachments = append(attachments, s)
+			}
+		}
+		return attachments
+	case string:
+		// Set by hand with pm task meta set
+		if value != "" {
+			return []string{value}
+		}
+	}
+	return nil
+}
+
+// AddAttachment attaches a URL or file path to the task, reporting false when
+// it is already attached
+func (t *Task) AddAttachment(ref string) bool {
+	attachments := t.Attachments()
+	for _, existing := range attachments {
+		if existing == ref {
+			return false
+		}
+	}
+	t.SetMeta(attachmentsKey, append(attachments, ref))
+	return true
+}
+
 func (t *Task) AddTag(tag string) {
 	for _, existingTag := range t.Tags {
 		if existingTag == tag {
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"errors"
+	"strings"
 	"testing"
 	"time"
 )
@@ -170,4 +172,62 @@ func TestPriorityString(t *testing.T) {
 			t.Errorf("Expected %s for priority %d, got %s", test.expected, test.priority, result)
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestParsePriority(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected Priority
+		valid    bool
+	}{
+		{"low", PriorityLow, true},
+		{"Medium", PriorityNormal, true},
+		{" high ", PriorityHigh, true},
+		{"critical", PriorityCritical, true},
+		{"urgent", PriorityNormal, false},
+	}
+
+	for _, test := range tests {
+		result, err := ParsePriority(test.name)
+		if (err == nil) != test.valid {
+			t.Errorf("Unexpected error for priority %q: %v", test.name, err)
+		}
+		if result != test.expected {
+			t.Errorf("Expected %s for priority %q, got %s", test.expected, test.name, result)
+		}
+	}
+}
+
+func TestTemplateParsePriority(t *testing.T) {
+	if _, ok, err := (TaskTemplate{}).ParsePriority("bug"); ok || err != nil {
+		t.Errorf("Expected a template without a priority to set none, got %v, %v", ok, err)
+	}
+
+	priority, ok, err := TaskTemplate{Priority: "High"}.ParsePriority("bug")
+	if err != nil || !ok || priority != PriorityHigh {
+		t.Errorf("Expected high, got %s, %v, %v", priority, ok, err)
+	}
+
+	_, _, err = TaskTemplate{Priority: "urgent"}.ParsePriority("bug")
+	if !errors.Is(err, ErrInvalidPriority) || !strings.Contains(err.Error(), "template bug") {
+		t.Errorf("Expected ErrInvalidPriority naming the template, got %v", err)
+	}
+}
+
+func TestTaskAttachments(t *testing.T) {
+	task := NewTask("Review design", "")
+
+	if !task.AddAttachment("https://example.com/pr/12") || !task.AddAttachment("/docs/design.md") {
+		t.Fatal("Expected new attachments to be added")
+	}
+	if task.AddAttachment("/docs/design.md") {
+		t.Error("Expected a repeated attachment to be ignored")
+	}
+
+	// Metadata loaded from the database holds lists as []interface{}
+	task.Metadata[attachmentsKey] = []interface{}{"https://example.com/pr/12", "/docs/design.md"}
+	attachments := task.Attachments()
+	if len(attachments) != 2 || attachments[1] != "/docs/design.md" {
+		t.Errorf("Expected both attachments in order, got %v", attachments)
+	}
+}
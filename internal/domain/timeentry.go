@@ -10,6 +10,7 @@ import (
 type TimeEntry struct {
 	ID          string                 `json:"id" db:"id"`
 	TaskID      string                 `json:"task_id" db:"task_id"`
+	Category    string                 `json:"category,omitempty" db:"category"`
 	ProjectID   string                 `json:"project_id" db:"project_id"`
 	Description string                 `json:"description" db:"description"`
 	StartTime   time.Time              `json:"start_time" db:"start_time"`
@@ -20,6 +21,16 @@ type TimeEntry struct {
 	Metadata    map[string]interface{} `json:"metadata" db:"metadata"`
 }
 
+// TimeTotal is the time tracked by the completed entries that share a task,
+// category, and project
+type TimeTotal struct {
+	TaskID    string
+	Category  string
+	ProjectID string
+	Duration  time.Duration
+	Entries   int
+}
+
 func NewTimeEntry(taskID, projectID, description string) *TimeEntry {
 	now := time.Now()
 	return &TimeEntry{
@@ -34,11 +45,23 @@ func NewTimeEntry(taskID, projectID, description string) *TimeEntry {
 	}
 }
 
+// NewCategoryTimeEntry creates an entry for time not tied to a task, such as
+// meetings or admin work
+func NewCategoryTimeEntry(category, description string) *TimeEntry {
+	entry := NewTimeEntry("", "", description)
+	entry.Category = category
+	return entry
+}
+
 func (te *TimeEntry) Stop() {
-	now := time.Now()
-	te.EndTime = &now
-	te.Duration = now.Sub(te.StartTime)
-	te.UpdatedAt = now
+	te.StopAt(time.Now())
+}
+
+// StopAt ends the entry at end rather than now
+func (te *TimeEntry) StopAt(end time.Time) {
+	te.EndTime = &end
+	te.Duration = end.Sub(te.StartTime)
+	te.UpdatedAt = time.Now()
 }
 
 func (te *TimeEntry) IsActive() bool {
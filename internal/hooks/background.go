@@ -0,0 +1,88 @@
+package hooks
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+// background tracks work started by a notifier so callers can wait for it,
+// and serializes the notifier's log output
+type background struct {
+	log io.Writer
+
+	logMu   sync.Mutex
+	pending sync.WaitGroup
+}
+
+// run starts fn in a goroutine that Wait accounts for
+func (b *background) run(fn func()) {
+	b.pending.Add(1)
+	go func() {
+		defer b.pending.Done()
+		fn()
+	}()
+}
+
+// Wait blocks until running work finishes or timeout passes, so a
+// short-lived CLI process does not cut it off. It reports whether all work
+// finished.
+func (b *background) Wait(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		b.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		b.logf("Warning: notifications still running after %s were abandoned", timeout)
+		return false
+	}
+}
+
+func (b *background) logf(format string, args ...interface{}) {
+	if b.log == nil {
+		return
+	}
+	b.logMu.Lock()
+	defer b.logMu.Unlock()
+	fmt.Fprintf(b.log, format+"\n", args...)
+}
+
+// Notifier is a domain.Notifier whose work runs in the background
+type Notifier interface {
+	domain.Notifier
+	Wait(timeout time.Duration) bool
+}
+
+// Group passes each event to every notifier in it
+type Group []Notifier
+
+// Notify implements domain.Notifier
+func (g Group) Notify(event domain.Event) {
+	for _, notifier := range g {
+		notifier.Notify(event)
+	}
+}
+
+// Wait waits for every notifier, sharing one timeout between them
+func (g Group) Wait(timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	finished := true
+	for _, notifier := range g {
+		remaining := time.Until(deadline)
+		if remaining < 0 {
+			remaining = 0
+		}
+		if !notifier.Wait(remaining) {
+			finished = false
+		}
+	}
+	return finished
+}
@@ -0,0 +1,115 @@
+package hooks
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+// Runner runs the shell command configured for each lifecycle event in the
+// background, with the event's fields in PM_* environment variables
+type Runner struct {
+	background
+	commands map[domain.EventName]string
+}
+
+// NewRunner creates a runner for commands keyed by event name. Failures are
+// written to log rather than returned.
+func NewRunner(commands map[string]string, log io.Writer) *Runner {
+	r := &Runner{
+		background: background{log: log},
+		commands:   make(map[domain.EventName]string, len(commands)),
+	}
+	for name, command := range commands {
+		if strings.TrimSpace(command) != "" {
+			r.commands[domain.EventName(name)] = command
+		}
+	}
+	return r
+}
+
+// Validate returns an error naming the first configured event that pm
+// never fires
+func Validate(commands map[string]string) error {
+	for name := range commands {
+		if !isKnownEvent(domain.EventName(name)) {
+			names := make([]string, len(domain.EventNames))
+			for i, known := range domain.EventNames {
+				names[i] = string(known)
+			}
+			return fmt.Errorf("unknown hook event %q (must be one of %s)", name, strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
+
+func isKnownEvent(name domain.EventName) bool {
+	for _, known := range domain.EventNames {
+		if name == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Notify implements domain.Notifier by starting the event's command, if any
+func (r *Runner) Notify(event domain.Event) {
+	command, ok := r.commands[event.Name]
+	if !ok {
+		return
+	}
+
+	env := append(os.Environ(), Env(event)...)
+	r.run(func() {
+		cmd := shellCommand(command)
+		cmd.Env = env
+		if output, err := cmd.CombinedOutput(); err != nil {
+			r.logf("Warning: %s hook failed: %v", event.Name, err)
+			if text := strings.TrimSpace(string(output)); text != "" {
+				r.logf("  %s", text)
+			}
+		}
+	})
+}
+
+// Env returns the PM_* environment variables describing event
+func Env(event domain.Event) []string {
+	env := []string{"PM_EVENT=" + string(event.Name)}
+
+	if task := event.Task; task != nil {
+		env = append(env,
+			"PM_TASK_ID="+task.ID,
+			"PM_TASK_TITLE="+task.Title,
+			"PM_TASK_DESCRIPTION="+task.Description,
+			"PM_TASK_STATUS="+string(task.Status),
+			"PM_TASK_PRIORITY="+task.Priority.String(),
+			"PM_TASK_PROJECT_ID="+task.ProjectID,
+			"PM_TASK_TAGS="+strings.Join(task.Tags, ","),
+		)
+		if task.CompletedAt != nil {
+			env = append(env, "PM_TASK_COMPLETED_AT="+task.CompletedAt.Format(time.RFC3339))
+		}
+	}
+
+	if entry := event.TimeEntry; entry != nil {
+		env = append(env,
+			"PM_TIME_ENTRY_ID="+entry.ID,
+			"PM_TIME_ENTRY_CATEGORY="+entry.Category,
+			"PM_TIME_ENTRY_DESCRIPTION="+entry.Description,
+			"PM_TIME_ENTRY_START="+entry.StartTime.Format(time.RFC3339),
+		)
+	}
+
+	if project := event.Project; project != nil {
+		env = append(env,
+			"PM_PROJECT_ID="+project.ID,
+			"PM_PROJECT_NAME="+project.Name,
+		)
+	}
+
+	return env
+}
@@ -0,0 +1,58 @@
+package hooks
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+func TestRunnerPassesTaskFieldsToCommand(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "hook.out")
+	runner := NewRunner(map[string]string{
+		"task_completed": `printf '%s|%s|%s' "$PM_EVENT" "$PM_TASK_TITLE" "$PM_TASK_TAGS" > "` + out + `"`,
+	}, nil)
+
+	task := domain.NewTask("Ship it", "")
+	task.Tags = []string{"release", "ops"}
+	task.Complete()
+
+	runner.Notify(domain.Event{Name: domain.EventTaskCreated, Task: task})
+	runner.Notify(domain.Event{Name: domain.EventTaskCompleted, Task: task})
+	if !runner.Wait(5 * time.Second) {
+		t.Fatal("Hook did not finish")
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("Hook did not run: %v", err)
+	}
+	if got, want := string(data), "task_completed|Ship it|release,ops"; got != want {
+		t.Errorf("Hook output = %q, want %q", got, want)
+	}
+}
+
+func TestRunnerLogsFailures(t *testing.T) {
+	var log bytes.Buffer
+	runner := NewRunner(map[string]string{"timer_started": "exit 3"}, &log)
+
+	runner.Notify(domain.Event{Name: domain.EventTimerStarted})
+	runner.Wait(5 * time.Second)
+
+	if !strings.Contains(log.String(), "timer_started hook failed") {
+		t.Errorf("Expected a logged failure, got %q", log.String())
+	}
+}
+
+func TestValidateRejectsUnknownEvents(t *testing.T) {
+	if err := Validate(map[string]string{"task_completed": "true"}); err != nil {
+		t.Errorf("Validate rejected a known event: %v", err)
+	}
+	if err := Validate(map[string]string{"task_deleted": "true"}); err == nil {
+		t.Error("Validate accepted an unknown event")
+	}
+}
@@ -0,0 +1,16 @@
+//go:build windows
+
+package hooks
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// shellCommand runs command through cmd.exe. The command line is passed as
+// written, since the quoting Go applies to arguments isn't what cmd expects.
+func shellCommand(command string) *exec.Cmd {
+	cmd := exec.Command("cmd.exe")
+	cmd.SysProcAttr = &syscall.SysProcAttr{CmdLine: "cmd.exe /C " + command}
+	return cmd
+}
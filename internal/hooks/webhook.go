@@ -0,0 +1,96 @@
+package hooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+// webhookTimeout bounds each delivery attempt
+const webhookTimeout = 5 * time.Second
+
+// WebhookPayload is the JSON body posted when a task is completed
+type WebhookPayload struct {
+	Event       domain.EventName `json:"event"`
+	TaskID      string           `json:"task_id"`
+	Title       string           `json:"title"`
+	ProjectID   string           `json:"project_id,omitempty"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty"`
+}
+
+// Webhook posts task completions to a URL in the background, retrying a
+// failed delivery once
+type Webhook struct {
+	background
+	url    string
+	client *http.Client
+}
+
+// NewWebhook creates a webhook that posts to rawURL. Failures are written to
+// log rather than returned.
+func NewWebhook(rawURL string, log io.Writer) *Webhook {
+	return &Webhook{
+		background: background{log: log},
+		url:        rawURL,
+		client:     &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// ValidateWebhookURL checks that rawURL is an absolute http or https URL
+func ValidateWebhookURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("webhook_url must be an http or https URL, got %q", rawURL)
+	}
+	return nil
+}
+
+// Notify implements domain.Notifier. Only task_completed events are sent.
+func (w *Webhook) Notify(event domain.Event) {
+	if event.Name != domain.EventTaskCompleted || event.Task == nil {
+		return
+	}
+
+	body, err := json.Marshal(WebhookPayload{
+		Event:       event.Name,
+		TaskID:      event.Task.ID,
+		Title:       event.Task.Title,
+		ProjectID:   event.Task.ProjectID,
+		CompletedAt: event.Task.CompletedAt,
+	})
+	if err != nil {
+		w.logf("Warning: failed to encode webhook payload: %v", err)
+		return
+	}
+
+	w.run(func() {
+		err := w.post(body)
+		if err != nil {
+			err = w.post(body)
+		}
+		if err != nil {
+			w.logf("Warning: webhook for task %s failed: %v", domain.ShortID(event.Task.ID), err)
+		}
+	})
+}
+
+// post makes one delivery attempt
+func (w *Webhook) post(body []byte) error {
+	resp, err := w.client.Post(w.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("server responded %s", resp.Status)
+	}
+	return nil
+}
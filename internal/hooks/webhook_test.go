@@ -0,0 +1,65 @@
+package hooks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+func TestWebhookRetriesOnceAndPostsCompletion(t *testing.T) {
+	var mu sync.Mutex
+	var attempts int
+	var received WebhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer server.Close()
+
+	webhook := NewWebhook(server.URL, nil)
+	task := domain.NewTask("Ship it", "")
+	task.ProjectID = "project-1"
+	task.Complete()
+
+	webhook.Notify(domain.Event{Name: domain.EventTaskCreated, Task: task})
+	webhook.Notify(domain.Event{Name: domain.EventTaskCompleted, Task: task})
+	if !webhook.Wait(5 * time.Second) {
+		t.Fatal("Webhook did not finish")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if attempts != 2 {
+		t.Errorf("Expected one failed attempt and one retry, got %d attempts", attempts)
+	}
+	if received.TaskID != task.ID || received.Title != "Ship it" || received.ProjectID != "project-1" {
+		t.Errorf("Unexpected payload: %+v", received)
+	}
+	if received.CompletedAt == nil {
+		t.Error("Expected completed_at in the payload")
+	}
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	for _, valid := range []string{"https://example.com/hook", "http://localhost:8080"} {
+		if err := ValidateWebhookURL(valid); err != nil {
+			t.Errorf("ValidateWebhookURL(%q) = %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"example.com/hook", "ftp://example.com", "https://"} {
+		if err := ValidateWebhookURL(invalid); err == nil {
+			t.Errorf("ValidateWebhookURL(%q) accepted an invalid URL", invalid)
+		}
+	}
+}
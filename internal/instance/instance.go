@@ -0,0 +1,197 @@
+// Package instance keeps one dashboard per database. The running dashboard
+// listens on a Unix socket, which a second dashboard finds to refuse to
+// start and which CLI commands use to have the dashboard reload after a
+// change.
+package instance
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrRunning is returned when a dashboard is already open on the database
+var ErrRunning = errors.New("the dashboard is already open on this database")
+
+// errLocked is returned by lockFile when another process holds the lock
+var errLocked = errors.New("lock file is held by another process")
+
+// dialTimeout bounds connecting to and talking with the running dashboard,
+// which answers at once when it is there
+const dialTimeout = 500 * time.Millisecond
+
+// reloadCommand asks the dashboard to reload its data
+const reloadCommand = "reload"
+
+// SocketPath returns where the dashboard for the database at dbPath listens,
+// inside dir. The name is a hash of the path, since socket paths are limited
+// to around 100 bytes.
+func SocketPath(dir, dbPath string) string {
+	if abs, err := filepath.Abs(dbPath); err == nil {
+		dbPath = abs
+	}
+	sum := sha256.Sum256([]byte(dbPath))
+	return filepath.Join(dir, "tui-"+hex.EncodeToString(sum[:6])+".sock")
+}
+
+// LockPath returns the lock file of the dashboard for the database at
+// dbPath, which sits next to the database
+func LockPath(dbPath string) string {
+	return dbPath + ".tui.lock"
+}
+
+// Lock is held by the running dashboard until it is released
+type Lock struct {
+	file     *os.File
+	listener net.Listener
+	reloads  chan struct{}
+}
+
+// Acquire claims the socket at path for this process. It fails with
+// ErrRunning when another dashboard answers there; a socket left by one
+// that crashed is replaced. The lock file at lockPath is held for as long as
+// the socket, so that of two dashboards starting together, only one gets to
+// probe and replace the socket.
+func Acquire(path, lockPath string) (*Lock, error) {
+	file, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open instance lock file: %w", err)
+	}
+	if err := lockFile(file); err != nil {
+		file.Close()
+		if !errors.Is(err, errLocked) {
+			return nil, fmt.Errorf("failed to lock instance lock file: %w", err)
+		}
+		// The holder may not be listening yet, in which case its pid is
+		// unknown
+		if pid, ok := probe(path); ok {
+			return nil, fmt.Errorf("%w (pid %d)", ErrRunning, pid)
+		}
+		return nil, ErrRunning
+	}
+
+	l, err := listen(path)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	l.file = file
+	return l, nil
+}
+
+// listen claims the socket at path, which the caller's lock file protects
+func listen(path string) (*Lock, error) {
+	// A dashboard from a pm without the lock file may still answer here
+	if pid, ok := probe(path); ok {
+		return nil, fmt.Errorf("%w (pid %d)", ErrRunning, pid)
+	}
+
+	// Nobody answered, so a socket file still there is stale
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove stale instance socket: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create instance socket directory: %w", err)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create instance socket: %w", err)
+	}
+
+	l := &Lock{listener: listener, reloads: make(chan struct{}, 1)}
+	go l.serve()
+	return l, nil
+}
+
+// Reloads delivers a value each time a CLI command asks the dashboard to
+// reload. Requests arriving before the last one is taken are merged.
+func (l *Lock) Reloads() <-chan struct{} {
+	return l.reloads
+}
+
+// Release stops listening, removes the socket, and unlocks the lock file
+func (l *Lock) Release() error {
+	defer l.file.Close()
+	if err := l.listener.Close(); err != nil {
+		return fmt.Errorf("failed to close instance socket: %w", err)
+	}
+	return nil
+}
+
+// serve answers connections until the listener is closed
+func (l *Lock) serve() {
+	for {
+		conn, err := l.listener.Accept()
+		if err != nil {
+			return
+		}
+		go l.handle(conn)
+	}
+}
+
+// handle greets a connection with this process's ID, then reads at most one
+// command from it
+func (l *Lock) handle(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(dialTimeout))
+
+	if _, err := fmt.Fprintf(conn, "pm %d\n", os.Getpid()); err != nil {
+		return
+	}
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil || strings.TrimSpace(line) != reloadCommand {
+		return
+	}
+	select {
+	case l.reloads <- struct{}{}:
+	default:
+	}
+}
+
+// NotifyReload asks the dashboard listening at path, if any, to reload its
+// data, reporting whether one was there
+func NotifyReload(path string) bool {
+	conn, _, ok := connect(path)
+	if !ok {
+		return false
+	}
+	defer conn.Close()
+
+	_, err := fmt.Fprintln(conn, reloadCommand)
+	return err == nil
+}
+
+// probe reports the process ID of the dashboard listening at path, if any
+func probe(path string) (int, bool) {
+	conn, pid, ok := connect(path)
+	if !ok {
+		return 0, false
+	}
+	conn.Close()
+	return pid, true
+}
+
+// connect dials the socket at path and reads the dashboard's greeting
+func connect(path string) (net.Conn, int, bool) {
+	conn, err := net.DialTimeout("unix", path, dialTimeout)
+	if err != nil {
+		return nil, 0, false
+	}
+	conn.SetDeadline(time.Now().Add(dialTimeout))
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	pid, convErr := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(line), "pm "))
+	if err != nil || convErr != nil {
+		conn.Close()
+		return nil, 0, false
+	}
+	return conn, pid, true
+}
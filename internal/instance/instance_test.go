@@ -0,0 +1,86 @@
+package instance
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSecondDashboardIsRefused(t *testing.T) {
+	dir := t.TempDir()
+	path, lockPath := SocketPath(dir, "pm.db"), LockPath(filepath.Join(dir, "pm.db"))
+
+	lock, err := Acquire(path, lockPath)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	if _, err := Acquire(path, lockPath); !errors.Is(err, ErrRunning) {
+		t.Errorf("Expected ErrRunning while the first lock is held, got %v", err)
+	}
+
+	if err := lock.Release(); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	again, err := Acquire(path, lockPath)
+	if err != nil {
+		t.Fatalf("Expected Acquire to succeed after Release, got %v", err)
+	}
+	again.Release()
+}
+
+func TestStaleSocketIsReplaced(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tui.sock")
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	lock, err := Acquire(path, LockPath(filepath.Join(dir, "pm.db")))
+	if err != nil {
+		t.Fatalf("Expected a stale socket file to be replaced, got %v", err)
+	}
+	lock.Release()
+}
+
+func TestLockFileGuardsTheSocket(t *testing.T) {
+	dir := t.TempDir()
+	path, lockPath := SocketPath(dir, "pm.db"), LockPath(filepath.Join(dir, "pm.db"))
+
+	lock, err := Acquire(path, lockPath)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	defer lock.Release()
+
+	// As if the first dashboard had taken the lock file but not yet
+	// listened: with nothing answering, the socket must still not be taken
+	os.Remove(path)
+	if _, err := Acquire(path, lockPath); !errors.Is(err, ErrRunning) {
+		t.Errorf("Expected ErrRunning while the lock file is held, got %v", err)
+	}
+}
+
+func TestNotifyReload(t *testing.T) {
+	dir := t.TempDir()
+	path := SocketPath(dir, "pm.db")
+	if NotifyReload(path) {
+		t.Error("Expected no dashboard to notify before one is running")
+	}
+
+	lock, err := Acquire(path, LockPath(filepath.Join(dir, "pm.db")))
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	defer lock.Release()
+
+	if !NotifyReload(path) {
+		t.Fatal("Expected the running dashboard to be notified")
+	}
+	select {
+	case <-lock.Reloads():
+	case <-time.After(2 * time.Second):
+		t.Error("Expected a reload request")
+	}
+}
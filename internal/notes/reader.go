@@ -109,16 +109,24 @@ func GetNoteModTime(path string) (time.Time, error) {
 	return info.ModTime(), nil
 }
 
+// NotesDir returns the dn-tui notes directory: $DEBUG_NOTES_DIR, or
+// ~/.debug-notes when it is not set
+func NotesDir() (string, error) {
+	if notesDir := os.Getenv("DEBUG_NOTES_DIR"); notesDir != "" {
+		return notesDir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".debug-notes"), nil
+}
+
 // FindNoteByID searches for a note file in the dn-tui notes directory by ID
 func FindNoteByID(noteID string) (string, error) {
-	// Get the notes directory from environment or use default
-	notesDir := os.Getenv("DEBUG_NOTES_DIR")
-	if notesDir == "" {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return "", fmt.Errorf("failed to get home directory: %w", err)
-		}
-		notesDir = filepath.Join(home, ".debug-notes")
+	notesDir, err := NotesDir()
+	if err != nil {
+		return "", err
 	}
 
 	// Check if notes directory exists
@@ -158,3 +166,43 @@ func FindNoteByID(noteID string) (string, error) {
 
 	return "", fmt.Errorf("note with ID %s not found", noteID)
 }
+
+// ReadNoteBody returns a note's content without its frontmatter
+func ReadNoteBody(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read note file: %w", err)
+	}
+	return stripFrontmatter(string(content)), nil
+}
+
+// stripFrontmatter removes a leading frontmatter block, leaving content
+// without one unchanged
+func stripFrontmatter(content string) string {
+	if !strings.HasPrefix(content, "---\n") {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	for i := 1; i < len(lines); i++ {
+		if lines[i] == "---" {
+			return strings.Join(lines[i+1:], "\n")
+		}
+	}
+	return content
+}
+
+// Preview returns the first maxLines lines of body, ignoring surrounding blank
+// space, and whether anything was cut off
+func Preview(body string, maxLines int) (string, bool) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", false
+	}
+
+	lines := strings.Split(body, "\n")
+	if len(lines) <= maxLines {
+		return body, false
+	}
+	return strings.TrimRight(strings.Join(lines[:maxLines], "\n"), " \n"), true
+}
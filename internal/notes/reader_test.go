@@ -0,0 +1,39 @@
+package notes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadNoteBodyStripsFrontmatter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "note.md")
+	content := "---\nid: abc\ncreated: 2024-05-01T10:00:00Z\n---\n# Findings\n\nThe cache is cold.\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write note: %v", err)
+	}
+
+	body, err := ReadNoteBody(path)
+	if err != nil {
+		t.Fatalf("ReadNoteBody failed: %v", err)
+	}
+	if want := "# Findings\n\nThe cache is cold.\n"; body != want {
+		t.Errorf("Body = %q, want %q", body, want)
+	}
+
+	if _, err := ReadNoteBody(filepath.Join(t.TempDir(), "missing.md")); err == nil {
+		t.Error("Expected an error for a missing note")
+	}
+}
+
+func TestPreviewTruncatesLongNotes(t *testing.T) {
+	preview, truncated := Preview("\none\ntwo\nthree\n", 2)
+	if preview != "one\ntwo" || !truncated {
+		t.Errorf("Preview = %q, %t; want %q, true", preview, truncated, "one\ntwo")
+	}
+
+	preview, truncated = Preview("one\ntwo\n", 2)
+	if preview != "one\ntwo" || truncated {
+		t.Errorf("Preview = %q, %t; want %q, false", preview, truncated, "one\ntwo")
+	}
+}
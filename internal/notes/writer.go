@@ -0,0 +1,93 @@
+package notes
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// CreateNote writes a new note headed by title to the notes directory,
+// creating the directory when needed. Its frontmatter carries a fresh ID and
+// links, so dn-tui sees which tasks the note belongs to.
+func CreateNote(title string, links []string) (*NoteMetadata, error) {
+	notesDir, err := NotesDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(notesDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create notes directory: %w", err)
+	}
+
+	if links == nil {
+		links = []string{}
+	}
+	frontmatter, err := yaml.Marshal(NoteFrontmatter{
+		ID:      uuid.New().String(),
+		Created: time.Now().Format(time.RFC3339),
+		Links:   links,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write note frontmatter: %w", err)
+	}
+	content := "---\n" + string(frontmatter) + "---\n# " + title + "\n\n"
+
+	path, err := writeNewFile(notesDir, noteFileName(title), content)
+	if err != nil {
+		return nil, err
+	}
+	return ParseNoteFrontmatter(path)
+}
+
+// noteFileName names a note after the date and its title, such as
+// "2024-05-01-fix-login-redirect"
+func noteFileName(title string) string {
+	var slug strings.Builder
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			slug.WriteRune(r)
+		case slug.Len() > 0 && !strings.HasSuffix(slug.String(), "-"):
+			slug.WriteRune('-')
+		}
+	}
+
+	name := time.Now().Format("2006-01-02")
+	if s := strings.Trim(slug.String(), "-"); s != "" {
+		name += "-" + s
+	}
+	return name
+}
+
+// writeNewFile writes content to name.md in dir, numbering the name when a
+// note of that name already exists, and returns the path written
+func writeNewFile(dir, name, content string) (string, error) {
+	for i := 1; ; i++ {
+		path := filepath.Join(dir, name+".md")
+		if i > 1 {
+			path = filepath.Join(dir, fmt.Sprintf("%s-%d.md", name, i))
+		}
+
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to create note file: %w", err)
+		}
+
+		if _, err := file.WriteString(content); err != nil {
+			file.Close()
+			return "", fmt.Errorf("failed to write note file: %w", err)
+		}
+		if err := file.Close(); err != nil {
+			return "", fmt.Errorf("failed to write note file: %w", err)
+		}
+		return path, nil
+	}
+}
@@ -0,0 +1,41 @@
+package notes
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCreateNoteLinksTheTask(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "notes")
+	t.Setenv("DEBUG_NOTES_DIR", dir)
+
+	note, err := CreateNote("Fix login redirect!", []string{"task-1"})
+	if err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+	if note.ID == "" || filepath.Dir(note.Path) != dir {
+		t.Fatalf("Expected a note with an ID in %s, got %+v", dir, note)
+	}
+	if !strings.HasSuffix(note.Path, "-fix-login-redirect.md") {
+		t.Errorf("Expected the file to be named after the title, got %s", note.Path)
+	}
+
+	found, err := FindNoteByID(note.ID)
+	if err != nil || found != note.Path {
+		t.Errorf("Expected FindNoteByID to find %s, got %s (%v)", note.Path, found, err)
+	}
+
+	body, err := ReadNoteBody(note.Path)
+	if err != nil || body != "# Fix login redirect!\n\n" {
+		t.Errorf("Body = %q (%v)", body, err)
+	}
+
+	again, err := CreateNote("Fix login redirect!", nil)
+	if err != nil {
+		t.Fatalf("CreateNote failed: %v", err)
+	}
+	if again.Path == note.Path || again.ID == note.ID {
+		t.Errorf("Expected a second note with the same title to get its own file and ID")
+	}
+}
@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+// ProjectRepository is an in-memory domain.ProjectRepository
+type ProjectRepository struct {
+	store *Store
+}
+
+func NewProjectRepository(store *Store) *ProjectRepository {
+	return &ProjectRepository{store: store}
+}
+
+func (r *ProjectRepository) Create(ctx context.Context, project *domain.Project) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.projects[project.ID]; exists {
+		return fmt.Errorf("failed to create project: project %s already exists", project.ID)
+	}
+	if r.nameTaken(project.Name, project.ID) {
+		return domain.ErrDuplicateProject
+	}
+	r.store.projects[project.ID] = cloneProject(project)
+	return nil
+}
+
+func (r *ProjectRepository) GetByID(ctx context.Context, id string) (*domain.Project, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	project, ok := r.store.projects[id]
+	if !ok {
+		return nil, domain.ErrProjectNotFound
+	}
+	return cloneProject(project), nil
+}
+
+func (r *ProjectRepository) GetByName(ctx context.Context, name string) (*domain.Project, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, project := range r.store.projects {
+		if project.Name == name {
+			return cloneProject(project), nil
+		}
+	}
+	return nil, domain.ErrProjectNotFound
+}
+
+// GetByIDs fetches the projects with the given IDs, keyed by ID. Blank and
+// unknown IDs are skipped.
+func (r *ProjectRepository) GetByIDs(ctx context.Context, ids []string) (map[string]*domain.Project, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	projects := make(map[string]*domain.Project)
+	for _, id := range ids {
+		if project, ok := r.store.projects[id]; ok {
+			projects[id] = cloneProject(project)
+		}
+	}
+	return projects, nil
+}
+
+// List returns the projects matching filter, newest first
+func (r *ProjectRepository) List(ctx context.Context, filter domain.ProjectFilter) ([]*domain.Project, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	search := strings.ToLower(filter.Search)
+
+	var projects []*domain.Project
+	for _, project := range r.store.projects {
+		if len(filter.Status) > 0 && !containsProjectStatus(filter.Status, project.Status) {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(project.Name), search) &&
+			!strings.Contains(strings.ToLower(project.Description), search) {
+			continue
+		}
+		projects = append(projects, cloneProject(project))
+	}
+
+	sort.SliceStable(projects, func(i, j int) bool {
+		return projects[i].CreatedAt.After(projects[j].CreatedAt)
+	})
+
+	start, end := page(len(projects), filter.Limit, filter.Offset)
+	return projects[start:end], nil
+}
+
+func (r *ProjectRepository) Update(ctx context.Context, project *domain.Project) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.nameTaken(project.Name, project.ID) {
+		return domain.ErrDuplicateProject
+	}
+	if _, ok := r.store.projects[project.ID]; !ok {
+		return domain.ErrProjectNotFound
+	}
+	r.store.projects[project.ID] = cloneProject(project)
+	return nil
+}
+
+// Delete removes a project along with its tasks and time entries
+func (r *ProjectRepository) Delete(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.projects[id]; !ok {
+		return domain.ErrProjectNotFound
+	}
+	delete(r.store.projects, id)
+
+	for taskID, task := range r.store.tasks {
+		if task.ProjectID == id {
+			r.store.deleteTask(taskID)
+		}
+	}
+	for entryID, entry := range r.store.entries {
+		if entry.ProjectID == id {
+			delete(r.store.entries, entryID)
+		}
+	}
+	return nil
+}
+
+// nameTaken reports whether a project other than id is called name. The
+// caller holds the lock.
+func (r *ProjectRepository) nameTaken(name, id string) bool {
+	for _, project := range r.store.projects {
+		if project.Name == name && project.ID != id {
+			return true
+		}
+	}
+	return false
+}
+
+func containsProjectStatus(statuses []domain.ProjectStatus, status domain.ProjectStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+// newRepositories returns the three repositories over one store, typed as the
+// domain interfaces they implement
+func newRepositories() (*Store, domain.TaskRepository, domain.ProjectRepository, domain.TimeEntryRepository) {
+	store := NewStore()
+	return store, NewTaskRepository(store), NewProjectRepository(store), NewTimeEntryRepository(store)
+}
+
+func TestListFiltersLikeSQLite(t *testing.T) {
+	_, tasks, projects, _ := newRepositories()
+	ctx := context.Background()
+
+	archived := domain.NewProject("Old", "")
+	archived.Status = domain.ProjectStatusArchived
+	if err := projects.Create(ctx, archived); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	now := time.Now()
+	due := now.AddDate(0, 0, 2)
+	create := func(title string, tags []string, created time.Time, mutate func(*domain.Task)) {
+		task := domain.NewTask(title, "")
+		task.Tags = tags
+		task.CreatedAt = created
+		if mutate != nil {
+			mutate(task)
+		}
+		if err := tasks.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+	create("Crash on save", []string{"bug", "urgent"}, now, func(task *domain.Task) { task.DueDate = &due })
+	create("Typo in help", []string{"bug"}, now.Add(-time.Hour), nil)
+	create("Old crash", []string{"bug", "urgent"}, now.AddDate(0, 0, -10), func(task *domain.Task) {
+		task.ProjectID = archived.ID
+		task.UpdatedAt = now.Add(time.Minute)
+	})
+
+	tests := []struct {
+		name   string
+		filter domain.TaskFilter
+		want   []string
+	}{
+		{"all, newest first", domain.TaskFilter{}, []string{"Crash on save", "Typo in help", "Old crash"}},
+		{"every tag", domain.TaskFilter{Tags: []string{"bug", "urgent"}}, []string{"Crash on save", "Old crash"}},
+		{"search ignores case", domain.TaskFilter{Search: "CRASH"}, []string{"Crash on save", "Old crash"}},
+		{"due before", domain.TaskFilter{DueBefore: &due}, []string{"Crash on save"}},
+		{"archived projects", domain.TaskFilter{ExcludeArchivedProjects: true}, []string{"Crash on save", "Typo in help"}},
+		{"limit and offset", domain.TaskFilter{Limit: 1, Offset: 1}, []string{"Typo in help"}},
+		{"recently updated", domain.TaskFilter{SortBy: domain.SortByUpdated, Limit: 1}, []string{"Old crash"}},
+	}
+
+	for _, tt := range tests {
+		got, err := tasks.List(ctx, tt.filter)
+		if err != nil {
+			t.Fatalf("%s: failed to list tasks: %v", tt.name, err)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: expected %d tasks, got %d", tt.name, len(tt.want), len(got))
+			continue
+		}
+		for i, task := range got {
+			if task.Title != tt.want[i] {
+				t.Errorf("%s: expected %q at %d, got %q", tt.name, tt.want[i], i, task.Title)
+			}
+		}
+	}
+}
+
+func TestReturnedTasksAreCopies(t *testing.T) {
+	_, tasks, _, _ := newRepositories()
+	ctx := context.Background()
+
+	task := domain.NewTask("Original", "")
+	if err := tasks.Create(ctx, task); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	task.Title = "Changed without saving"
+
+	got, _ := tasks.GetByID(ctx, task.ID)
+	got.Tags = append(got.Tags, "unsaved")
+
+	stored, _ := tasks.GetByID(ctx, task.ID)
+	if stored.Title != "Original" || len(stored.Tags) != 0 {
+		t.Errorf("Expected the stored task to be unchanged, got %q %v", stored.Title, stored.Tags)
+	}
+}
+
+func TestDeletingProjectCascades(t *testing.T) {
+	_, tasks, projects, entries := newRepositories()
+	ctx := context.Background()
+
+	project := domain.NewProject("Website", "")
+	projects.Create(ctx, project)
+
+	parent := domain.NewTask("Launch", "")
+	parent.ProjectID = project.ID
+	tasks.Create(ctx, parent)
+
+	child := domain.NewTask("Write copy", "")
+	child.ParentID = &parent.ID
+	tasks.Create(ctx, child)
+	tasks.AddComment(ctx, domain.NewComment(child.ID, "Draft ready"))
+
+	entry := domain.NewTimeEntry(child.ID, "", "Writing")
+	entries.Create(ctx, entry)
+
+	if err := projects.Delete(ctx, project.ID); err != nil {
+		t.Fatalf("Failed to delete project: %v", err)
+	}
+
+	if _, err := tasks.GetByID(ctx, child.ID); !errors.Is(err, domain.ErrTaskNotFound) {
+		t.Errorf("Expected the subtask to be deleted, got %v", err)
+	}
+	if _, err := entries.GetByID(ctx, entry.ID); !errors.Is(err, domain.ErrTimeEntryNotFound) {
+		t.Errorf("Expected the time entry to be deleted, got %v", err)
+	}
+	if comments, _ := tasks.ListComments(ctx, child.ID); len(comments) != 0 {
+		t.Errorf("Expected the comments to be deleted, got %d", len(comments))
+	}
+}
+
+func TestOnlyOneActiveTimeEntry(t *testing.T) {
+	_, _, _, entries := newRepositories()
+	ctx := context.Background()
+
+	if err := entries.Create(ctx, domain.NewTimeEntry("", "", "First")); err != nil {
+		t.Fatalf("Failed to create time entry: %v", err)
+	}
+	if err := entries.Create(ctx, domain.NewTimeEntry("", "", "Second")); !errors.Is(err, domain.ErrActiveTimeEntry) {
+		t.Errorf("Expected ErrActiveTimeEntry, got %v", err)
+	}
+
+	active, err := entries.GetActive(ctx)
+	if err != nil || active.Description != "First" {
+		t.Errorf("Expected the first entry to be active, got %v, %v", active, err)
+	}
+}
+
+func TestUniqueProjectNames(t *testing.T) {
+	_, _, projects, _ := newRepositories()
+	ctx := context.Background()
+
+	projects.Create(ctx, domain.NewProject("Website", ""))
+	if err := projects.Create(ctx, domain.NewProject("Website", "")); !errors.Is(err, domain.ErrDuplicateProject) {
+		t.Errorf("Expected ErrDuplicateProject, got %v", err)
+	}
+}
+
+func TestWithinTransactionRollsBackOnError(t *testing.T) {
+	store, tasks, _, _ := newRepositories()
+	ctx := context.Background()
+
+	kept := domain.NewTask("Kept", "")
+	tasks.Create(ctx, kept)
+
+	failure := errors.New("boom")
+	err := store.WithinTransaction(ctx, func(ctx context.Context) error {
+		tasks.Create(ctx, domain.NewTask("Discarded", ""))
+		renamed, _ := tasks.GetByID(ctx, kept.ID)
+		renamed.Title = "Renamed"
+		tasks.Update(ctx, renamed)
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Expected the transaction error, got %v", err)
+	}
+
+	all, _ := tasks.List(ctx, domain.TaskFilter{})
+	if len(all) != 1 || all[0].Title != "Kept" {
+		t.Errorf("Expected only the unchanged task to remain, got %d tasks", len(all))
+	}
+}
@@ -0,0 +1,149 @@
+// Package memory implements the domain repositories in memory, for tests and
+// for scratch sessions that should leave nothing on disk. Its behavior
+// follows the SQLite repositories: the same filters and ordering, cascading
+// deletes, one active timer, and unique project names.
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+// Store holds the data shared by the repositories created from it, so that
+// deleting a project or task cascades across them as it does in SQLite
+type Store struct {
+	mu        sync.Mutex
+	tasks     map[string]*domain.Task
+	projects  map[string]*domain.Project
+	entries   map[string]*domain.TimeEntry
+	comments  []*domain.Comment
+	sequences map[string]int
+}
+
+// NewStore creates an empty store
+func NewStore() *Store {
+	return &Store{
+		tasks:     make(map[string]*domain.Task),
+		projects:  make(map[string]*domain.Project),
+		entries:   make(map[string]*domain.TimeEntry),
+		sequences: make(map[string]int),
+	}
+}
+
+// txKey marks a context running inside WithinTransaction
+type txKey struct{}
+
+// WithinTransaction implements domain.Transactor. The store is restored to
+// its state before fn if fn returns an error or the context is cancelled.
+// Nested calls join the outer transaction.
+func (s *Store) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(txKey{}) != nil {
+		return fn(ctx)
+	}
+
+	saved := s.snapshot()
+	err := fn(context.WithValue(ctx, txKey{}, true))
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		s.restore(saved)
+	}
+	return err
+}
+
+// snapshot copies the store's maps. Stored values are replaced rather than
+// modified, so copying the maps is enough to restore them later.
+func (s *Store) snapshot() *Store {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved := NewStore()
+	for id, task := range s.tasks {
+		saved.tasks[id] = task
+	}
+	for id, project := range s.projects {
+		saved.projects[id] = project
+	}
+	for id, entry := range s.entries {
+		saved.entries[id] = entry
+	}
+	saved.comments = append([]*domain.Comment(nil), s.comments...)
+	for prefix, number := range s.sequences {
+		saved.sequences[prefix] = number
+	}
+	return saved
+}
+
+// restore puts back the data from a snapshot
+func (s *Store) restore(saved *Store) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tasks = saved.tasks
+	s.projects = saved.projects
+	s.entries = saved.entries
+	s.comments = saved.comments
+	s.sequences = saved.sequences
+}
+
+// deleteTask removes a task with its subtasks, time entries, and comments.
+// The caller holds the lock.
+func (s *Store) deleteTask(id string) {
+	delete(s.tasks, id)
+
+	for childID, task := range s.tasks {
+		if task.ParentID != nil && *task.ParentID == id {
+			s.deleteTask(childID)
+		}
+	}
+	for entryID, entry := range s.entries {
+		if entry.TaskID == id {
+			delete(s.entries, entryID)
+		}
+	}
+
+	kept := s.comments[:0:0]
+	for _, comment := range s.comments {
+		if comment.TaskID != id {
+			kept = append(kept, comment)
+		}
+	}
+	s.comments = kept
+}
+
+// The clone functions copy values going in and out of the store, so callers
+// can only change stored data through the repositories, as with SQLite
+
+func cloneTask(task *domain.Task) *domain.Task {
+	clone := *task
+	clone.Tags = append([]string{}, task.Tags...)
+	clone.Metadata = cloneMetadata(task.Metadata)
+	if task.ParentID != nil {
+		parentID := *task.ParentID
+		clone.ParentID = &parentID
+	}
+	return &clone
+}
+
+func cloneProject(project *domain.Project) *domain.Project {
+	clone := *project
+	clone.Metadata = cloneMetadata(project.Metadata)
+	return &clone
+}
+
+func cloneTimeEntry(entry *domain.TimeEntry) *domain.TimeEntry {
+	clone := *entry
+	clone.Metadata = cloneMetadata(entry.Metadata)
+	return &clone
+}
+
+func cloneMetadata(metadata map[string]interface{}) map[string]interface{} {
+	clone := make(map[string]interface{}, len(metadata))
+	for key, value := range metadata {
+		clone[key] = value
+	}
+	return clone
+}
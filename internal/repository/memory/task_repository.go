@@ -0,0 +1,332 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+// TaskRepository is an in-memory domain.TaskRepository
+type TaskRepository struct {
+	store *Store
+}
+
+func NewTaskRepository(store *Store) *TaskRepository {
+	return &TaskRepository{store: store}
+}
+
+func (r *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.tasks[task.ID]; exists {
+		return fmt.Errorf("failed to create task: task %s already exists", task.ID)
+	}
+	if r.refTaken(task.Ref, task.ID) {
+		return fmt.Errorf("failed to create task: ref %s already exists", task.Ref)
+	}
+	r.store.tasks[task.ID] = cloneTask(task)
+	return nil
+}
+
+func (r *TaskRepository) GetByID(ctx context.Context, id string) (*domain.Task, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	task, ok := r.store.tasks[id]
+	if !ok {
+		return nil, domain.ErrTaskNotFound
+	}
+	return cloneTask(task), nil
+}
+
+// GetByRef returns the task with the sequential reference ref
+func (r *TaskRepository) GetByRef(ctx context.Context, ref string) (*domain.Task, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if ref != "" {
+		for _, task := range r.store.tasks {
+			if task.Ref == ref {
+				return cloneTask(task), nil
+			}
+		}
+	}
+	return nil, domain.ErrTaskNotFound
+}
+
+// NextTaskNumber increments and returns the counter for prefix
+func (r *TaskRepository) NextTaskNumber(ctx context.Context, prefix string) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.sequences[prefix]++
+	return r.store.sequences[prefix], nil
+}
+
+func (r *TaskRepository) List(ctx context.Context, filter domain.TaskFilter) ([]*domain.Task, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.list(filter), nil
+}
+
+// ListEach passes the tasks matching filter to fn, stopping at the first
+// error fn returns
+func (r *TaskRepository) ListEach(ctx context.Context, filter domain.TaskFilter, fn func(*domain.Task) error) error {
+	tasks, err := r.List(ctx, filter)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if err := fn(task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListWithSubtaskCounts lists the tasks matching filter along with their
+// number of direct subtasks
+func (r *TaskRepository) ListWithSubtaskCounts(ctx context.Context, filter domain.TaskFilter) ([]*domain.TaskWithSubtaskCount, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	counts := make(map[string]int)
+	for _, task := range r.store.tasks {
+		if task.ParentID != nil {
+			counts[*task.ParentID]++
+		}
+	}
+
+	tasks := r.list(filter)
+	counted := make([]*domain.TaskWithSubtaskCount, 0, len(tasks))
+	for _, task := range tasks {
+		counted = append(counted, &domain.TaskWithSubtaskCount{Task: task, SubtaskCount: counts[task.ID]})
+	}
+	return counted, nil
+}
+
+// list returns copies of the tasks matching filter, newest first by the
+// time filter sorts by. The caller holds the lock.
+func (r *TaskRepository) list(filter domain.TaskFilter) []*domain.Task {
+	var tasks []*domain.Task
+	for _, task := range r.store.tasks {
+		if r.matches(task, filter) {
+			tasks = append(tasks, cloneTask(task))
+		}
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if filter.SortBy == domain.SortByUpdated {
+			return tasks[i].UpdatedAt.After(tasks[j].UpdatedAt)
+		}
+		return tasks[i].CreatedAt.After(tasks[j].CreatedAt)
+	})
+
+	start, end := page(len(tasks), filter.Limit, filter.Offset)
+	return tasks[start:end]
+}
+
+// matches reports whether task passes every condition of filter, as the
+// SQLite query's WHERE clause would. The caller holds the lock.
+func (r *TaskRepository) matches(task *domain.Task, filter domain.TaskFilter) bool {
+	if len(filter.Status) > 0 && !containsStatus(filter.Status, task.Status) {
+		return false
+	}
+	if len(filter.Priority) > 0 && !containsPriority(filter.Priority, task.Priority) {
+		return false
+	}
+	if filter.ProjectID != "" && task.ProjectID != filter.ProjectID {
+		return false
+	}
+	if filter.Workspace != "" && task.Workspace != filter.Workspace {
+		return false
+	}
+	if filter.ExcludeArchivedProjects && task.ProjectID != "" {
+		if project, ok := r.store.projects[task.ProjectID]; ok && project.Status == domain.ProjectStatusArchived {
+			return false
+		}
+	}
+
+	// Tasks without a due date never match a due date bound
+	if filter.DueBefore != nil && (task.DueDate == nil || task.DueDate.After(*filter.DueBefore)) {
+		return false
+	}
+	if filter.DueAfter != nil && (task.DueDate == nil || task.DueDate.Before(*filter.DueAfter)) {
+		return false
+	}
+	if filter.CreatedAfter != nil && task.CreatedAt.Before(*filter.CreatedAfter) {
+		return false
+	}
+	if filter.CreatedBefore != nil && task.CreatedAt.After(*filter.CreatedBefore) {
+		return false
+	}
+
+	for _, tag := range filter.Tags {
+		if !task.HasTag(tag) {
+			return false
+		}
+	}
+
+	if filter.Search != "" {
+		search := strings.ToLower(filter.Search)
+		if !strings.Contains(strings.ToLower(task.Title), search) &&
+			!strings.Contains(strings.ToLower(task.Description), search) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.tasks[task.ID]; !ok {
+		return domain.ErrTaskNotFound
+	}
+	if r.refTaken(task.Ref, task.ID) {
+		return fmt.Errorf("failed to update task: ref %s already exists", task.Ref)
+	}
+	r.store.tasks[task.ID] = cloneTask(task)
+	return nil
+}
+
+// UpdateTasks updates several tasks at once, so either all of the changes
+// are saved or none are
+func (r *TaskRepository) UpdateTasks(ctx context.Context, tasks []*domain.Task) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, task := range tasks {
+		if _, ok := r.store.tasks[task.ID]; !ok {
+			return domain.ErrTaskNotFound
+		}
+	}
+	for _, task := range tasks {
+		r.store.tasks[task.ID] = cloneTask(task)
+	}
+	return nil
+}
+
+// Delete removes a task along with its subtasks, time entries, and comments
+func (r *TaskRepository) Delete(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.tasks[id]; !ok {
+		return domain.ErrTaskNotFound
+	}
+	r.store.deleteTask(id)
+	return nil
+}
+
+func (r *TaskRepository) GetByProject(ctx context.Context, projectID string) ([]*domain.Task, error) {
+	return r.List(ctx, domain.TaskFilter{ProjectID: projectID})
+}
+
+// GetSubtasks returns a task's direct subtasks, oldest first
+func (r *TaskRepository) GetSubtasks(ctx context.Context, parentID string) ([]*domain.Task, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var tasks []*domain.Task
+	for _, task := range r.store.tasks {
+		if task.ParentID != nil && *task.ParentID == parentID {
+			tasks = append(tasks, cloneTask(task))
+		}
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
+	})
+	return tasks, nil
+}
+
+// SearchTasks matches titles and descriptions containing query, like the
+// SQLite repository does when its full-text index is unavailable
+func (r *TaskRepository) SearchTasks(ctx context.Context, query string) ([]*domain.Task, error) {
+	return r.List(ctx, domain.TaskFilter{Search: query})
+}
+
+func (r *TaskRepository) AddComment(ctx context.Context, comment *domain.Comment) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.tasks[comment.TaskID]; !ok {
+		return fmt.Errorf("failed to add comment: %w", domain.ErrTaskNotFound)
+	}
+	clone := *comment
+	r.store.comments = append(r.store.comments, &clone)
+	return nil
+}
+
+// ListComments returns a task's comments, oldest first
+func (r *TaskRepository) ListComments(ctx context.Context, taskID string) ([]*domain.Comment, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var comments []*domain.Comment
+	for _, comment := range r.store.comments {
+		if comment.TaskID == taskID {
+			clone := *comment
+			comments = append(comments, &clone)
+		}
+	}
+
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+	})
+	return comments, nil
+}
+
+// refTaken reports whether a task other than id has the reference ref, which
+// the SQLite schema's unique index forbids. The caller holds the lock.
+func (r *TaskRepository) refTaken(ref, id string) bool {
+	if ref == "" {
+		return false
+	}
+	for _, task := range r.store.tasks {
+		if task.Ref == ref && task.ID != id {
+			return true
+		}
+	}
+	return false
+}
+
+func containsStatus(statuses []domain.TaskStatus, status domain.TaskStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPriority(priorities []domain.Priority, priority domain.Priority) bool {
+	for _, p := range priorities {
+		if p == priority {
+			return true
+		}
+	}
+	return false
+}
+
+// page returns the bounds of the slice of n items selected by limit and
+// offset, where zero means no limit or no offset
+func page(n, limit, offset int) (int, int) {
+	start := offset
+	if start > n {
+		start = n
+	}
+	end := n
+	if limit > 0 && start+limit < n {
+		end = start + limit
+	}
+	return start, end
+}
@@ -0,0 +1,166 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+// TimeEntryRepository is an in-memory domain.TimeEntryRepository
+type TimeEntryRepository struct {
+	store *Store
+}
+
+func NewTimeEntryRepository(store *Store) *TimeEntryRepository {
+	return &TimeEntryRepository{store: store}
+}
+
+func (r *TimeEntryRepository) Create(ctx context.Context, entry *domain.TimeEntry) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.entries[entry.ID]; exists {
+		return fmt.Errorf("failed to create time entry: entry %s already exists", entry.ID)
+	}
+	if entry.IsActive() && r.otherActive(entry.ID) {
+		return domain.ErrActiveTimeEntry
+	}
+	r.store.entries[entry.ID] = cloneTimeEntry(entry)
+	return nil
+}
+
+func (r *TimeEntryRepository) GetByID(ctx context.Context, id string) (*domain.TimeEntry, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	entry, ok := r.store.entries[id]
+	if !ok {
+		return nil, domain.ErrTimeEntryNotFound
+	}
+	return cloneTimeEntry(entry), nil
+}
+
+// List returns the entries matching filter, latest start first
+func (r *TimeEntryRepository) List(ctx context.Context, filter domain.TimeEntryFilter) ([]*domain.TimeEntry, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var entries []*domain.TimeEntry
+	for _, entry := range r.store.entries {
+		if matchesTimeEntry(entry, filter) {
+			entries = append(entries, cloneTimeEntry(entry))
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].StartTime.After(entries[j].StartTime)
+	})
+
+	start, end := page(len(entries), filter.Limit, filter.Offset)
+	return entries[start:end], nil
+}
+
+// SumDurations totals the completed entries matching filter by task,
+// category, and project. Limit and Offset are ignored.
+func (r *TimeEntryRepository) SumDurations(ctx context.Context, filter domain.TimeEntryFilter) ([]*domain.TimeTotal, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	byKey := make(map[domain.TimeTotal]*domain.TimeTotal)
+	var totals []*domain.TimeTotal
+	for _, entry := range r.store.entries {
+		if entry.IsActive() || !matchesTimeEntry(entry, filter) {
+			continue
+		}
+		key := domain.TimeTotal{TaskID: entry.TaskID, Category: entry.Category, ProjectID: entry.ProjectID}
+		total, ok := byKey[key]
+		if !ok {
+			total = &domain.TimeTotal{TaskID: entry.TaskID, Category: entry.Category, ProjectID: entry.ProjectID}
+			byKey[key] = total
+			totals = append(totals, total)
+		}
+		total.Duration += entry.Duration
+		total.Entries++
+	}
+	return totals, nil
+}
+
+// matchesTimeEntry reports whether entry passes every condition of filter.
+// Running entries count as ending before any EndBefore bound.
+func matchesTimeEntry(entry *domain.TimeEntry, filter domain.TimeEntryFilter) bool {
+	if filter.TaskID != "" && entry.TaskID != filter.TaskID {
+		return false
+	}
+	if filter.ProjectID != "" && entry.ProjectID != filter.ProjectID {
+		return false
+	}
+	if filter.StartAfter != nil && entry.StartTime.Before(*filter.StartAfter) {
+		return false
+	}
+	if filter.EndBefore != nil && entry.EndTime != nil && entry.EndTime.After(*filter.EndBefore) {
+		return false
+	}
+	if filter.Active != nil && entry.IsActive() != *filter.Active {
+		return false
+	}
+	return true
+}
+
+func (r *TimeEntryRepository) Update(ctx context.Context, entry *domain.TimeEntry) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if entry.IsActive() && r.otherActive(entry.ID) {
+		return domain.ErrActiveTimeEntry
+	}
+	if _, ok := r.store.entries[entry.ID]; !ok {
+		return domain.ErrTimeEntryNotFound
+	}
+	r.store.entries[entry.ID] = cloneTimeEntry(entry)
+	return nil
+}
+
+func (r *TimeEntryRepository) Delete(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.entries[id]; !ok {
+		return domain.ErrTimeEntryNotFound
+	}
+	delete(r.store.entries, id)
+	return nil
+}
+
+// GetActive returns the running entry, if any
+func (r *TimeEntryRepository) GetActive(ctx context.Context) (*domain.TimeEntry, error) {
+	active := true
+	entries, err := r.List(ctx, domain.TimeEntryFilter{Active: &active, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, domain.ErrNoActiveTimeEntry
+	}
+	return entries[0], nil
+}
+
+func (r *TimeEntryRepository) GetByTask(ctx context.Context, taskID string) ([]*domain.TimeEntry, error) {
+	return r.List(ctx, domain.TimeEntryFilter{TaskID: taskID})
+}
+
+func (r *TimeEntryRepository) GetByProject(ctx context.Context, projectID string) ([]*domain.TimeEntry, error) {
+	return r.List(ctx, domain.TimeEntryFilter{ProjectID: projectID})
+}
+
+// otherActive reports whether an entry other than id is running, which the
+// SQLite schema's unique index forbids. The caller holds the lock.
+func (r *TimeEntryRepository) otherActive(id string) bool {
+	for _, entry := range r.store.entries {
+		if entry.IsActive() && entry.ID != id {
+			return true
+		}
+	}
+	return false
+}
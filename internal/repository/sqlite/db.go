@@ -3,36 +3,65 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/adriannajera/project-manager-cli/internal/domain"
 	_ "modernc.org/sqlite"
 )
 
 type DB struct {
 	*sql.DB
+
+	// fts reports whether the FTS5 task index is available
+	fts bool
 }
 
+// busyRetryTimeout is how long the second attempt to open a database that
+// another process holds locked waits for the lock
+const busyRetryTimeout = 5 * time.Second
+
+// NewDB opens the database at dbPath and brings its schema up to date. A
+// database locked by another process is retried once, waiting for the lock;
+// errors for a locked or corrupt file wrap domain.ErrDatabaseLocked or
+// domain.ErrDatabaseCorrupt.
 func NewDB(dbPath string) (*DB, error) {
 	if err := ensureDBDir(dbPath); err != nil {
 		return nil, fmt.Errorf("failed to create database directory: %w", err)
 	}
 
+	db, err := openDB(dbPath, 0)
+	if errors.Is(err, domain.ErrDatabaseLocked) {
+		db, err = openDB(dbPath, busyRetryTimeout)
+	}
+	return db, err
+}
+
+// openDB opens and migrates the database, waiting up to busyTimeout for
+// locks held by other connections
+func openDB(dbPath string, busyTimeout time.Duration) (*DB, error) {
 	// Add _time_format parameter to properly handle DATETIME columns
 	connStr := dbPath + "?_time_format=sqlite"
+	if busyTimeout > 0 {
+		connStr += fmt.Sprintf("&_pragma=busy_timeout(%d)", busyTimeout.Milliseconds())
+	}
 	db, err := sql.Open("sqlite", connStr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
 	if err := db.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", classifyError(err))
 	}
 
 	// Enable foreign key constraints
 	if _, err := db.Exec("PRAGMA foreign_keys = ON"); err != nil {
-		return nil, fmt.Errorf("failed to enable foreign keys: %w", err)
+		db.Close()
+		return nil, fmt.Errorf("failed to enable foreign keys: %w", classifyError(err))
 	}
 
 	db.SetMaxOpenConns(1)
@@ -41,12 +70,47 @@ func NewDB(dbPath string) (*DB, error) {
 
 	if err := RunMigrations(context.Background(), db); err != nil {
 		sqliteDB.Close()
-		return nil, fmt.Errorf("failed to run migrations: %w", err)
+		return nil, fmt.Errorf("failed to run migrations: %w", classifyError(err))
+	}
+
+	fts, err := featureEnabled(context.Background(), db, "fts5")
+	if err != nil {
+		sqliteDB.Close()
+		return nil, fmt.Errorf("failed to check for full-text search: %w", classifyError(err))
 	}
+	sqliteDB.fts = fts
 
 	return sqliteDB, nil
 }
 
+// SQLite result codes that mean the file is locked or unreadable
+const (
+	sqliteBusy    = 5
+	sqliteLocked  = 6
+	sqliteCorrupt = 11
+	sqliteNotADB  = 26
+)
+
+// classifyError marks err as domain.ErrDatabaseLocked or
+// domain.ErrDatabaseCorrupt when SQLite reports one of those conditions,
+// keeping the driver's message
+func classifyError(err error) error {
+	var coded interface{ Code() int }
+	if !errors.As(err, &coded) {
+		return err
+	}
+
+	// Extended result codes carry the primary code in the low byte
+	switch coded.Code() & 0xff {
+	case sqliteBusy, sqliteLocked:
+		return fmt.Errorf("%w: %w", domain.ErrDatabaseLocked, err)
+	case sqliteCorrupt, sqliteNotADB:
+		return fmt.Errorf("%w: %w", domain.ErrDatabaseCorrupt, err)
+	default:
+		return err
+	}
+}
+
 func ensureDBDir(dbPath string) error {
 	dir := filepath.Dir(dbPath)
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
@@ -61,4 +125,57 @@ func (db *DB) Close() error {
 
 func (db *DB) BeginTx(ctx context.Context) (*sql.Tx, error) {
 	return db.DB.BeginTx(ctx, nil)
+}
+
+// TotalChanges returns the number of rows inserted, updated or deleted
+// through db since it was opened. The database keeps a single connection, so
+// comparing two readings tells whether anything was written in between.
+func (db *DB) TotalChanges(ctx context.Context) (int64, error) {
+	var changes int64
+	err := db.QueryRowContext(ctx, "SELECT total_changes()").Scan(&changes)
+	return changes, err
+}
+
+// querier is implemented by both *sql.DB and *sql.Tx
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// txKey marks the transaction stored in a context by WithinTransaction
+type txKey struct{}
+
+// conn returns the transaction carried by ctx, or the database itself. With a
+// single open connection, a query outside a running transaction would block,
+// so every repository query must go through conn.
+func (db *DB) conn(ctx context.Context) querier {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db.DB
+}
+
+// WithinTransaction implements domain.Transactor. Nested calls join the
+// outer transaction.
+func (db *DB) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
 }
\ No newline at end of file
@@ -0,0 +1,117 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+func TestWithinTransactionRollsBackOnError(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	taskRepo := NewTaskRepository(db)
+	timeEntryRepo := NewTimeEntryRepository(db)
+	ctx := context.Background()
+
+	task := domain.NewTask("Rolled back", "")
+	entry := domain.NewCategoryTimeEntry("meetings", "")
+	failure := errors.New("failure after create")
+
+	err := db.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := taskRepo.Create(ctx, task); err != nil {
+			return err
+		}
+		if err := timeEntryRepo.Create(ctx, entry); err != nil {
+			return err
+		}
+		// Reads inside the transaction see its writes
+		if _, err := taskRepo.GetByID(ctx, task.ID); err != nil {
+			return err
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Expected the callback error, got %v", err)
+	}
+
+	if _, err := taskRepo.GetByID(ctx, task.ID); !errors.Is(err, domain.ErrTaskNotFound) {
+		t.Errorf("Expected the task to be rolled back, got %v", err)
+	}
+	if _, err := timeEntryRepo.GetByID(ctx, entry.ID); !errors.Is(err, domain.ErrTimeEntryNotFound) {
+		t.Errorf("Expected the time entry to be rolled back, got %v", err)
+	}
+}
+
+func TestTotalChangesCountsWritesOnly(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	before, err := db.TotalChanges(ctx)
+	if err != nil {
+		t.Fatalf("Failed to count changes: %v", err)
+	}
+	if _, err := repo.List(ctx, domain.TaskFilter{}); err != nil {
+		t.Fatalf("Failed to list tasks: %v", err)
+	}
+	if after, _ := db.TotalChanges(ctx); after != before {
+		t.Errorf("Expected a read to leave the count at %d, got %d", before, after)
+	}
+
+	if err := repo.Create(ctx, domain.NewTask("Counted", "")); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	if after, _ := db.TotalChanges(ctx); after == before {
+		t.Error("Expected a write to move the count")
+	}
+}
+
+func TestNewDBReportsCorruptFile(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "corrupt.db")
+	if err := os.WriteFile(dbPath, []byte("this is not a sqlite database, just some text that is long enough"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	db, err := NewDB(dbPath)
+	if err == nil {
+		db.Close()
+		t.Fatal("Expected an error opening a corrupt file")
+	}
+	if !errors.Is(err, domain.ErrDatabaseCorrupt) {
+		t.Errorf("Expected ErrDatabaseCorrupt, got %v", err)
+	}
+}
+
+type codedError int
+
+func (e codedError) Error() string { return "sqlite error" }
+func (e codedError) Code() int     { return int(e) }
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want error
+	}{
+		{codedError(sqliteBusy), domain.ErrDatabaseLocked},
+		{codedError(517), domain.ErrDatabaseLocked}, // SQLITE_BUSY_SNAPSHOT
+		{codedError(sqliteNotADB), domain.ErrDatabaseCorrupt},
+		{codedError(sqliteCorrupt), domain.ErrDatabaseCorrupt},
+	}
+	for _, tt := range tests {
+		if err := classifyError(tt.err); !errors.Is(err, tt.want) || !errors.Is(err, tt.err) {
+			t.Errorf("classifyError(%d) = %v, want it to wrap %v", tt.err, err, tt.want)
+		}
+	}
+
+	plain := errors.New("no such table")
+	if err := classifyError(plain); err != plain {
+		t.Errorf("Expected errors without a code to pass through, got %v", err)
+	}
+}
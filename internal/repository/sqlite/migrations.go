@@ -7,7 +7,9 @@ import (
 )
 
 const (
-	migrationVersion = 5
+	// legacyMigrationVersion is the schema version reached by the flat
+	// migrations list; later versions live in schemaMigrations
+	legacyMigrationVersion = 5
 )
 
 var migrations = []string{
@@ -115,13 +117,158 @@ var migrations = []string{
 	`CREATE INDEX IF NOT EXISTS idx_tasks_note_id ON tasks(note_id);`,
 }
 
+// schemaMigration upgrades the schema to version. When module names a
+// SQLite module this build lacks, fallback runs in place of statements.
+type schemaMigration struct {
+	version    int
+	statements []string
+	module     string
+	fallback   []string
+}
+
+// schemaMigrations run in order after the legacy migrations, on fresh
+// installs and upgrades alike
+var schemaMigrations = []schemaMigration{
+	{
+		// Migration v6: Add task time estimates, stored in nanoseconds
+		version: 6,
+		statements: []string{
+			`ALTER TABLE tasks ADD COLUMN estimate INTEGER DEFAULT 0;`,
+		},
+	},
+	{
+		// Migration v7: Allow time entries without a task, grouped by category
+		version: 7,
+		statements: []string{
+			`CREATE TABLE time_entries_new (
+				id TEXT PRIMARY KEY,
+				task_id TEXT,
+				category TEXT DEFAULT '',
+				project_id TEXT,
+				description TEXT,
+				start_time DATETIME NOT NULL,
+				end_time DATETIME,
+				duration INTEGER,
+				created_at DATETIME NOT NULL,
+				updated_at DATETIME NOT NULL,
+				metadata TEXT,
+				FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+				FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+			);`,
+			`INSERT INTO time_entries_new (
+				id, task_id, project_id, description, start_time, end_time,
+				duration, created_at, updated_at, metadata
+			) SELECT
+				id, task_id, project_id, description, start_time, end_time,
+				duration, created_at, updated_at, metadata
+			FROM time_entries;`,
+			`DROP TABLE time_entries;`,
+			`ALTER TABLE time_entries_new RENAME TO time_entries;`,
+			`CREATE INDEX IF NOT EXISTS idx_time_entries_task_id ON time_entries(task_id);`,
+			`CREATE INDEX IF NOT EXISTS idx_time_entries_project_id ON time_entries(project_id);`,
+			`CREATE INDEX IF NOT EXISTS idx_time_entries_start_time ON time_entries(start_time);`,
+			`CREATE INDEX IF NOT EXISTS idx_time_entries_category ON time_entries(category);`,
+		},
+	},
+	{
+		// Migration v8: Allow at most one active timer. Timers left running
+		// side by side are stopped when the newest one started.
+		version: 8,
+		statements: []string{
+			`UPDATE time_entries SET
+				end_time = (SELECT MAX(start_time) FROM time_entries WHERE end_time IS NULL),
+				duration = CAST((julianday((SELECT MAX(start_time) FROM time_entries WHERE end_time IS NULL)) - julianday(start_time)) * 86400000000000 AS INTEGER)
+			WHERE end_time IS NULL
+				AND start_time < (SELECT MAX(start_time) FROM time_entries WHERE end_time IS NULL);`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entries_single_active
+				ON time_entries((end_time IS NULL)) WHERE end_time IS NULL;`,
+		},
+	},
+	{
+		// Migration v9: Add inline task comments
+		version: 9,
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS task_comments (
+				id TEXT PRIMARY KEY,
+				task_id TEXT NOT NULL,
+				body TEXT NOT NULL,
+				created_at DATETIME NOT NULL,
+				FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+			);`,
+			`CREATE INDEX IF NOT EXISTS idx_task_comments_task_id ON task_comments(task_id, created_at);`,
+		},
+	},
+	{
+		// Migration v10: Add sequential task references such as "WEB-42",
+		// numbered per prefix
+		version: 10,
+		statements: []string{
+			`ALTER TABLE tasks ADD COLUMN ref TEXT;`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_ref ON tasks(ref) WHERE ref IS NOT NULL;`,
+			`CREATE TABLE IF NOT EXISTS task_sequences (
+				prefix TEXT PRIMARY KEY,
+				last_number INTEGER NOT NULL
+			);`,
+		},
+	},
+	{
+		// Migration v11: Add a full-text index over tasks, kept in sync by
+		// triggers. Without FTS5 the index is recorded as unavailable and
+		// search falls back to LIKE.
+		version: 11,
+		module:  "fts5",
+		statements: []string{
+			schemaFeaturesTable,
+			`CREATE VIRTUAL TABLE IF NOT EXISTS tasks_fts USING fts5(
+				title, description, tags,
+				content='tasks', content_rowid='rowid'
+			);`,
+			`CREATE TRIGGER IF NOT EXISTS tasks_fts_insert AFTER INSERT ON tasks BEGIN
+				INSERT INTO tasks_fts(rowid, title, description, tags)
+				VALUES (new.rowid, new.title, new.description, new.tags);
+			END;`,
+			`CREATE TRIGGER IF NOT EXISTS tasks_fts_delete AFTER DELETE ON tasks BEGIN
+				INSERT INTO tasks_fts(tasks_fts, rowid, title, description, tags)
+				VALUES ('delete', old.rowid, old.title, old.description, old.tags);
+			END;`,
+			`CREATE TRIGGER IF NOT EXISTS tasks_fts_update AFTER UPDATE ON tasks BEGIN
+				INSERT INTO tasks_fts(tasks_fts, rowid, title, description, tags)
+				VALUES ('delete', old.rowid, old.title, old.description, old.tags);
+				INSERT INTO tasks_fts(rowid, title, description, tags)
+				VALUES (new.rowid, new.title, new.description, new.tags);
+			END;`,
+			`INSERT INTO tasks_fts(tasks_fts) VALUES ('rebuild');`,
+			`INSERT OR REPLACE INTO schema_features (name, enabled) VALUES ('fts5', 1);`,
+		},
+		fallback: []string{
+			schemaFeaturesTable,
+			`INSERT OR REPLACE INTO schema_features (name, enabled) VALUES ('fts5', 0);`,
+		},
+	},
+}
+
+// schemaFeaturesTable records optional schema features and whether the
+// migration that adds them could enable them
+const schemaFeaturesTable = `CREATE TABLE IF NOT EXISTS schema_features (
+	name TEXT PRIMARY KEY,
+	enabled INTEGER NOT NULL
+);`
+
+// latestMigrationVersion returns the schema version after all migrations
+func latestMigrationVersion() int {
+	if len(schemaMigrations) == 0 {
+		return legacyMigrationVersion
+	}
+	return schemaMigrations[len(schemaMigrations)-1].version
+}
+
 func RunMigrations(ctx context.Context, db *sql.DB) error {
 	currentVersion, err := getCurrentVersion(ctx, db)
 	if err != nil {
 		return fmt.Errorf("failed to get current schema version: %w", err)
 	}
 
-	if currentVersion >= migrationVersion {
+	if currentVersion >= latestMigrationVersion() {
 		return nil
 	}
 
@@ -131,8 +278,9 @@ func RunMigrations(ctx context.Context, db *sql.DB) error {
 	}
 	defer tx.Rollback()
 
-	// For fresh install (version 0), run base schema with all fields
-	if currentVersion == 0 {
+	switch {
+	case currentVersion == 0:
+		// For fresh install (version 0), run base schema with all fields
 		// Run base schema (migrations 0-12: 4 tables + 9 indices)
 		// Base schema already includes changelist, workspace, and note fields
 		// Skip ALTER TABLE migrations (13+) since base schema has everything
@@ -141,7 +289,7 @@ func RunMigrations(ctx context.Context, db *sql.DB) error {
 				return fmt.Errorf("failed to execute migration %d: %w", i, err)
 			}
 		}
-	} else {
+	case currentVersion < legacyMigrationVersion:
 		// For existing databases, run incremental migrations
 		startIndex := 10 + currentVersion
 		for i := startIndex; i < len(migrations); i++ {
@@ -151,7 +299,29 @@ func RunMigrations(ctx context.Context, db *sql.DB) error {
 		}
 	}
 
-	if err := setVersion(ctx, tx, migrationVersion); err != nil {
+	// Versioned migrations apply on top of the legacy schema
+	for _, migration := range schemaMigrations {
+		if migration.version <= currentVersion {
+			continue
+		}
+		statements := migration.statements
+		if migration.module != "" {
+			available, err := moduleAvailable(ctx, tx, migration.module)
+			if err != nil {
+				return fmt.Errorf("failed to check for SQLite module %s: %w", migration.module, err)
+			}
+			if !available {
+				statements = migration.fallback
+			}
+		}
+		for i, stmt := range statements {
+			if _, err := tx.ExecContext(ctx, stmt); err != nil {
+				return fmt.Errorf("failed to execute migration v%d statement %d: %w", migration.version, i, err)
+			}
+		}
+	}
+
+	if err := setVersion(ctx, tx, latestMigrationVersion()); err != nil {
 		return fmt.Errorf("failed to update schema version: %w", err)
 	}
 
@@ -173,4 +343,22 @@ func getCurrentVersion(ctx context.Context, db *sql.DB) (int, error) {
 func setVersion(ctx context.Context, tx *sql.Tx, version int) error {
 	_, err := tx.ExecContext(ctx, "INSERT OR REPLACE INTO schema_version (version) VALUES (?)", version)
 	return err
-}
\ No newline at end of file
+}
+
+// moduleAvailable reports whether SQLite was built with the named module
+func moduleAvailable(ctx context.Context, tx *sql.Tx, module string) (bool, error) {
+	var count int
+	err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM pragma_module_list WHERE name = ?", module).Scan(&count)
+	return count > 0, err
+}
+
+// featureEnabled reports whether the migration adding the named optional
+// feature enabled it
+func featureEnabled(ctx context.Context, db *sql.DB, name string) (bool, error) {
+	var enabled bool
+	err := db.QueryRowContext(ctx, "SELECT enabled FROM schema_features WHERE name = ?", name).Scan(&enabled)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return enabled, err
+}
@@ -0,0 +1,213 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+func TestMigrationsReachLatestVersion(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	version, err := getCurrentVersion(ctx, db.DB)
+	if err != nil {
+		t.Fatalf("Failed to get schema version: %v", err)
+	}
+	if version != latestMigrationVersion() {
+		t.Errorf("Expected schema version %d, got %d", latestMigrationVersion(), version)
+	}
+
+	// Running again is a no-op
+	if err := RunMigrations(ctx, db.DB); err != nil {
+		t.Fatalf("Failed to re-run migrations: %v", err)
+	}
+}
+
+func TestTaskEstimateRoundTrip(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	task := createTestTask(t, repo)
+	task.Estimate = 90 * time.Minute
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatalf("Failed to update task: %v", err)
+	}
+
+	loaded, err := repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("Failed to get task: %v", err)
+	}
+	if loaded.Estimate != 90*time.Minute {
+		t.Errorf("Expected estimate 1h30m, got %s", loaded.Estimate)
+	}
+}
+
+func TestCategoryTimeEntryRoundTrip(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTimeEntryRepository(db)
+	ctx := context.Background()
+
+	entry := domain.NewCategoryTimeEntry("meetings", "Planning")
+	if err := repo.Create(ctx, entry); err != nil {
+		t.Fatalf("Failed to create time entry without a task: %v", err)
+	}
+
+	loaded, err := repo.GetByID(ctx, entry.ID)
+	if err != nil {
+		t.Fatalf("Failed to get time entry: %v", err)
+	}
+	if loaded.TaskID != "" || loaded.Category != "meetings" {
+		t.Errorf("Expected a meetings entry without a task, got task %q category %q", loaded.TaskID, loaded.Category)
+	}
+}
+
+func TestSecondActiveTimerIsRejected(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTimeEntryRepository(db)
+	ctx := context.Background()
+
+	first := domain.NewCategoryTimeEntry("meetings", "")
+	if err := repo.Create(ctx, first); err != nil {
+		t.Fatalf("Failed to create first timer: %v", err)
+	}
+
+	second := domain.NewCategoryTimeEntry("email", "")
+	if err := repo.Create(ctx, second); !errors.Is(err, domain.ErrActiveTimeEntry) {
+		t.Fatalf("Expected ErrActiveTimeEntry for a second active timer, got %v", err)
+	}
+
+	// Once the first timer stops another can start
+	first.Stop()
+	if err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("Failed to stop first timer: %v", err)
+	}
+	if err := repo.Create(ctx, second); err != nil {
+		t.Errorf("Failed to start a timer after stopping the first: %v", err)
+	}
+}
+
+func TestMigrationV8StopsAllButTheNewestRunningTimer(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTimeEntryRepository(db)
+	ctx := context.Background()
+
+	// Recreate a database from before v8, where timers could run side by side
+	if _, err := db.ExecContext(ctx, "DROP INDEX "+singleActiveIndex); err != nil {
+		t.Fatalf("Failed to drop the index: %v", err)
+	}
+	now := time.Now().Truncate(time.Second)
+	older := domain.NewCategoryTimeEntry("meetings", "")
+	older.StartTime = now.Add(-2 * time.Hour)
+	newer := domain.NewCategoryTimeEntry("email", "")
+	newer.StartTime = now.Add(-30 * time.Minute)
+	for _, entry := range []*domain.TimeEntry{older, newer} {
+		if err := repo.Create(ctx, entry); err != nil {
+			t.Fatalf("Failed to create running timer: %v", err)
+		}
+	}
+
+	for _, migration := range schemaMigrations {
+		if migration.version != 8 {
+			continue
+		}
+		for _, stmt := range migration.statements {
+			if _, err := db.ExecContext(ctx, stmt); err != nil {
+				t.Fatalf("Failed to run migration v8: %v", err)
+			}
+		}
+	}
+
+	stopped, err := repo.GetByID(ctx, older.ID)
+	if err != nil {
+		t.Fatalf("Failed to get time entry: %v", err)
+	}
+	if stopped.EndTime == nil || !stopped.EndTime.Equal(newer.StartTime) {
+		t.Errorf("Expected the older timer to stop when the newer one started, got %v", stopped.EndTime)
+	}
+	if stopped.Duration != 90*time.Minute {
+		t.Errorf("Expected a 1h30m duration, got %s", stopped.Duration)
+	}
+
+	active, err := repo.GetActive(ctx)
+	if err != nil || active.ID != newer.ID {
+		t.Errorf("Expected the newer timer to keep running, got %v (%v)", active, err)
+	}
+
+	third := domain.NewCategoryTimeEntry("review", "")
+	if err := repo.Create(ctx, third); !errors.Is(err, domain.ErrActiveTimeEntry) {
+		t.Errorf("Expected the index to reject another running timer, got %v", err)
+	}
+}
+
+func TestOtherUniqueFailuresAreNotActiveTimerConflicts(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTimeEntryRepository(db)
+	ctx := context.Background()
+
+	entry := domain.NewCategoryTimeEntry("meetings", "")
+	entry.Stop()
+	if err := repo.Create(ctx, entry); err != nil {
+		t.Fatalf("Failed to create time entry: %v", err)
+	}
+	if err := repo.Create(ctx, entry); err == nil || errors.Is(err, domain.ErrActiveTimeEntry) {
+		t.Errorf("Expected a duplicate ID to fail without blaming a running timer, got %v", err)
+	}
+}
+
+func TestMigrationV11RecordsFullTextSearchFallback(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	ctx := context.Background()
+
+	// Simulate a SQLite build without FTS5
+	last := &schemaMigrations[len(schemaMigrations)-1]
+	module := last.module
+	last.module = "no_such_module"
+	defer func() { last.module = module }()
+
+	db, err := NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create database without full-text search: %v", err)
+	}
+	defer db.Close()
+
+	if db.fts {
+		t.Error("Expected full-text search to be recorded as unavailable")
+	}
+	var tables int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE name = 'tasks_fts'").Scan(&tables); err != nil {
+		t.Fatalf("Failed to check for the index: %v", err)
+	}
+	if tables != 0 {
+		t.Error("Expected no full-text index in the fallback")
+	}
+
+	repo := NewTaskRepository(db)
+	task := domain.NewTask("Fix login redirect", "")
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	results, err := repo.SearchTasks(ctx, "login")
+	if err != nil {
+		t.Fatalf("Failed to search tasks: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != task.ID {
+		t.Errorf("Expected substring search to find the task, got %d results", len(results))
+	}
+}
@@ -27,7 +27,7 @@ func (r *ProjectRepository) Create(ctx context.Context, project *domain.Project)
 		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.db.conn(ctx).ExecContext(ctx, query,
 		project.ID, project.Name, project.Description, string(project.Status),
 		project.Color, project.CreatedAt, project.UpdatedAt, project.ArchivedAt,
 		string(metadataJSON),
@@ -49,7 +49,7 @@ func (r *ProjectRepository) GetByID(ctx context.Context, id string) (*domain.Pro
 		FROM projects WHERE id = ?
 	`
 
-	row := r.db.QueryRowContext(ctx, query, id)
+	row := r.db.conn(ctx).QueryRowContext(ctx, query, id)
 	project, err := r.scanProject(row)
 	if err == sql.ErrNoRows {
 		return nil, domain.ErrProjectNotFound
@@ -67,7 +67,7 @@ func (r *ProjectRepository) GetByName(ctx context.Context, name string) (*domain
 		FROM projects WHERE name = ?
 	`
 
-	row := r.db.QueryRowContext(ctx, query, name)
+	row := r.db.conn(ctx).QueryRowContext(ctx, query, name)
 	project, err := r.scanProject(row)
 	if err == sql.ErrNoRows {
 		return nil, domain.ErrProjectNotFound
@@ -79,6 +79,48 @@ func (r *ProjectRepository) GetByName(ctx context.Context, name string) (*domain
 	return project, nil
 }
 
+// GetByIDs fetches the projects with the given IDs in a single query, keyed
+// by ID. Blank and unknown IDs are skipped.
+func (r *ProjectRepository) GetByIDs(ctx context.Context, ids []string) (map[string]*domain.Project, error) {
+	projects := make(map[string]*domain.Project)
+
+	seen := make(map[string]bool, len(ids))
+	placeholders := make([]string, 0, len(ids))
+	args := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		placeholders = append(placeholders, "?")
+		args = append(args, id)
+	}
+	if len(args) == 0 {
+		return projects, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, name, description, status, color, created_at, updated_at, archived_at, metadata
+		FROM projects WHERE id IN (%s)
+	`, strings.Join(placeholders, ","))
+
+	rows, err := r.db.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects by ID: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		project, err := r.scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+		projects[project.ID] = project
+	}
+
+	return projects, rows.Err()
+}
+
 func (r *ProjectRepository) List(ctx context.Context, filter domain.ProjectFilter) ([]*domain.Project, error) {
 	query := "SELECT id, name, description, status, color, created_at, updated_at, archived_at, metadata FROM projects WHERE 1=1"
 	args := []interface{}{}
@@ -110,7 +152,7 @@ func (r *ProjectRepository) List(ctx context.Context, filter domain.ProjectFilte
 		args = append(args, filter.Offset)
 	}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := r.db.conn(ctx).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list projects: %w", err)
 	}
@@ -138,7 +180,7 @@ func (r *ProjectRepository) Update(ctx context.Context, project *domain.Project)
 		WHERE id = ?
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := r.db.conn(ctx).ExecContext(ctx, query,
 		project.Name, project.Description, string(project.Status), project.Color,
 		project.UpdatedAt, project.ArchivedAt, string(metadataJSON), project.ID,
 	)
@@ -164,7 +206,7 @@ func (r *ProjectRepository) Update(ctx context.Context, project *domain.Project)
 
 func (r *ProjectRepository) Delete(ctx context.Context, id string) error {
 	query := "DELETE FROM projects WHERE id = ?"
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.conn(ctx).ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete project: %w", err)
 	}
@@ -0,0 +1,41 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+func TestGetByIDsFetchesKnownProjects(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewProjectRepository(db)
+	ctx := context.Background()
+
+	var ids []string
+	for _, name := range []string{"Website", "Mobile", "Infra"} {
+		project := domain.NewProject(name, "")
+		if err := repo.Create(ctx, project); err != nil {
+			t.Fatalf("Failed to create project: %v", err)
+		}
+		ids = append(ids, project.ID)
+	}
+
+	projects, err := repo.GetByIDs(ctx, []string{ids[0], ids[2], ids[0], "", "missing"})
+	if err != nil {
+		t.Fatalf("Failed to get projects: %v", err)
+	}
+	if len(projects) != 2 {
+		t.Fatalf("Expected 2 projects, got %d", len(projects))
+	}
+	if projects[ids[0]].Name != "Website" || projects[ids[2]].Name != "Infra" {
+		t.Errorf("Expected Website and Infra, got %v", projects)
+	}
+
+	projects, err = repo.GetByIDs(ctx, nil)
+	if err != nil || len(projects) != 0 {
+		t.Errorf("Expected no projects for no IDs, got %v, %v", projects, err)
+	}
+}
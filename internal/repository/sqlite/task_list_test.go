@@ -0,0 +1,235 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+func TestListFiltersByTagsAndCreationDate(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	create := func(title string, tags []string, created time.Time) *domain.Task {
+		task := domain.NewTask(title, "")
+		task.Tags = tags
+		task.CreatedAt = created
+		if err := repo.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		return task
+	}
+	both := create("Crash on save", []string{"bug", "urgent"}, now)
+	create("Typo in help", []string{"bug"}, now)
+	create("Old crash", []string{"bug", "urgent"}, now.AddDate(0, 0, -10))
+
+	tasks, err := repo.List(ctx, domain.TaskFilter{Tags: []string{"bug", "urgent"}})
+	if err != nil {
+		t.Fatalf("Failed to list tasks: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Errorf("Expected 2 tasks tagged bug and urgent, got %d", len(tasks))
+	}
+
+	since := now.AddDate(0, 0, -1)
+	tasks, err = repo.List(ctx, domain.TaskFilter{Tags: []string{"urgent"}, CreatedAfter: &since})
+	if err != nil {
+		t.Fatalf("Failed to list tasks: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != both.ID {
+		t.Errorf("Expected only the recent urgent task, got %d tasks", len(tasks))
+	}
+}
+
+func TestMultilineDescriptionsRoundTrip(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	task := domain.NewTask("Plan migration", "Steps:\n\n1. Back up\n2. Migrate")
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	task.Description += "\n3. Verify"
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatalf("Failed to update task: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("Failed to get task: %v", err)
+	}
+	if got.Description != "Steps:\n\n1. Back up\n2. Migrate\n3. Verify" {
+		t.Errorf("Expected the description's lines to be kept, got %q", got.Description)
+	}
+}
+
+func TestCommentsAreListedOldestFirstAndDeletedWithTask(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	task := domain.NewTask("Commented", "")
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	first := domain.NewComment(task.ID, "First")
+	first.CreatedAt = time.Now().Add(-time.Hour)
+	second := domain.NewComment(task.ID, "Second")
+	for _, comment := range []*domain.Comment{second, first} {
+		if err := repo.AddComment(ctx, comment); err != nil {
+			t.Fatalf("Failed to add comment: %v", err)
+		}
+	}
+
+	comments, err := repo.ListComments(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("Failed to list comments: %v", err)
+	}
+	if len(comments) != 2 || comments[0].Body != "First" || comments[1].Body != "Second" {
+		t.Fatalf("Expected comments oldest first, got %+v", comments)
+	}
+
+	if err := repo.Delete(ctx, task.ID); err != nil {
+		t.Fatalf("Failed to delete task: %v", err)
+	}
+	comments, err = repo.ListComments(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("Failed to list comments: %v", err)
+	}
+	if len(comments) != 0 {
+		t.Errorf("Expected comments to be deleted with their task, got %d", len(comments))
+	}
+}
+
+func TestListWithSubtaskCounts(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	create := func(title string, parent *domain.Task, tags []string) *domain.Task {
+		task := domain.NewTask(title, "")
+		task.Tags = tags
+		if parent != nil {
+			task.ParentID = &parent.ID
+		}
+		if err := repo.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		return task
+	}
+	release := create("Release", nil, []string{"ops"})
+	tag := create("Tag build", release, nil)
+	create("Write notes", release, []string{"ops"})
+	create("Push tag", tag, nil)
+	create("Standalone", nil, []string{"ops"})
+
+	tasks, err := repo.ListWithSubtaskCounts(ctx, domain.TaskFilter{})
+	if err != nil {
+		t.Fatalf("Failed to list tasks: %v", err)
+	}
+	if len(tasks) != 5 {
+		t.Fatalf("Expected 5 tasks, got %d", len(tasks))
+	}
+
+	want := map[string]int{"Release": 2, "Tag build": 1, "Write notes": 0, "Push tag": 0, "Standalone": 0}
+	for _, task := range tasks {
+		if task.SubtaskCount != want[task.Title] {
+			t.Errorf("Expected %q to have %d subtasks, got %d", task.Title, want[task.Title], task.SubtaskCount)
+		}
+	}
+
+	// Filters apply to the listed tasks, not to the subtasks counted
+	tasks, err = repo.ListWithSubtaskCounts(ctx, domain.TaskFilter{Tags: []string{"ops"}})
+	if err != nil {
+		t.Fatalf("Failed to list tasks: %v", err)
+	}
+	if len(tasks) != 3 {
+		t.Fatalf("Expected 3 tasks tagged ops, got %d", len(tasks))
+	}
+	for _, task := range tasks {
+		if task.Title == "Release" && task.SubtaskCount != 2 {
+			t.Errorf("Expected Release to count both subtasks, got %d", task.SubtaskCount)
+		}
+	}
+}
+
+func TestTaskRefsAreNumberedAndFound(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	for _, want := range []int{1, 2} {
+		number, err := repo.NextTaskNumber(ctx, "WEB")
+		if err != nil || number != want {
+			t.Fatalf("Expected number %d, got %d, %v", want, number, err)
+		}
+	}
+	if number, _ := repo.NextTaskNumber(ctx, "API"); number != 1 {
+		t.Errorf("Expected API to be numbered separately, got %d", number)
+	}
+
+	task := domain.NewTask("Numbered", "")
+	task.Ref = "WEB-2"
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	// Tasks without a ref don't collide on the unique index
+	for i := 0; i < 2; i++ {
+		if err := repo.Create(ctx, domain.NewTask("Plain", "")); err != nil {
+			t.Fatalf("Failed to create task without a ref: %v", err)
+		}
+	}
+
+	found, err := repo.GetByRef(ctx, "WEB-2")
+	if err != nil || found.ID != task.ID || found.Ref != "WEB-2" {
+		t.Errorf("Expected to find the task by ref, got %v, %v", found, err)
+	}
+	if _, err := repo.GetByRef(ctx, "WEB-3"); err != domain.ErrTaskNotFound {
+		t.Errorf("Expected ErrTaskNotFound, got %v", err)
+	}
+}
+
+func TestListSortsByUpdatedAt(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	older := domain.NewTask("Created first, touched last", "")
+	older.CreatedAt = now.Add(-2 * time.Hour)
+	older.UpdatedAt = now
+	newer := domain.NewTask("Created last, untouched", "")
+	newer.CreatedAt = now.Add(-time.Hour)
+	newer.UpdatedAt = now.Add(-time.Hour)
+	for _, task := range []*domain.Task{older, newer} {
+		if err := repo.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	tasks, err := repo.List(ctx, domain.TaskFilter{SortBy: domain.SortByUpdated, Limit: 1})
+	if err != nil {
+		t.Fatalf("Failed to list tasks: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != older.ID {
+		t.Errorf("Expected the most recently updated task first, got %d tasks", len(tasks))
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/adriannajera/project-manager-cli/internal/domain"
 )
@@ -26,8 +27,8 @@ func (r *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
 		INSERT INTO tasks (
 			id, title, description, status, priority, project_id, parent_id,
 			tags, changelist, workspace, due_date, created_at, updated_at, completed_at, metadata,
-			note_id, note_path, has_note, note_created_at, note_updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			note_id, note_path, has_note, note_created_at, note_updated_at, estimate, ref
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	// Convert empty project_id to NULL to avoid foreign key constraint violations
@@ -38,12 +39,13 @@ func (r *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
 		projectID = nil
 	}
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.db.conn(ctx).ExecContext(ctx, query,
 		task.ID, task.Title, task.Description, string(task.Status),
 		int(task.Priority), projectID, task.ParentID, string(tagsJSON),
 		task.Changelist, task.Workspace, task.DueDate, task.CreatedAt, task.UpdatedAt, task.CompletedAt,
 		string(metadataJSON),
 		task.NoteID, task.NotePath, task.HasNote, task.NoteCreatedAt, task.NoteUpdatedAt,
+		int64(task.Estimate), nullableString(task.Ref),
 	)
 
 	if err != nil {
@@ -57,11 +59,11 @@ func (r *TaskRepository) GetByID(ctx context.Context, id string) (*domain.Task,
 	query := `
 		SELECT id, title, description, status, priority, project_id, parent_id,
 		       tags, changelist, workspace, due_date, created_at, updated_at, completed_at, metadata,
-		       note_id, note_path, has_note, note_created_at, note_updated_at
+		       note_id, note_path, has_note, note_created_at, note_updated_at, estimate, ref
 		FROM tasks WHERE id = ?
 	`
 
-	row := r.db.QueryRowContext(ctx, query, id)
+	row := r.db.conn(ctx).QueryRowContext(ctx, query, id)
 	task, err := r.scanTask(row)
 	if err == sql.ErrNoRows {
 		return nil, domain.ErrTaskNotFound
@@ -73,8 +75,111 @@ func (r *TaskRepository) GetByID(ctx context.Context, id string) (*domain.Task,
 	return task, nil
 }
 
+// GetByRef returns the task with the sequential reference ref
+func (r *TaskRepository) GetByRef(ctx context.Context, ref string) (*domain.Task, error) {
+	query := "SELECT " + taskColumns + " FROM tasks WHERE ref = ?"
+
+	row := r.db.conn(ctx).QueryRowContext(ctx, query, ref)
+	task, err := r.scanTask(row)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task by ref: %w", err)
+	}
+
+	return task, nil
+}
+
+// NextTaskNumber increments the counter for prefix in task_sequences and
+// returns the new value, so numbers are never reused even after deletes
+func (r *TaskRepository) NextTaskNumber(ctx context.Context, prefix string) (int, error) {
+	query := `
+		INSERT INTO task_sequences (prefix, last_number) VALUES (?, 1)
+		ON CONFLICT(prefix) DO UPDATE SET last_number = last_number + 1
+		RETURNING last_number
+	`
+
+	var number int
+	if err := r.db.conn(ctx).QueryRowContext(ctx, query, prefix).Scan(&number); err != nil {
+		return 0, fmt.Errorf("failed to get next task number: %w", err)
+	}
+
+	return number, nil
+}
+
 func (r *TaskRepository) List(ctx context.Context, filter domain.TaskFilter) ([]*domain.Task, error) {
-	query := "SELECT id, title, description, status, priority, project_id, parent_id, tags, changelist, workspace, due_date, created_at, updated_at, completed_at, metadata, note_id, note_path, has_note, note_created_at, note_updated_at FROM tasks WHERE 1=1"
+	var tasks []*domain.Task
+	err := r.ListEach(ctx, filter, func(task *domain.Task) error {
+		tasks = append(tasks, task)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// ListEach streams the tasks matching filter to fn as rows are scanned,
+// stopping at the first error fn returns
+func (r *TaskRepository) ListEach(ctx context.Context, filter domain.TaskFilter, fn func(*domain.Task) error) error {
+	where, args := taskFilterClause(filter)
+	query := "SELECT " + taskColumns + " FROM tasks" + where
+
+	rows, err := r.db.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		task, err := r.scanTask(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan task: %w", err)
+		}
+		if err := fn(task); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// ListWithSubtaskCounts lists the tasks matching filter along with their
+// number of direct subtasks, joining on a grouped count rather than asking
+// for each task's subtasks
+func (r *TaskRepository) ListWithSubtaskCounts(ctx context.Context, filter domain.TaskFilter) ([]*domain.TaskWithSubtaskCount, error) {
+	where, args := taskFilterClause(filter)
+	query := "SELECT " + taskColumns + ", COALESCE(subtasks.subtask_count, 0) FROM tasks" +
+		" LEFT JOIN (SELECT parent_id AS counted_id, COUNT(*) AS subtask_count FROM tasks WHERE parent_id IS NOT NULL GROUP BY parent_id) subtasks" +
+		" ON subtasks.counted_id = tasks.id" + where
+
+	rows, err := r.db.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []*domain.TaskWithSubtaskCount
+	for rows.Next() {
+		var count int
+		task, err := r.scanTask(countScanner{row: rows, count: &count})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		tasks = append(tasks, &domain.TaskWithSubtaskCount{Task: task, SubtaskCount: count})
+	}
+
+	return tasks, rows.Err()
+}
+
+// taskColumns are the columns scanTask reads, in order
+const taskColumns = "id, title, description, status, priority, project_id, parent_id, tags, changelist, workspace, due_date, created_at, updated_at, completed_at, metadata, note_id, note_path, has_note, note_created_at, note_updated_at, estimate, ref"
+
+// taskFilterClause builds the WHERE, ORDER BY, and LIMIT part of a task
+// listing query for filter
+func taskFilterClause(filter domain.TaskFilter) (string, []interface{}) {
+	query := " WHERE 1=1"
 	args := []interface{}{}
 
 	if len(filter.Status) > 0 {
@@ -105,6 +210,11 @@ func (r *TaskRepository) List(ctx context.Context, filter domain.TaskFilter) ([]
 		args = append(args, filter.Workspace)
 	}
 
+	if filter.ExcludeArchivedProjects {
+		query += " AND (project_id IS NULL OR project_id NOT IN (SELECT id FROM projects WHERE status = ?))"
+		args = append(args, string(domain.ProjectStatusArchived))
+	}
+
 	if filter.DueBefore != nil {
 		query += " AND due_date <= ?"
 		args = append(args, filter.DueBefore)
@@ -115,13 +225,33 @@ func (r *TaskRepository) List(ctx context.Context, filter domain.TaskFilter) ([]
 		args = append(args, filter.DueAfter)
 	}
 
+	if filter.CreatedAfter != nil {
+		query += " AND created_at >= ?"
+		args = append(args, filter.CreatedAfter)
+	}
+
+	if filter.CreatedBefore != nil {
+		query += " AND created_at <= ?"
+		args = append(args, filter.CreatedBefore)
+	}
+
+	// A task must carry every requested tag
+	for _, tag := range filter.Tags {
+		query += " AND EXISTS (SELECT 1 FROM json_each(tasks.tags) WHERE json_each.value = ?)"
+		args = append(args, tag)
+	}
+
 	if filter.Search != "" {
 		query += " AND (title LIKE ? OR description LIKE ?)"
 		searchTerm := "%" + filter.Search + "%"
 		args = append(args, searchTerm, searchTerm)
 	}
 
-	query += " ORDER BY created_at DESC"
+	if filter.SortBy == domain.SortByUpdated {
+		query += " ORDER BY updated_at DESC"
+	} else {
+		query += " ORDER BY created_at DESC"
+	}
 
 	if filter.Limit > 0 {
 		query += " LIMIT ?"
@@ -133,22 +263,7 @@ func (r *TaskRepository) List(ctx context.Context, filter domain.TaskFilter) ([]
 		args = append(args, filter.Offset)
 	}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list tasks: %w", err)
-	}
-	defer rows.Close()
-
-	var tasks []*domain.Task
-	for rows.Next() {
-		task, err := r.scanTask(rows)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan task: %w", err)
-		}
-		tasks = append(tasks, task)
-	}
-
-	return tasks, nil
+	return query, args
 }
 
 func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
@@ -160,7 +275,8 @@ func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
 			title = ?, description = ?, status = ?, priority = ?,
 			project_id = ?, parent_id = ?, tags = ?, changelist = ?, workspace = ?, due_date = ?,
 			updated_at = ?, completed_at = ?, metadata = ?,
-			note_id = ?, note_path = ?, has_note = ?, note_created_at = ?, note_updated_at = ?
+			note_id = ?, note_path = ?, has_note = ?, note_created_at = ?, note_updated_at = ?,
+			estimate = ?, ref = ?
 		WHERE id = ?
 	`
 
@@ -172,11 +288,12 @@ func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
 		projectID = nil
 	}
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := r.db.conn(ctx).ExecContext(ctx, query,
 		task.Title, task.Description, string(task.Status), int(task.Priority),
 		projectID, task.ParentID, string(tagsJSON), task.Changelist, task.Workspace, task.DueDate,
 		task.UpdatedAt, task.CompletedAt, string(metadataJSON),
-		task.NoteID, task.NotePath, task.HasNote, task.NoteCreatedAt, task.NoteUpdatedAt, task.ID,
+		task.NoteID, task.NotePath, task.HasNote, task.NoteCreatedAt, task.NoteUpdatedAt,
+		int64(task.Estimate), nullableString(task.Ref), task.ID,
 	)
 
 	if err != nil {
@@ -195,9 +312,22 @@ func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
 	return nil
 }
 
+// UpdateTasks updates several tasks in a single transaction, so either all
+// of the changes are saved or none are
+func (r *TaskRepository) UpdateTasks(ctx context.Context, tasks []*domain.Task) error {
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, task := range tasks {
+			if err := r.Update(ctx, task); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (r *TaskRepository) Delete(ctx context.Context, id string) error {
 	query := "DELETE FROM tasks WHERE id = ?"
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.conn(ctx).ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete task: %w", err)
 	}
@@ -223,12 +353,12 @@ func (r *TaskRepository) GetSubtasks(ctx context.Context, parentID string) ([]*d
 	query := `
 		SELECT id, title, description, status, priority, project_id, parent_id,
 		       tags, changelist, workspace, due_date, created_at, updated_at, completed_at, metadata,
-		       note_id, note_path, has_note, note_created_at, note_updated_at
+		       note_id, note_path, has_note, note_created_at, note_updated_at, estimate, ref
 		FROM tasks WHERE parent_id = ?
 		ORDER BY created_at ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, parentID)
+	rows, err := r.db.conn(ctx).QueryContext(ctx, query, parentID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get subtasks: %w", err)
 	}
@@ -246,10 +376,103 @@ func (r *TaskRepository) GetSubtasks(ctx context.Context, parentID string) ([]*d
 	return tasks, nil
 }
 
+func (r *TaskRepository) AddComment(ctx context.Context, comment *domain.Comment) error {
+	query := `INSERT INTO task_comments (id, task_id, body, created_at) VALUES (?, ?, ?, ?)`
+
+	_, err := r.db.conn(ctx).ExecContext(ctx, query, comment.ID, comment.TaskID, comment.Body, comment.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TaskRepository) ListComments(ctx context.Context, taskID string) ([]*domain.Comment, error) {
+	query := `
+		SELECT id, task_id, body, created_at
+		FROM task_comments WHERE task_id = ?
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.conn(ctx).QueryContext(ctx, query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	defer rows.Close()
+
+	var comments []*domain.Comment
+	for rows.Next() {
+		var comment domain.Comment
+		if err := rows.Scan(&comment.ID, &comment.TaskID, &comment.Body, &comment.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comments = append(comments, &comment)
+	}
+
+	return comments, rows.Err()
+}
+
+// SearchTasks performs a ranked full-text search over task titles,
+// descriptions, and tags. It falls back to LIKE matching when the FTS5
+// index is unavailable.
+func (r *TaskRepository) SearchTasks(ctx context.Context, query string) ([]*domain.Task, error) {
+	match := ftsQuery(query)
+	if !r.db.fts || match == "" {
+		return r.List(ctx, domain.TaskFilter{Search: query})
+	}
+
+	sqlQuery := `
+		SELECT t.id, t.title, t.description, t.status, t.priority, t.project_id, t.parent_id,
+		       t.tags, t.changelist, t.workspace, t.due_date, t.created_at, t.updated_at, t.completed_at, t.metadata,
+		       t.note_id, t.note_path, t.has_note, t.note_created_at, t.note_updated_at, t.estimate, t.ref
+		FROM tasks_fts
+		JOIN tasks t ON t.rowid = tasks_fts.rowid
+		WHERE tasks_fts MATCH ?
+		ORDER BY rank
+	`
+
+	rows, err := r.db.conn(ctx).QueryContext(ctx, sqlQuery, match)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []*domain.Task
+	for rows.Next() {
+		task, err := r.scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, rows.Err()
+}
+
+// ftsQuery turns free-form input into an FTS5 expression matching every term
+// as a prefix. Terms are quoted so punctuation isn't parsed as query syntax.
+func ftsQuery(input string) string {
+	terms := strings.Fields(input)
+	for i, term := range terms {
+		terms[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"*`
+	}
+	return strings.Join(terms, " ")
+}
+
 type RowScanner interface {
 	Scan(dest ...interface{}) error
 }
 
+// countScanner scans a row of task columns followed by a count
+type countScanner struct {
+	row   RowScanner
+	count *int
+}
+
+func (s countScanner) Scan(dest ...interface{}) error {
+	return s.row.Scan(append(dest, s.count)...)
+}
+
 func (r *TaskRepository) scanTask(row RowScanner) (*domain.Task, error) {
 	var task domain.Task
 	var tagsJSON, metadataJSON string
@@ -258,6 +481,8 @@ func (r *TaskRepository) scanTask(row RowScanner) (*domain.Task, error) {
 	var noteID, notePath sql.NullString
 	var hasNote sql.NullBool
 	var noteCreatedAt, noteUpdatedAt sql.NullTime
+	var estimate sql.NullInt64
+	var ref sql.NullString
 
 	err := row.Scan(
 		&task.ID, &task.Title, &task.Description, &task.Status,
@@ -265,6 +490,7 @@ func (r *TaskRepository) scanTask(row RowScanner) (*domain.Task, error) {
 		&dueDate, &task.CreatedAt, &task.UpdatedAt, &completedAt,
 		&metadataJSON,
 		&noteID, &notePath, &hasNote, &noteCreatedAt, &noteUpdatedAt,
+		&estimate, &ref,
 	)
 
 	if err != nil {
@@ -324,5 +550,13 @@ func (r *TaskRepository) scanTask(row RowScanner) (*domain.Task, error) {
 		task.NoteUpdatedAt = &noteUpdatedAt.Time
 	}
 
+	if estimate.Valid {
+		task.Estimate = time.Duration(estimate.Int64)
+	}
+
+	if ref.Valid {
+		task.Ref = ref.String
+	}
+
 	return &task, nil
 }
\ No newline at end of file
@@ -0,0 +1,131 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+func TestSearchTasks(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	login := domain.NewTask("Fix login redirect", "Users land on a blank page after signing in")
+	login.Tags = []string{"auth"}
+	if err := repo.Create(ctx, login); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	docs := domain.NewTask("Write release notes", "Summarize the auth changes")
+	if err := repo.Create(ctx, docs); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	results, err := repo.SearchTasks(ctx, "login")
+	if err != nil {
+		t.Fatalf("Failed to search tasks: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != login.ID {
+		t.Fatalf("Expected only the login task, got %d results", len(results))
+	}
+
+	// Description matches count too
+	results, err = repo.SearchTasks(ctx, "auth")
+	if err != nil {
+		t.Fatalf("Failed to search tasks: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("Expected 2 results for 'auth', got %d", len(results))
+	}
+
+	// The index follows updates
+	login.Title = "Fix session redirect"
+	login.Description = ""
+	login.Tags = []string{}
+	if err := repo.Update(ctx, login); err != nil {
+		t.Fatalf("Failed to update task: %v", err)
+	}
+	results, err = repo.SearchTasks(ctx, "login")
+	if err != nil {
+		t.Fatalf("Failed to search tasks: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Expected no results after retitling, got %d", len(results))
+	}
+
+	// And deletes
+	if err := repo.Delete(ctx, docs.ID); err != nil {
+		t.Fatalf("Failed to delete task: %v", err)
+	}
+	results, err = repo.SearchTasks(ctx, "release")
+	if err != nil {
+		t.Fatalf("Failed to search tasks: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Expected no results after delete, got %d", len(results))
+	}
+}
+
+func TestFTSQuery(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", ""},
+		{"login", `"login"*`},
+		{"login  bug", `"login"* "bug"*`},
+		{`say "hi"`, `"say"* """hi"""*`},
+	}
+
+	for _, test := range tests {
+		if result := ftsQuery(test.input); result != test.expected {
+			t.Errorf("ftsQuery(%q) = %q, expected %q", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestListExcludesArchivedProjectTasks(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	projectRepo := NewProjectRepository(db)
+	taskRepo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	archived := domain.NewProject("Old launch", "")
+	archived.Archive()
+	if err := projectRepo.Create(ctx, archived); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	stale := domain.NewTask("Send launch email", "")
+	stale.ProjectID = archived.ID
+	if err := taskRepo.Create(ctx, stale); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	loose := domain.NewTask("Renew domain", "")
+	if err := taskRepo.Create(ctx, loose); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	tasks, err := taskRepo.List(ctx, domain.TaskFilter{ExcludeArchivedProjects: true})
+	if err != nil {
+		t.Fatalf("Failed to list tasks: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != loose.ID {
+		t.Errorf("Expected only the task without a project, got %d tasks", len(tasks))
+	}
+
+	tasks, err = taskRepo.List(ctx, domain.TaskFilter{})
+	if err != nil {
+		t.Fatalf("Failed to list tasks: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Errorf("Expected both tasks without the filter, got %d", len(tasks))
+	}
+}
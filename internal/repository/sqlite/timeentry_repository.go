@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/adriannajera/project-manager-cli/internal/domain"
@@ -23,9 +24,9 @@ func (r *TimeEntryRepository) Create(ctx context.Context, entry *domain.TimeEntr
 
 	query := `
 		INSERT INTO time_entries (
-			id, task_id, project_id, description, start_time, end_time,
+			id, task_id, category, project_id, description, start_time, end_time,
 			duration, created_at, updated_at, metadata
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	var durationNanos *int64
@@ -42,13 +43,16 @@ func (r *TimeEntryRepository) Create(ctx context.Context, entry *domain.TimeEntr
 		projectID = nil
 	}
 
-	_, err := r.db.ExecContext(ctx, query,
-		entry.ID, entry.TaskID, projectID, entry.Description,
+	_, err := r.db.conn(ctx).ExecContext(ctx, query,
+		entry.ID, nullableString(entry.TaskID), entry.Category, projectID, entry.Description,
 		entry.StartTime, entry.EndTime, durationNanos, entry.CreatedAt,
 		entry.UpdatedAt, string(metadataJSON),
 	)
 
 	if err != nil {
+		if isActiveTimerConflict(err) {
+			return domain.ErrActiveTimeEntry
+		}
 		return fmt.Errorf("failed to create time entry: %w", err)
 	}
 
@@ -57,12 +61,12 @@ func (r *TimeEntryRepository) Create(ctx context.Context, entry *domain.TimeEntr
 
 func (r *TimeEntryRepository) GetByID(ctx context.Context, id string) (*domain.TimeEntry, error) {
 	query := `
-		SELECT id, task_id, project_id, description, start_time, end_time,
+		SELECT id, task_id, category, project_id, description, start_time, end_time,
 		       duration, created_at, updated_at, metadata
 		FROM time_entries WHERE id = ?
 	`
 
-	row := r.db.QueryRowContext(ctx, query, id)
+	row := r.db.conn(ctx).QueryRowContext(ctx, query, id)
 	entry, err := r.scanTimeEntry(row)
 	if err == sql.ErrNoRows {
 		return nil, domain.ErrTimeEntryNotFound
@@ -75,7 +79,71 @@ func (r *TimeEntryRepository) GetByID(ctx context.Context, id string) (*domain.T
 }
 
 func (r *TimeEntryRepository) List(ctx context.Context, filter domain.TimeEntryFilter) ([]*domain.TimeEntry, error) {
-	query := "SELECT id, task_id, project_id, description, start_time, end_time, duration, created_at, updated_at, metadata FROM time_entries WHERE 1=1"
+	where, args := timeEntryFilterClause(filter)
+	query := "SELECT id, task_id, category, project_id, description, start_time, end_time, duration, created_at, updated_at, metadata FROM time_entries" + where
+
+	query += " ORDER BY start_time DESC"
+
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	if filter.Offset > 0 {
+		query += " OFFSET ?"
+		args = append(args, filter.Offset)
+	}
+
+	rows, err := r.db.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list time entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*domain.TimeEntry
+	for rows.Next() {
+		entry, err := r.scanTimeEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan time entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// SumDurations totals the completed entries matching filter in SQL, grouped
+// by task, category, and project. Limit and Offset are ignored.
+func (r *TimeEntryRepository) SumDurations(ctx context.Context, filter domain.TimeEntryFilter) ([]*domain.TimeTotal, error) {
+	where, args := timeEntryFilterClause(filter)
+	query := "SELECT COALESCE(task_id, ''), COALESCE(category, ''), COALESCE(project_id, ''), SUM(COALESCE(duration, 0)), COUNT(*)" +
+		" FROM time_entries" + where + " AND end_time IS NOT NULL" +
+		" GROUP BY task_id, category, project_id"
+
+	rows, err := r.db.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum time entries: %w", err)
+	}
+	defer rows.Close()
+
+	var totals []*domain.TimeTotal
+	for rows.Next() {
+		var total domain.TimeTotal
+		var nanos int64
+		if err := rows.Scan(&total.TaskID, &total.Category, &total.ProjectID, &nanos, &total.Entries); err != nil {
+			return nil, fmt.Errorf("failed to scan time total: %w", err)
+		}
+		total.Duration = time.Duration(nanos)
+		totals = append(totals, &total)
+	}
+
+	return totals, rows.Err()
+}
+
+// timeEntryFilterClause builds the WHERE part of a time entry query for
+// filter
+func timeEntryFilterClause(filter domain.TimeEntryFilter) (string, []interface{}) {
+	query := " WHERE 1=1"
 	args := []interface{}{}
 
 	if filter.TaskID != "" {
@@ -106,34 +174,7 @@ func (r *TimeEntryRepository) List(ctx context.Context, filter domain.TimeEntryF
 		}
 	}
 
-	query += " ORDER BY start_time DESC"
-
-	if filter.Limit > 0 {
-		query += " LIMIT ?"
-		args = append(args, filter.Limit)
-	}
-
-	if filter.Offset > 0 {
-		query += " OFFSET ?"
-		args = append(args, filter.Offset)
-	}
-
-	rows, err := r.db.QueryContext(ctx, query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list time entries: %w", err)
-	}
-	defer rows.Close()
-
-	var entries []*domain.TimeEntry
-	for rows.Next() {
-		entry, err := r.scanTimeEntry(rows)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan time entry: %w", err)
-		}
-		entries = append(entries, entry)
-	}
-
-	return entries, nil
+	return query, args
 }
 
 func (r *TimeEntryRepository) Update(ctx context.Context, entry *domain.TimeEntry) error {
@@ -141,7 +182,7 @@ func (r *TimeEntryRepository) Update(ctx context.Context, entry *domain.TimeEntr
 
 	query := `
 		UPDATE time_entries SET
-			task_id = ?, project_id = ?, description = ?, start_time = ?,
+			task_id = ?, category = ?, project_id = ?, description = ?, start_time = ?,
 			end_time = ?, duration = ?, updated_at = ?, metadata = ?
 		WHERE id = ?
 	`
@@ -160,13 +201,16 @@ func (r *TimeEntryRepository) Update(ctx context.Context, entry *domain.TimeEntr
 		projectID = nil
 	}
 
-	result, err := r.db.ExecContext(ctx, query,
-		entry.TaskID, projectID, entry.Description, entry.StartTime,
+	result, err := r.db.conn(ctx).ExecContext(ctx, query,
+		nullableString(entry.TaskID), entry.Category, projectID, entry.Description, entry.StartTime,
 		entry.EndTime, durationNanos, entry.UpdatedAt, string(metadataJSON),
 		entry.ID,
 	)
 
 	if err != nil {
+		if isActiveTimerConflict(err) {
+			return domain.ErrActiveTimeEntry
+		}
 		return fmt.Errorf("failed to update time entry: %w", err)
 	}
 
@@ -184,7 +228,7 @@ func (r *TimeEntryRepository) Update(ctx context.Context, entry *domain.TimeEntr
 
 func (r *TimeEntryRepository) Delete(ctx context.Context, id string) error {
 	query := "DELETE FROM time_entries WHERE id = ?"
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.conn(ctx).ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete time entry: %w", err)
 	}
@@ -203,13 +247,13 @@ func (r *TimeEntryRepository) Delete(ctx context.Context, id string) error {
 
 func (r *TimeEntryRepository) GetActive(ctx context.Context) (*domain.TimeEntry, error) {
 	query := `
-		SELECT id, task_id, project_id, description, start_time, end_time,
+		SELECT id, task_id, category, project_id, description, start_time, end_time,
 		       duration, created_at, updated_at, metadata
 		FROM time_entries WHERE end_time IS NULL
 		ORDER BY start_time DESC LIMIT 1
 	`
 
-	row := r.db.QueryRowContext(ctx, query)
+	row := r.db.conn(ctx).QueryRowContext(ctx, query)
 	entry, err := r.scanTimeEntry(row)
 	if err == sql.ErrNoRows {
 		return nil, domain.ErrNoActiveTimeEntry
@@ -234,12 +278,12 @@ func (r *TimeEntryRepository) GetByProject(ctx context.Context, projectID string
 func (r *TimeEntryRepository) scanTimeEntry(row RowScanner) (*domain.TimeEntry, error) {
 	var entry domain.TimeEntry
 	var metadataJSON string
-	var projectID sql.NullString
+	var taskID, category, projectID sql.NullString
 	var endTime sql.NullTime
 	var durationNanos sql.NullInt64
 
 	err := row.Scan(
-		&entry.ID, &entry.TaskID, &projectID, &entry.Description,
+		&entry.ID, &taskID, &category, &projectID, &entry.Description,
 		&entry.StartTime, &endTime, &durationNanos, &entry.CreatedAt,
 		&entry.UpdatedAt, &metadataJSON,
 	)
@@ -248,6 +292,9 @@ func (r *TimeEntryRepository) scanTimeEntry(row RowScanner) (*domain.TimeEntry,
 		return nil, err
 	}
 
+	entry.TaskID = taskID.String
+	entry.Category = category.String
+
 	if projectID.Valid {
 		entry.ProjectID = projectID.String
 	}
@@ -265,4 +312,24 @@ func (r *TimeEntryRepository) scanTimeEntry(row RowScanner) (*domain.TimeEntry,
 	}
 
 	return &entry, nil
-}
\ No newline at end of file
+}
+
+// nullableString stores empty strings as NULL so optional foreign keys stay
+// valid and unique indexes, such as the one on task refs, skip them
+func nullableString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// singleActiveIndex is the index, created by migration v8, that allows only
+// one active timer
+const singleActiveIndex = "idx_time_entries_single_active"
+
+// isActiveTimerConflict reports whether err comes from the index that allows
+// only one active timer
+func isActiveTimerConflict(err error) bool {
+	return strings.Contains(err.Error(), "UNIQUE constraint failed") &&
+		strings.Contains(err.Error(), singleActiveIndex)
+}
@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+func TestSumDurationsGroupsCompletedEntries(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	tasks := NewTaskRepository(db)
+	repo := NewTimeEntryRepository(db)
+	ctx := context.Background()
+
+	task := domain.NewTask("Deploy", "")
+	if err := tasks.Create(ctx, task); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	track := func(entry *domain.TimeEntry, offset, length time.Duration) {
+		entry.StartTime = start.Add(offset)
+		entry.StopAt(entry.StartTime.Add(length))
+		if err := repo.Create(ctx, entry); err != nil {
+			t.Fatalf("Failed to create time entry: %v", err)
+		}
+	}
+	track(domain.NewTimeEntry(task.ID, "", ""), 0, time.Hour)
+	track(domain.NewTimeEntry(task.ID, "", ""), 2*time.Hour, 30*time.Minute)
+	track(domain.NewCategoryTimeEntry("meetings", ""), 3*time.Hour, 15*time.Minute)
+	// Outside the range
+	track(domain.NewCategoryTimeEntry("meetings", ""), -48*time.Hour, time.Hour)
+	// Running entries have no stored duration and are left out
+	if err := repo.Create(ctx, domain.NewCategoryTimeEntry("email", "")); err != nil {
+		t.Fatalf("Failed to create active entry: %v", err)
+	}
+
+	from := start.Add(-time.Hour)
+	totals, err := repo.SumDurations(ctx, domain.TimeEntryFilter{StartAfter: &from})
+	if err != nil {
+		t.Fatalf("Failed to sum durations: %v", err)
+	}
+
+	got := make(map[string]*domain.TimeTotal)
+	for _, total := range totals {
+		got[total.TaskID+"/"+total.Category] = total
+	}
+	if len(got) != 2 {
+		t.Fatalf("Expected a task total and a meetings total, got %d totals", len(totals))
+	}
+	if total := got[task.ID+"/"]; total == nil || total.Duration != 90*time.Minute || total.Entries != 2 {
+		t.Errorf("Expected 90m over 2 entries for the task, got %+v", total)
+	}
+	if total := got["/meetings"]; total == nil || total.Duration != 15*time.Minute {
+		t.Errorf("Expected 15m of meetings, got %+v", total)
+	}
+}
@@ -0,0 +1,606 @@
+// Package server exposes the task, project, and time services as an HTTP
+// JSON API for editor plugins and dashboards
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+	"github.com/adriannajera/project-manager-cli/internal/service/project"
+	"github.com/adriannajera/project-manager-cli/internal/service/task"
+	timeService "github.com/adriannajera/project-manager-cli/internal/service/time"
+)
+
+// shutdownTimeout is how long requests in flight get to finish once the
+// server is asked to stop
+const shutdownTimeout = 5 * time.Second
+
+// maxBodyBytes caps request bodies, which are small JSON objects
+const maxBodyBytes = 1 << 20
+
+// Options configures a Server
+type Options struct {
+	// ReadOnly refuses every request that would change data
+	ReadOnly bool
+	// RequestTimeout bounds each request; zero means no limit
+	RequestTimeout time.Duration
+	// OnChange, when set, is called after each request that changed data
+	OnChange func()
+}
+
+// Server serves the API from the same services the CLI uses
+type Server struct {
+	tasks    *task.Service
+	projects *project.Service
+	timer    *timeService.Service
+	options  Options
+
+	// addr is the address ListenAndServe listens on, which requests must
+	// name as their Host
+	addr string
+}
+
+// New creates a server over the given services
+func New(tasks *task.Service, projects *project.Service, timer *timeService.Service, options Options) *Server {
+	return &Server{
+		tasks:    tasks,
+		projects: projects,
+		timer:    timer,
+		options:  options,
+	}
+}
+
+// ListenAndServe serves the API on addr until ctx is canceled, then lets
+// requests in flight finish before returning
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	s.addr = addr
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return fmt.Errorf("failed to serve: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down: %w", err)
+	}
+	return nil
+}
+
+// Handler returns the API's routes:
+//
+//	GET  /api/tasks                  list tasks (?status=, ?project=, ?tag=, ?search=, ?limit=)
+//	POST /api/tasks                  create a task
+//	GET  /api/tasks/{id}             get a task by ID, prefix, or reference
+//	POST /api/tasks/{id}/start       mark a task doing
+//	POST /api/tasks/{id}/complete    mark a task done
+//	GET  /api/projects               list projects (?status=)
+//	POST /api/projects               create a project
+//	GET  /api/projects/{id}          get a project
+//	GET  /api/time-entries           list time entries (?task=, ?project=, ?limit=)
+//	GET  /api/timer                  the running timer, or null
+//	POST /api/timer/start            start a timer on a task or category
+//	POST /api/timer/stop             stop the running timer
+//
+// Since the API has no authentication, it refuses requests that a web page
+// could make on the user's behalf: ones from another origin, ones naming a
+// host other than this server (DNS rebinding), and changes that are not sent
+// as JSON, which browsers cannot send across origins without asking first.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/tasks", s.handleTasks)
+	mux.HandleFunc("/api/tasks/", s.handleTask)
+	mux.HandleFunc("/api/projects", s.handleProjects)
+	mux.HandleFunc("/api/projects/", s.handleProject)
+	mux.HandleFunc("/api/time-entries", s.handleTimeEntries)
+	mux.HandleFunc("/api/timer", s.handleTimer)
+	mux.HandleFunc("/api/timer/", s.handleTimer)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.hostAllowed(r.Host) {
+			writeJSON(w, http.StatusForbidden, errorResponse{Error: "unknown host: " + r.Host, Kind: "forbidden"})
+			return
+		}
+		if origin := r.Header.Get("Origin"); origin != "" && origin != "http://"+r.Host {
+			writeJSON(w, http.StatusForbidden, errorResponse{Error: "cross-origin requests are not allowed", Kind: "forbidden"})
+			return
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead && !isJSON(r) {
+			writeJSON(w, http.StatusUnsupportedMediaType, errorResponse{Error: "requests that change data must have Content-Type: application/json", Kind: "validation"})
+			return
+		}
+		if s.options.ReadOnly && r.Method != http.MethodGet && r.Method != http.MethodHead {
+			writeJSON(w, http.StatusForbidden, errorResponse{Error: "server is read-only", Kind: "read_only"})
+			return
+		}
+		if s.options.RequestTimeout > 0 {
+			ctx, cancel := context.WithTimeout(r.Context(), s.options.RequestTimeout)
+			defer cancel()
+			r = r.WithContext(ctx)
+		}
+		if s.options.OnChange == nil || r.Method == http.MethodGet || r.Method == http.MethodHead {
+			mux.ServeHTTP(w, r)
+			return
+		}
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		mux.ServeHTTP(rec, r)
+		if rec.status < http.StatusBadRequest {
+			s.options.OnChange()
+		}
+	})
+}
+
+// statusRecorder remembers the status a handler responds with, so that
+// failed requests, which changed nothing, do not count as changes
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// hostAllowed reports whether host, from a request's Host header, names this
+// server: localhost, a loopback address, or the host it listens on. When it
+// listens on every interface, any IP address is accepted too. Other names
+// are refused, since a site that rebinds its name to this machine would
+// otherwise be able to read the API.
+func (s *Server) hostAllowed(host string) bool {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+	host = strings.Trim(strings.ToLower(host), "[]")
+
+	listenHost := s.addr
+	if name, _, err := net.SplitHostPort(s.addr); err == nil {
+		listenHost = name
+	}
+	listenHost = strings.ToLower(listenHost)
+
+	ip := net.ParseIP(host)
+	switch {
+	case host == "localhost", host == listenHost:
+		return true
+	case ip == nil:
+		return false
+	case ip.IsLoopback():
+		return true
+	}
+	listenIP := net.ParseIP(listenHost)
+	return listenHost == "" || (listenIP != nil && listenIP.IsUnspecified())
+}
+
+// isJSON reports whether the request says its body is JSON
+func isJSON(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
+
+// createTaskRequest is the body of POST /api/tasks
+type createTaskRequest struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Priority    string   `json:"priority"`
+	ProjectID   string   `json:"project_id"`
+	ParentID    *string  `json:"parent_id"`
+	Tags        []string `json:"tags"`
+	DueDate     string   `json:"due_date"`
+	Estimate    string   `json:"estimate"`
+}
+
+func (s *Server) handleTasks(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		options := task.ListOptions{
+			ProjectID: query.Get("project"),
+			Search:    query.Get("search"),
+		}
+		if tag := query.Get("tag"); tag != "" {
+			options.Tags = []string{tag}
+		}
+		for _, status := range splitList(query.Get("status")) {
+			switch domain.TaskStatus(status) {
+			case domain.StatusBacklog, domain.StatusTodo, domain.StatusDoing, domain.StatusDone, domain.StatusBlocked:
+				options.Status = append(options.Status, domain.TaskStatus(status))
+			default:
+				writeError(w, fmt.Errorf("%w: %s", domain.ErrInvalidStatus, status))
+				return
+			}
+		}
+		limit, err := parseLimit(query.Get("limit"))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		options.Limit = limit
+
+		tasks, err := s.tasks.ListTasks(r.Context(), options)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, nonNil(tasks))
+	case http.MethodPost:
+		var req createTaskRequest
+		if err := decodeBody(w, r, &req); err != nil {
+			writeError(w, err)
+			return
+		}
+		priority := domain.PriorityNormal
+		if req.Priority != "" {
+			parsed, err := domain.ParsePriority(req.Priority)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			priority = parsed
+		}
+
+		created, err := s.tasks.CreateTask(r.Context(), task.CreateTaskInput{
+			Title:       strings.TrimSpace(req.Title),
+			Description: req.Description,
+			Priority:    priority,
+			ProjectID:   req.ProjectID,
+			ParentID:    req.ParentID,
+			Tags:        req.Tags,
+			DueDate:     req.DueDate,
+			Estimate:    req.Estimate,
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, created)
+	default:
+		methodNotAllowed(w, http.MethodGet, http.MethodPost)
+	}
+}
+
+func (s *Server) handleTask(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/tasks/"), "/")
+	if len(parts) > 2 || parts[0] == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	id, err := s.tasks.ResolveID(r.Context(), parts[0])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if len(parts) == 1 {
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, http.MethodGet)
+			return
+		}
+		t, err := s.tasks.GetTask(r.Context(), id)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, t)
+		return
+	}
+
+	var change func(context.Context, string) error
+	switch parts[1] {
+	case "start":
+		change = s.tasks.StartTask
+	case "complete":
+		change = s.tasks.CompleteTask
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+	if err := change(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	t, err := s.tasks.GetTask(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, t)
+}
+
+// createProjectRequest is the body of POST /api/projects
+type createProjectRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Color       string `json:"color"`
+}
+
+func (s *Server) handleProjects(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		var options project.ListOptions
+		for _, status := range splitList(r.URL.Query().Get("status")) {
+			switch domain.ProjectStatus(status) {
+			case domain.ProjectStatusActive, domain.ProjectStatusArchived, domain.ProjectStatusCompleted, domain.ProjectStatusOnHold:
+				options.Status = append(options.Status, domain.ProjectStatus(status))
+			default:
+				writeError(w, fmt.Errorf("%w: %s", domain.ErrInvalidStatus, status))
+				return
+			}
+		}
+
+		projects, err := s.projects.ListProjects(r.Context(), options)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, nonNil(projects))
+	case http.MethodPost:
+		var req createProjectRequest
+		if err := decodeBody(w, r, &req); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		created, err := s.projects.CreateProject(r.Context(), project.CreateProjectInput{
+			Name:        strings.TrimSpace(req.Name),
+			Description: req.Description,
+			Color:       req.Color,
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, created)
+	default:
+		methodNotAllowed(w, http.MethodGet, http.MethodPost)
+	}
+}
+
+func (s *Server) handleProject(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/projects/")
+	if id == "" || strings.Contains(id, "/") {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	p, err := s.projects.GetProject(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) handleTimeEntries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	query := r.URL.Query()
+	options := timeService.ListOptions{ProjectID: query.Get("project")}
+	if taskID := query.Get("task"); taskID != "" {
+		id, err := s.tasks.ResolveID(r.Context(), taskID)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		options.TaskID = id
+	}
+	limit, err := parseLimit(query.Get("limit"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	options.Limit = limit
+
+	entries, err := s.timer.ListTimeEntries(r.Context(), options)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, nonNil(entries))
+}
+
+// startTimerRequest is the body of POST /api/timer/start
+type startTimerRequest struct {
+	TaskID      string `json:"task_id"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+}
+
+func (s *Server) handleTimer(w http.ResponseWriter, r *http.Request) {
+	switch strings.TrimSuffix(r.URL.Path, "/") {
+	case "/api/timer":
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, http.MethodGet)
+			return
+		}
+		entry, err := s.timer.GetActiveTimeEntry(r.Context())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, entry)
+	case "/api/timer/start":
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w, http.MethodPost)
+			return
+		}
+		var req startTimerRequest
+		if err := decodeBody(w, r, &req); err != nil {
+			writeError(w, err)
+			return
+		}
+		if req.TaskID != "" {
+			id, err := s.tasks.ResolveID(r.Context(), req.TaskID)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			req.TaskID = id
+		}
+
+		entry, err := s.timer.StartTimeTracking(r.Context(), timeService.StartTimeEntryInput{
+			TaskID:      req.TaskID,
+			Category:    req.Category,
+			Description: req.Description,
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, entry)
+	case "/api/timer/stop":
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w, http.MethodPost)
+			return
+		}
+		entry, err := s.timer.StopTimeTracking(r.Context())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, entry)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// errBadRequest marks a request the API could not read
+var errBadRequest = errors.New("bad request")
+
+// decodeBody reads a JSON request body into v, rejecting unknown fields so
+// typos are not silently ignored
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("%w: invalid JSON body: %v", errBadRequest, err)
+	}
+	return nil
+}
+
+// parseLimit reads the limit query parameter; empty means no limit
+func parseLimit(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		return 0, fmt.Errorf("%w: limit must be a positive number", errBadRequest)
+	}
+	return limit, nil
+}
+
+// splitList splits a comma-separated query parameter
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// nonNil returns an empty list in place of nil, so lists encode as [] rather
+// than null
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+// errorResponse is the body of every failed request
+type errorResponse struct {
+	Error string `json:"error"`
+	Kind  string `json:"kind"`
+}
+
+// errorKinds maps domain errors to a kind and HTTP status, in the order they
+// are checked
+var errorKinds = []struct {
+	err    error
+	kind   string
+	status int
+}{
+	{errBadRequest, "validation", http.StatusBadRequest},
+	{domain.ErrTaskNotFound, "not_found", http.StatusNotFound},
+	{domain.ErrProjectNotFound, "not_found", http.StatusNotFound},
+	{domain.ErrTimeEntryNotFound, "not_found", http.StatusNotFound},
+	{domain.ErrParentNotFound, "not_found", http.StatusNotFound},
+	{domain.ErrNoActiveTimeEntry, "not_found", http.StatusNotFound},
+	{domain.ErrInvalidDueDate, "validation", http.StatusBadRequest},
+	{domain.ErrInvalidStatus, "validation", http.StatusBadRequest},
+	{domain.ErrInvalidPriority, "validation", http.StatusBadRequest},
+	{domain.ErrInvalidEstimate, "validation", http.StatusBadRequest},
+	{domain.ErrEmptyTitle, "validation", http.StatusBadRequest},
+	{domain.ErrEmptyName, "validation", http.StatusBadRequest},
+	{domain.ErrInvalidTaskID, "validation", http.StatusBadRequest},
+	{domain.ErrInvalidProjectID, "validation", http.StatusBadRequest},
+	{domain.ErrAmbiguousID, "validation", http.StatusBadRequest},
+	{domain.ErrTaskOrCategory, "validation", http.StatusBadRequest},
+	{domain.ErrDuplicateProject, "conflict", http.StatusConflict},
+	{domain.ErrActiveTimeEntry, "conflict", http.StatusConflict},
+	{domain.ErrCircularDependency, "conflict", http.StatusConflict},
+}
+
+// writeError writes err as JSON with the status that fits it
+func writeError(w http.ResponseWriter, err error) {
+	kind, status := "error", http.StatusInternalServerError
+	for _, k := range errorKinds {
+		if errors.Is(err, k.err) {
+			kind, status = k.kind, k.status
+			break
+		}
+	}
+	writeJSON(w, status, errorResponse{Error: err.Error(), Kind: kind})
+}
+
+// methodNotAllowed answers a request made with a method the route does not
+// support
+func methodNotAllowed(w http.ResponseWriter, allowed ...string) {
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed", Kind: "validation"})
+}
+
+// writeJSON writes v as the JSON response body
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
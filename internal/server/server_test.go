@@ -0,0 +1,196 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+	"github.com/adriannajera/project-manager-cli/internal/repository/memory"
+	"github.com/adriannajera/project-manager-cli/internal/service/project"
+	"github.com/adriannajera/project-manager-cli/internal/service/task"
+	timeService "github.com/adriannajera/project-manager-cli/internal/service/time"
+)
+
+func newTestServer(options Options) http.Handler {
+	store := memory.NewStore()
+	taskRepo := memory.NewTaskRepository(store)
+	projectRepo := memory.NewProjectRepository(store)
+	timeSvc := timeService.NewService(memory.NewTimeEntryRepository(store), taskRepo, store, timeService.Options{})
+	timeSvc.SetProjectRepository(projectRepo)
+
+	return New(task.NewService(taskRepo, nil), project.NewService(projectRepo, nil), timeSvc, options).Handler()
+}
+
+func do(t *testing.T, handler http.Handler, method, path, body string, out interface{}) int {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Host = "localhost:8080"
+	if method == http.MethodPost {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: invalid JSON %q: %v", method, path, rec.Body.String(), err)
+		}
+	}
+	return rec.Code
+}
+
+func TestTaskAndTimerRoutes(t *testing.T) {
+	handler := newTestServer(Options{})
+
+	var created domain.Task
+	if code := do(t, handler, http.MethodPost, "/api/tasks", `{"title":"Fix login","priority":"high"}`, &created); code != http.StatusCreated {
+		t.Fatalf("Expected 201 creating a task, got %d", code)
+	}
+	if created.Title != "Fix login" || created.Priority != domain.PriorityHigh {
+		t.Errorf("Expected the task as created, got %+v", created)
+	}
+
+	var tasks []domain.Task
+	if code := do(t, handler, http.MethodGet, "/api/tasks?status=todo", "", &tasks); code != http.StatusOK || len(tasks) != 1 {
+		t.Errorf("Expected one todo task, got %d (%d)", len(tasks), code)
+	}
+
+	var entry domain.TimeEntry
+	body := `{"task_id":"` + created.ID[:8] + `"}`
+	if code := do(t, handler, http.MethodPost, "/api/timer/start", body, &entry); code != http.StatusCreated || entry.TaskID != created.ID {
+		t.Fatalf("Expected a timer on the task, got %d %+v", code, entry)
+	}
+
+	var failure errorResponse
+	if code := do(t, handler, http.MethodPost, "/api/timer/start", body, &failure); code != http.StatusConflict || failure.Kind != "conflict" {
+		t.Errorf("Expected a second timer to conflict, got %d %+v", code, failure)
+	}
+
+	var started domain.Task
+	do(t, handler, http.MethodGet, "/api/tasks/"+created.ID, "", &started)
+	if started.Status != domain.StatusDoing {
+		t.Errorf("Expected starting a timer to move the task to doing, got %s", started.Status)
+	}
+
+	if code := do(t, handler, http.MethodPost, "/api/timer/stop", "", &entry); code != http.StatusOK || entry.EndTime == nil {
+		t.Errorf("Expected the timer to stop, got %d %+v", code, entry)
+	}
+}
+
+func TestErrorsAndReadOnly(t *testing.T) {
+	handler := newTestServer(Options{})
+
+	var failure errorResponse
+	if code := do(t, handler, http.MethodGet, "/api/tasks/missing-task", "", &failure); code != http.StatusNotFound || failure.Kind != "not_found" {
+		t.Errorf("Expected 404 for an unknown task, got %d %+v", code, failure)
+	}
+	if code := do(t, handler, http.MethodPost, "/api/tasks", `{"title":""}`, &failure); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an empty title, got %d", code)
+	}
+	if code := do(t, handler, http.MethodPost, "/api/tasks", `{"titel":"Typo"}`, &failure); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown field, got %d", code)
+	}
+	if code := do(t, handler, http.MethodGet, "/api/tasks?status=later", "", &failure); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown status, got %d", code)
+	}
+
+	readOnly := newTestServer(Options{ReadOnly: true})
+	if code := do(t, readOnly, http.MethodPost, "/api/projects", `{"name":"Website"}`, &failure); code != http.StatusForbidden {
+		t.Errorf("Expected 403 creating a project on a read-only server, got %d", code)
+	}
+	var projects []domain.Project
+	if code := do(t, readOnly, http.MethodGet, "/api/projects", "", &projects); code != http.StatusOK || projects == nil {
+		t.Errorf("Expected an empty project list, got %d %v", code, projects)
+	}
+}
+
+func TestCrossSiteRequestsAreRefused(t *testing.T) {
+	srv := New(nil, nil, nil, Options{})
+	srv.addr = "127.0.0.1:8080"
+	handler := srv.Handler()
+
+	for _, tc := range []struct {
+		name        string
+		method      string
+		host        string
+		origin      string
+		contentType string
+		want        int
+	}{
+		{"rebound host name", http.MethodGet, "attacker.example:8080", "", "", http.StatusForbidden},
+		{"another origin", http.MethodPost, "127.0.0.1:8080", "https://attacker.example", "application/json", http.StatusForbidden},
+		{"plain text body", http.MethodPost, "127.0.0.1:8080", "", "text/plain", http.StatusUnsupportedMediaType},
+		{"form body", http.MethodPost, "localhost:8080", "", "application/x-www-form-urlencoded", http.StatusUnsupportedMediaType},
+		{"no content type", http.MethodPost, "localhost:8080", "", "", http.StatusUnsupportedMediaType},
+	} {
+		req := httptest.NewRequest(tc.method, "/api/timer/stop", nil)
+		req.Host = tc.host
+		if tc.origin != "" {
+			req.Header.Set("Origin", tc.origin)
+		}
+		if tc.contentType != "" {
+			req.Header.Set("Content-Type", tc.contentType)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("%s: expected %d, got %d", tc.name, tc.want, rec.Code)
+		}
+	}
+
+	for _, host := range []string{"127.0.0.1:8080", "localhost:8080", "[::1]:8080"} {
+		if !srv.hostAllowed(host) {
+			t.Errorf("Expected host %s to be allowed", host)
+		}
+	}
+	if srv.hostAllowed("192.168.1.20:8080") {
+		t.Error("Expected another address to be refused when listening on loopback")
+	}
+	srv.addr = ":8080"
+	if !srv.hostAllowed("192.168.1.20:8080") {
+		t.Error("Expected any address to be allowed when listening on every interface")
+	}
+}
+
+func TestSameOriginRequestsAreServed(t *testing.T) {
+	handler := newTestServer(Options{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/projects", nil)
+	req.Host = "localhost:8080"
+	req.Header.Set("Origin", "http://localhost:8080")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected a same-origin request to be served, got %d", rec.Code)
+	}
+}
+
+func TestOnChangeFollowsSuccessfulChanges(t *testing.T) {
+	changes := 0
+	handler := newTestServer(Options{OnChange: func() { changes++ }})
+
+	var created domain.Task
+	do(t, handler, http.MethodPost, "/api/tasks", `{"title":"Fix login"}`, &created)
+	if changes != 1 {
+		t.Fatalf("Expected one change after creating a task, got %d", changes)
+	}
+
+	do(t, handler, http.MethodGet, "/api/tasks/"+created.ID, "", nil)
+	do(t, handler, http.MethodPost, "/api/tasks", `{"title":""}`, nil)
+	if changes != 1 {
+		t.Errorf("Expected reads and failed requests not to count as changes, got %d", changes)
+	}
+}
+
+func TestListenAndServeStopsWithTheContext(t *testing.T) {
+	srv := New(nil, nil, nil, Options{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := srv.ListenAndServe(ctx, "127.0.0.1:0"); err != nil {
+		t.Errorf("Expected a clean shutdown, got %v", err)
+	}
+}
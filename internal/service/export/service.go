@@ -1,10 +1,13 @@
 package export
 
 import (
+	"bytes"
 	"context"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
 	"time"
 
@@ -35,49 +38,173 @@ func NewService(
 type ExportFormat string
 
 const (
-	FormatJSON ExportFormat = "json"
-	FormatCSV  ExportFormat = "csv"
-	FormatICAL ExportFormat = "ical"
+	FormatJSON  ExportFormat = "json"
+	FormatCSV   ExportFormat = "csv"
+	FormatICAL  ExportFormat = "ical"
+	FormatJSONL ExportFormat = "jsonl"
 )
 
+// csvTimeLayout is the default layout for CSV timestamps
+const csvTimeLayout = "2006-01-02 15:04:05"
+
+// ExportOptions controls CSV output
+type ExportOptions struct {
+	// Delimiter separates fields; zero means a comma
+	Delimiter rune
+	// IncludeHeader writes the column names as the first row
+	IncludeHeader bool
+	// ByteOrderMark starts the output with a UTF-8 BOM, which Excel needs
+	// to detect the encoding
+	ByteOrderMark bool
+	// TimeLayout formats timestamps; empty means "2006-01-02 15:04:05"
+	TimeLayout string
+}
+
+// ExcelExportOptions returns options for CSV that Excel opens correctly: a
+// BOM and ISO 8601 timestamps
+func ExcelExportOptions() ExportOptions {
+	options := DefaultExportOptions()
+	options.ByteOrderMark = true
+	options.TimeLayout = time.RFC3339
+	return options
+}
+
+// timeLayout returns the layout for CSV timestamps
+func (o ExportOptions) timeLayout() string {
+	if o.TimeLayout == "" {
+		return csvTimeLayout
+	}
+	return o.TimeLayout
+}
+
+// writeBOM writes the UTF-8 byte order mark when the options ask for one
+func (o ExportOptions) writeBOM(w io.Writer) error {
+	if !o.ByteOrderMark {
+		return nil
+	}
+	if _, err := io.WriteString(w, "\uFEFF"); err != nil {
+		return fmt.Errorf("failed to write byte order mark: %w", err)
+	}
+	return nil
+}
+
+// DefaultExportOptions returns comma-separated output with a header row
+func DefaultExportOptions() ExportOptions {
+	return ExportOptions{Delimiter: ',', IncludeHeader: true}
+}
+
+// newCSVWriter returns a CSV writer using the options' delimiter
+func (o ExportOptions) newCSVWriter(w io.Writer) *csv.Writer {
+	writer := csv.NewWriter(w)
+	if o.Delimiter != 0 {
+		writer.Comma = o.Delimiter
+	}
+	return writer
+}
+
 // ExportTasksToJSON exports tasks to JSON format
 func (s *Service) ExportTasksToJSON(ctx context.Context, filter domain.TaskFilter) ([]byte, error) {
-	tasks, err := s.taskRepo.List(ctx, filter)
+	var buf bytes.Buffer
+	if err := s.WriteTasksJSON(ctx, filter, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteTasksJSON writes tasks to w as an indented JSON array, encoding each
+// task as it is read
+func (s *Service) WriteTasksJSON(ctx context.Context, filter domain.TaskFilter, w io.Writer) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return fmt.Errorf("failed to write JSON: %w", err)
+	}
+
+	count := 0
+	err := s.taskRepo.ListEach(ctx, filter, func(task *domain.Task) error {
+		data, err := json.MarshalIndent(task, "  ", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode task %s: %w", task.ID, err)
+		}
+
+		separator := ",\n  "
+		if count == 0 {
+			separator = "\n  "
+		}
+		count++
+		if _, err := io.WriteString(w, separator); err != nil {
+			return fmt.Errorf("failed to write JSON: %w", err)
+		}
+		if _, err := w.Write(data); err != nil {
+			return fmt.Errorf("failed to write JSON: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tasks: %w", err)
+		return err
 	}
 
-	return json.MarshalIndent(tasks, "", "  ")
+	closing := "]\n"
+	if count > 0 {
+		closing = "\n]\n"
+	}
+	if _, err := io.WriteString(w, closing); err != nil {
+		return fmt.Errorf("failed to write JSON: %w", err)
+	}
+	return nil
 }
 
-// ExportTasksToCSV exports tasks to CSV format
-func (s *Service) ExportTasksToCSV(ctx context.Context, filter domain.TaskFilter) ([]byte, error) {
-	tasks, err := s.taskRepo.List(ctx, filter)
+// ExportTasksToJSONL writes tasks to w as JSON Lines, one object per line,
+// encoding each task as it is read so large exports are never held in memory
+func (s *Service) ExportTasksToJSONL(ctx context.Context, filter domain.TaskFilter, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	err := s.taskRepo.ListEach(ctx, filter, func(task *domain.Task) error {
+		if err := encoder.Encode(task); err != nil {
+			return fmt.Errorf("failed to write task %s: %w", task.ID, err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tasks: %w", err)
+		return err
+	}
+	return nil
+}
+
+// ExportTasksToCSV exports tasks to CSV format
+func (s *Service) ExportTasksToCSV(ctx context.Context, filter domain.TaskFilter, options ExportOptions) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := s.WriteTasksCSV(ctx, filter, options, &buf); err != nil {
+		return nil, err
 	}
+	return buf.Bytes(), nil
+}
 
-	var buf strings.Builder
-	writer := csv.NewWriter(&buf)
+// WriteTasksCSV writes tasks to w as CSV, one row per task as it is read
+func (s *Service) WriteTasksCSV(ctx context.Context, filter domain.TaskFilter, options ExportOptions, w io.Writer) error {
+	if err := options.writeBOM(w); err != nil {
+		return err
+	}
+	writer := options.newCSVWriter(w)
+	timeLayout := options.timeLayout()
 
 	// Write header
-	header := []string{
-		"ID", "Title", "Description", "Status", "Priority", "Project ID",
-		"Tags", "Due Date", "Created At", "Updated At", "Completed At",
-		"Note ID", "Note Path", "Has Note", "Note Created", "Note Updated",
-	}
-	if err := writer.Write(header); err != nil {
-		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	if options.IncludeHeader {
+		header := []string{
+			"ID", "Title", "Description", "Status", "Priority", "Priority Level",
+			"Project ID", "Tags", "Due Date", "Created At", "Updated At", "Completed At",
+			"Note ID", "Note Path", "Has Note", "Note Created", "Note Updated",
+		}
+		if err := writer.Write(header); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
 	}
 
 	// Write tasks
-	for _, task := range tasks {
+	err := s.taskRepo.ListEach(ctx, filter, func(task *domain.Task) error {
 		var dueDate, completedAt string
 		if task.DueDate != nil {
-			dueDate = task.DueDate.Format("2006-01-02 15:04:05")
+			dueDate = task.DueDate.Format(timeLayout)
 		}
 		if task.CompletedAt != nil {
-			completedAt = task.CompletedAt.Format("2006-01-02 15:04:05")
+			completedAt = task.CompletedAt.Format(timeLayout)
 		}
 
 		// Handle note fields
@@ -95,10 +222,10 @@ func (s *Service) ExportTasksToCSV(ctx context.Context, filter domain.TaskFilter
 			hasNote = "false"
 		}
 		if task.NoteCreatedAt != nil {
-			noteCreated = task.NoteCreatedAt.Format("2006-01-02 15:04:05")
+			noteCreated = task.NoteCreatedAt.Format(timeLayout)
 		}
 		if task.NoteUpdatedAt != nil {
-			noteUpdated = task.NoteUpdatedAt.Format("2006-01-02 15:04:05")
+			noteUpdated = task.NoteUpdatedAt.Format(timeLayout)
 		}
 
 		record := []string{
@@ -107,11 +234,13 @@ func (s *Service) ExportTasksToCSV(ctx context.Context, filter domain.TaskFilter
 			task.Description,
 			string(task.Status),
 			task.Priority.String(),
+			// Numeric so spreadsheets sort by severity, from 0 (low) to 3 (critical)
+			strconv.Itoa(int(task.Priority)),
 			task.ProjectID,
 			strings.Join(task.Tags, ";"),
 			dueDate,
-			task.CreatedAt.Format("2006-01-02 15:04:05"),
-			task.UpdatedAt.Format("2006-01-02 15:04:05"),
+			task.CreatedAt.Format(timeLayout),
+			task.UpdatedAt.Format(timeLayout),
 			completedAt,
 			noteID,
 			notePath,
@@ -121,26 +250,56 @@ func (s *Service) ExportTasksToCSV(ctx context.Context, filter domain.TaskFilter
 		}
 
 		if err := writer.Write(record); err != nil {
-			return nil, fmt.Errorf("failed to write CSV record: %w", err)
+			return fmt.Errorf("failed to write CSV record: %w", err)
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	writer.Flush()
 	if err := writer.Error(); err != nil {
-		return nil, fmt.Errorf("failed to flush CSV writer: %w", err)
+		return fmt.Errorf("failed to flush CSV writer: %w", err)
 	}
 
-	return []byte(buf.String()), nil
+	return nil
 }
 
-// ExportTasksToICAL exports tasks with due dates to iCal format
-func (s *Service) ExportTasksToICAL(ctx context.Context, filter domain.TaskFilter) ([]byte, error) {
-	tasks, err := s.taskRepo.List(ctx, filter)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get tasks: %w", err)
+// ICALMode selects how tasks are represented in an iCal export
+type ICALMode string
+
+const (
+	// ICALModeTodo exports every task as a VTODO, the iCal to-do component
+	ICALModeTodo ICALMode = "todo"
+	// ICALModeEvent exports tasks with due dates as VEVENTs on that date
+	ICALModeEvent ICALMode = "event"
+)
+
+// ParseICALMode parses an iCal export mode name
+func ParseICALMode(value string) (ICALMode, error) {
+	switch ICALMode(value) {
+	case ICALModeTodo, ICALModeEvent:
+		return ICALMode(value), nil
+	default:
+		return "", fmt.Errorf("invalid iCal mode: %s (must be todo or event)", value)
 	}
+}
+
+// ExportTasksToICAL exports tasks to iCal format
+func (s *Service) ExportTasksToICAL(ctx context.Context, filter domain.TaskFilter, mode ICALMode) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := s.WriteTasksICAL(ctx, filter, mode, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
 
-	var buf strings.Builder
+// WriteTasksICAL writes tasks to w as an iCal calendar: every task as a
+// to-do, or only tasks with due dates as events
+func (s *Service) WriteTasksICAL(ctx context.Context, filter domain.TaskFilter, mode ICALMode, w io.Writer) error {
+	buf := &icalWriter{w: w}
+	stamp := time.Now().UTC().Format("20060102T150405Z")
 
 	// iCal header
 	buf.WriteString("BEGIN:VCALENDAR\r\n")
@@ -148,98 +307,255 @@ func (s *Service) ExportTasksToICAL(ctx context.Context, filter domain.TaskFilte
 	buf.WriteString("PRODID:-//Project Manager CLI//NONSGML v1.0//EN\r\n")
 	buf.WriteString("CALSCALE:GREGORIAN\r\n")
 
-	// Export tasks with due dates as events
-	for _, task := range tasks {
-		if task.DueDate != nil {
-			buf.WriteString("BEGIN:VEVENT\r\n")
-			buf.WriteString(fmt.Sprintf("UID:%s@pm-cli\r\n", task.ID))
-			buf.WriteString(fmt.Sprintf("DTSTAMP:%s\r\n", time.Now().UTC().Format("20060102T150405Z")))
-			buf.WriteString(fmt.Sprintf("DTSTART:%s\r\n", task.DueDate.UTC().Format("20060102T150405Z")))
-			buf.WriteString(fmt.Sprintf("SUMMARY:%s\r\n", escapeICALText(task.Title)))
-
-			if task.Description != "" {
-				buf.WriteString(fmt.Sprintf("DESCRIPTION:%s\r\n", escapeICALText(task.Description)))
+	err := s.taskRepo.ListEach(ctx, filter, func(task *domain.Task) error {
+		switch mode {
+		case ICALModeEvent:
+			if task.DueDate != nil {
+				writeICALEvent(buf, task, stamp)
 			}
-
-			buf.WriteString(fmt.Sprintf("STATUS:%s\r\n", mapTaskStatusToICAL(task.Status)))
-			buf.WriteString("END:VEVENT\r\n")
+		default:
+			writeICALTodo(buf, task, stamp)
 		}
+		return buf.err
+	})
+	if err != nil {
+		return err
 	}
 
 	buf.WriteString("END:VCALENDAR\r\n")
 
-	return []byte(buf.String()), nil
+	if buf.err != nil {
+		return fmt.Errorf("failed to write iCal: %w", buf.err)
+	}
+	return nil
+}
+
+// writeICALEvent writes a task with a due date as an event on that date
+func writeICALEvent(buf *icalWriter, task *domain.Task, stamp string) {
+	buf.WriteString("BEGIN:VEVENT\r\n")
+	buf.WriteString(fmt.Sprintf("UID:%s@pm-cli\r\n", task.ID))
+	buf.WriteString(fmt.Sprintf("DTSTAMP:%s\r\n", stamp))
+	buf.WriteString(fmt.Sprintf("DTSTART:%s\r\n", task.DueDate.UTC().Format("20060102T150405Z")))
+	buf.WriteString(fmt.Sprintf("SUMMARY:%s\r\n", escapeICALText(task.Title)))
+
+	writeICALDetails(buf, task)
+
+	buf.WriteString(fmt.Sprintf("STATUS:%s\r\n", mapTaskStatusToICAL(task.Status)))
+	buf.WriteString("END:VEVENT\r\n")
+}
+
+// writeICALTodo writes a task as a to-do, due on its due date if it has one
+func writeICALTodo(buf *icalWriter, task *domain.Task, stamp string) {
+	buf.WriteString("BEGIN:VTODO\r\n")
+	buf.WriteString(fmt.Sprintf("UID:%s@pm-cli\r\n", task.ID))
+	buf.WriteString(fmt.Sprintf("DTSTAMP:%s\r\n", stamp))
+	buf.WriteString(fmt.Sprintf("CREATED:%s\r\n", task.CreatedAt.UTC().Format("20060102T150405Z")))
+	buf.WriteString(fmt.Sprintf("LAST-MODIFIED:%s\r\n", task.UpdatedAt.UTC().Format("20060102T150405Z")))
+	buf.WriteString(fmt.Sprintf("SUMMARY:%s\r\n", escapeICALText(task.Title)))
+
+	writeICALDetails(buf, task)
+	if task.DueDate != nil {
+		buf.WriteString(fmt.Sprintf("DUE:%s\r\n", task.DueDate.UTC().Format("20060102T150405Z")))
+	}
+
+	buf.WriteString(fmt.Sprintf("STATUS:%s\r\n", mapTaskStatusToICAL(task.Status)))
+	buf.WriteString(fmt.Sprintf("PERCENT-COMPLETE:%d\r\n", percentComplete(task.Status)))
+	if task.CompletedAt != nil {
+		buf.WriteString(fmt.Sprintf("COMPLETED:%s\r\n", task.CompletedAt.UTC().Format("20060102T150405Z")))
+	}
+	buf.WriteString("END:VTODO\r\n")
+}
+
+// icalWriter keeps the first write error so a calendar can be written line
+// by line and checked once
+type icalWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (iw *icalWriter) WriteString(s string) {
+	if iw.err == nil {
+		_, iw.err = io.WriteString(iw.w, s)
+	}
 }
 
 // ExportProjectsToJSON exports projects to JSON format
 func (s *Service) ExportProjectsToJSON(ctx context.Context, filter domain.ProjectFilter) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := s.WriteProjectsJSON(ctx, filter, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteProjectsJSON writes projects to w as an indented JSON array
+func (s *Service) WriteProjectsJSON(ctx context.Context, filter domain.ProjectFilter, w io.Writer) error {
 	projects, err := s.projectRepo.List(ctx, filter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get projects: %w", err)
+		return fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(projects); err != nil {
+		return fmt.Errorf("failed to write JSON: %w", err)
+	}
+	return nil
+}
+
+// ExportProjectsToCSV exports projects to CSV format
+func (s *Service) ExportProjectsToCSV(ctx context.Context, filter domain.ProjectFilter, options ExportOptions) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := s.WriteProjectsCSV(ctx, filter, options, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteProjectsCSV writes projects to w as CSV
+func (s *Service) WriteProjectsCSV(ctx context.Context, filter domain.ProjectFilter, options ExportOptions, w io.Writer) error {
+	projects, err := s.projectRepo.List(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	if err := options.writeBOM(w); err != nil {
+		return err
+	}
+	writer := options.newCSVWriter(w)
+	timeLayout := options.timeLayout()
+
+	// Write header
+	if options.IncludeHeader {
+		header := []string{
+			"ID", "Name", "Description", "Status", "Color",
+			"Created At", "Updated At", "Archived At",
+		}
+		if err := writer.Write(header); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
+	}
+
+	// Write projects
+	for _, project := range projects {
+		var archivedAt string
+		if project.ArchivedAt != nil {
+			archivedAt = project.ArchivedAt.Format(timeLayout)
+		}
+
+		record := []string{
+			project.ID,
+			project.Name,
+			project.Description,
+			string(project.Status),
+			project.Color,
+			project.CreatedAt.Format(timeLayout),
+			project.UpdatedAt.Format(timeLayout),
+			archivedAt,
+		}
+
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV record: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to flush CSV writer: %w", err)
 	}
 
-	return json.MarshalIndent(projects, "", "  ")
+	return nil
 }
 
 // ExportTimeEntriesToJSON exports time entries to JSON format
 func (s *Service) ExportTimeEntriesToJSON(ctx context.Context, filter domain.TimeEntryFilter) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := s.WriteTimeEntriesJSON(ctx, filter, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteTimeEntriesJSON writes time entries to w as an indented JSON array
+func (s *Service) WriteTimeEntriesJSON(ctx context.Context, filter domain.TimeEntryFilter, w io.Writer) error {
 	entries, err := s.timeEntryRepo.List(ctx, filter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get time entries: %w", err)
+		return fmt.Errorf("failed to get time entries: %w", err)
 	}
 
-	return json.MarshalIndent(entries, "", "  ")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(entries); err != nil {
+		return fmt.Errorf("failed to write JSON: %w", err)
+	}
+	return nil
 }
 
 // ExportTimeEntriesToCSV exports time entries to CSV format
-func (s *Service) ExportTimeEntriesToCSV(ctx context.Context, filter domain.TimeEntryFilter) ([]byte, error) {
+func (s *Service) ExportTimeEntriesToCSV(ctx context.Context, filter domain.TimeEntryFilter, options ExportOptions) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := s.WriteTimeEntriesCSV(ctx, filter, options, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteTimeEntriesCSV writes time entries to w as CSV
+func (s *Service) WriteTimeEntriesCSV(ctx context.Context, filter domain.TimeEntryFilter, options ExportOptions, w io.Writer) error {
 	entries, err := s.timeEntryRepo.List(ctx, filter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get time entries: %w", err)
+		return fmt.Errorf("failed to get time entries: %w", err)
 	}
 
-	var buf strings.Builder
-	writer := csv.NewWriter(&buf)
+	if err := options.writeBOM(w); err != nil {
+		return err
+	}
+	writer := options.newCSVWriter(w)
+	timeLayout := options.timeLayout()
 
 	// Write header
-	header := []string{
-		"ID", "Task ID", "Project ID", "Description", "Start Time",
-		"End Time", "Duration (seconds)", "Created At", "Updated At",
-	}
-	if err := writer.Write(header); err != nil {
-		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	if options.IncludeHeader {
+		header := []string{
+			"ID", "Task ID", "Category", "Project ID", "Description",
+			"Start Time", "End Time", "Duration (seconds)", "Created At",
+			"Updated At",
+		}
+		if err := writer.Write(header); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
 	}
 
 	// Write time entries
 	for _, entry := range entries {
 		var endTime string
 		if entry.EndTime != nil {
-			endTime = entry.EndTime.Format("2006-01-02 15:04:05")
+			endTime = entry.EndTime.Format(timeLayout)
 		}
 
 		record := []string{
 			entry.ID,
 			entry.TaskID,
+			entry.Category,
 			entry.ProjectID,
 			entry.Description,
-			entry.StartTime.Format("2006-01-02 15:04:05"),
+			entry.StartTime.Format(timeLayout),
 			endTime,
 			fmt.Sprintf("%.0f", entry.GetDuration().Seconds()),
-			entry.CreatedAt.Format("2006-01-02 15:04:05"),
-			entry.UpdatedAt.Format("2006-01-02 15:04:05"),
+			entry.CreatedAt.Format(timeLayout),
+			entry.UpdatedAt.Format(timeLayout),
 		}
 
 		if err := writer.Write(record); err != nil {
-			return nil, fmt.Errorf("failed to write CSV record: %w", err)
+			return fmt.Errorf("failed to write CSV record: %w", err)
 		}
 	}
 
 	writer.Flush()
 	if err := writer.Error(); err != nil {
-		return nil, fmt.Errorf("failed to flush CSV writer: %w", err)
+		return fmt.Errorf("failed to flush CSV writer: %w", err)
 	}
 
-	return []byte(buf.String()), nil
+	return nil
 }
 
 // escapeICALText escapes special characters in iCal text fields
@@ -252,6 +568,59 @@ func escapeICALText(text string) string {
 	return text
 }
 
+// writeICALDetails writes the description, priority, and categories shared
+// by events and to-dos
+func writeICALDetails(buf *icalWriter, task *domain.Task) {
+	// The description ends with the task's changelist and ID so imported
+	// items can be traced back
+	var details []string
+	if task.Description != "" {
+		details = append(details, task.Description, "")
+	}
+	if task.Changelist != "" {
+		details = append(details, "Changelist: "+task.Changelist)
+	}
+	details = append(details, "Task ID: "+task.ID)
+	buf.WriteString(fmt.Sprintf("DESCRIPTION:%s\r\n", escapeICALText(strings.Join(details, "\n"))))
+
+	buf.WriteString(fmt.Sprintf("PRIORITY:%d\r\n", mapPriorityToICAL(task.Priority)))
+
+	if len(task.Tags) > 0 {
+		categories := make([]string, len(task.Tags))
+		for i, tag := range task.Tags {
+			categories[i] = escapeICALText(tag)
+		}
+		buf.WriteString(fmt.Sprintf("CATEGORIES:%s\r\n", strings.Join(categories, ",")))
+	}
+}
+
+// mapPriorityToICAL maps a task priority onto the iCal PRIORITY scale, where
+// 1 is the most urgent and 9 the least
+func mapPriorityToICAL(priority domain.Priority) int {
+	switch priority {
+	case domain.PriorityCritical:
+		return 1
+	case domain.PriorityHigh:
+		return 3
+	case domain.PriorityLow:
+		return 9
+	default:
+		return 5
+	}
+}
+
+// percentComplete estimates a to-do's progress from its status
+func percentComplete(status domain.TaskStatus) int {
+	switch status {
+	case domain.StatusDone:
+		return 100
+	case domain.StatusDoing:
+		return 50
+	default:
+		return 0
+	}
+}
+
 // mapTaskStatusToICAL maps task status to iCal status
 func mapTaskStatusToICAL(status domain.TaskStatus) string {
 	switch status {
@@ -264,4 +633,4 @@ func mapTaskStatusToICAL(status domain.TaskStatus) string {
 	default:
 		return "NEEDS-ACTION"
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,221 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+// listTaskRepository serves a fixed set of tasks; other methods are unused
+type listTaskRepository struct {
+	domain.TaskRepository
+	tasks []*domain.Task
+}
+
+func (r *listTaskRepository) List(ctx context.Context, filter domain.TaskFilter) ([]*domain.Task, error) {
+	return r.tasks, nil
+}
+
+func (r *listTaskRepository) ListEach(ctx context.Context, filter domain.TaskFilter, fn func(*domain.Task) error) error {
+	for _, task := range r.tasks {
+		if err := fn(task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listTimeEntryRepository serves a fixed set of time entries; other methods
+// are unused
+type listTimeEntryRepository struct {
+	domain.TimeEntryRepository
+	entries []*domain.TimeEntry
+}
+
+func (r *listTimeEntryRepository) List(ctx context.Context, filter domain.TimeEntryFilter) ([]*domain.TimeEntry, error) {
+	return r.entries, nil
+}
+
+func TestExportTasksToJSONLWritesOneTaskPerLine(t *testing.T) {
+	first := domain.NewTask("First", "spans\ntwo lines")
+	second := domain.NewTask("Second", "")
+	service := NewService(&listTaskRepository{tasks: []*domain.Task{first, second}}, nil, nil)
+
+	var buf bytes.Buffer
+	if err := service.ExportTasksToJSONL(context.Background(), domain.TaskFilter{}, &buf); err != nil {
+		t.Fatalf("ExportTasksToJSONL failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+	for i, want := range []*domain.Task{first, second} {
+		var got domain.Task
+		if err := json.Unmarshal([]byte(lines[i]), &got); err != nil {
+			t.Fatalf("Line %d is not valid JSON: %v", i+1, err)
+		}
+		if got.ID != want.ID || got.Description != want.Description {
+			t.Errorf("Line %d = %+v, want task %s", i+1, got, want.ID)
+		}
+	}
+}
+
+func TestWriteTasksJSONMatchesIndentedArray(t *testing.T) {
+	tasks := []*domain.Task{domain.NewTask("First", ""), domain.NewTask("Second", "")}
+	service := NewService(&listTaskRepository{tasks: tasks}, nil, nil)
+
+	var buf bytes.Buffer
+	if err := service.WriteTasksJSON(context.Background(), domain.TaskFilter{}, &buf); err != nil {
+		t.Fatalf("WriteTasksJSON failed: %v", err)
+	}
+
+	want, _ := json.MarshalIndent(tasks, "", "  ")
+	if got := buf.String(); got != string(want)+"\n" {
+		t.Errorf("WriteTasksJSON output differs from json.MarshalIndent:\n%s", got)
+	}
+
+	empty := NewService(&listTaskRepository{}, nil, nil)
+	data, err := empty.ExportTasksToJSON(context.Background(), domain.TaskFilter{})
+	if err != nil || string(data) != "[]\n" {
+		t.Errorf("ExportTasksToJSON with no tasks = %q, %v; want %q", data, err, "[]\n")
+	}
+}
+
+func TestWriteTasksICALModes(t *testing.T) {
+	undated := domain.NewTask("Undated", "")
+	dated := domain.NewTask("Dated", "")
+	due := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	dated.DueDate = &due
+	dated.Priority = domain.PriorityCritical
+	dated.Tags = []string{"release", "a,b"}
+	dated.Changelist = "CL-42"
+	dated.Complete()
+	service := NewService(&listTaskRepository{tasks: []*domain.Task{undated, dated}}, nil, nil)
+
+	var todos bytes.Buffer
+	if err := service.WriteTasksICAL(context.Background(), domain.TaskFilter{}, ICALModeTodo, &todos); err != nil {
+		t.Fatalf("WriteTasksICAL failed: %v", err)
+	}
+	out := todos.String()
+	if got := strings.Count(out, "BEGIN:VTODO"); got != 2 {
+		t.Errorf("Expected a VTODO for each task, got %d", got)
+	}
+	for _, want := range []string{
+		"DUE:20251020T090000Z", "STATUS:COMPLETED", "PERCENT-COMPLETE:100", "PERCENT-COMPLETE:0",
+		"PRIORITY:1", "PRIORITY:5", "CATEGORIES:release,a\\,b", "DESCRIPTION:Changelist: CL-42\\nTask ID: " + dated.ID,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %s in to-do export", want)
+		}
+	}
+
+	var events bytes.Buffer
+	if err := service.WriteTasksICAL(context.Background(), domain.TaskFilter{}, ICALModeEvent, &events); err != nil {
+		t.Fatalf("WriteTasksICAL failed: %v", err)
+	}
+	if got := strings.Count(events.String(), "BEGIN:VEVENT"); got != 1 || strings.Contains(events.String(), "VTODO") {
+		t.Errorf("Expected only the dated task as an event, got:\n%s", events.String())
+	}
+}
+
+func TestWriteTasksCSVOptions(t *testing.T) {
+	task := domain.NewTask("Fix; then ship", "")
+	service := NewService(&listTaskRepository{tasks: []*domain.Task{task}}, nil, nil)
+
+	var buf bytes.Buffer
+	options := ExportOptions{Delimiter: ';'}
+	if err := service.WriteTasksCSV(context.Background(), domain.TaskFilter{}, options, &buf); err != nil {
+		t.Fatalf("WriteTasksCSV failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Expected only the task row without a header, got %d lines", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], task.ID+`;"Fix; then ship";;todo;normal;1;`) {
+		t.Errorf("Expected semicolon-separated fields with the numeric priority, got %q", lines[0])
+	}
+}
+
+func TestWriteTasksCSVKeepsMultilineDescriptions(t *testing.T) {
+	task := domain.NewTask("Plan migration", "Steps:\n1. Back up, \"then\" migrate\n2. Verify")
+	service := NewService(&listTaskRepository{tasks: []*domain.Task{task}}, nil, nil)
+
+	var buf bytes.Buffer
+	if err := service.WriteTasksCSV(context.Background(), domain.TaskFilter{}, DefaultExportOptions(), &buf); err != nil {
+		t.Fatalf("WriteTasksCSV failed: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read the CSV back: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected a header and one task row, got %d records", len(records))
+	}
+	if records[1][2] != task.Description {
+		t.Errorf("Expected the description to survive intact, got %q", records[1][2])
+	}
+}
+
+func TestWriteTimeEntriesCSVExcelOptions(t *testing.T) {
+	start := time.Date(2025, 10, 2, 15, 4, 5, 0, time.UTC)
+	entry := domain.NewTimeEntry("task-1", "", "Café planning")
+	entry.StartTime = start
+	service := NewService(nil, nil, &listTimeEntryRepository{entries: []*domain.TimeEntry{entry}})
+
+	var buf bytes.Buffer
+	if err := service.WriteTimeEntriesCSV(context.Background(), domain.TimeEntryFilter{}, ExcelExportOptions(), &buf); err != nil {
+		t.Fatalf("WriteTimeEntriesCSV failed: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "\uFEFFID,") {
+		t.Errorf("Expected a BOM before the header, got %q", out[:10])
+	}
+	if !strings.Contains(out, "2025-10-02T15:04:05Z") {
+		t.Errorf("Expected an RFC 3339 start time, got %q", out)
+	}
+}
+
+func TestWriteTimeEntriesCSVDefaultsToSecondsPrecision(t *testing.T) {
+	entry := domain.NewTimeEntry("task-1", "", "Planning")
+	entry.StartTime = time.Date(2025, 10, 2, 15, 4, 5, 0, time.UTC)
+	service := NewService(nil, nil, &listTimeEntryRepository{entries: []*domain.TimeEntry{entry}})
+
+	var buf bytes.Buffer
+	if err := service.WriteTimeEntriesCSV(context.Background(), domain.TimeEntryFilter{}, DefaultExportOptions(), &buf); err != nil {
+		t.Fatalf("WriteTimeEntriesCSV failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "2025-10-02 15:04:05") {
+		t.Errorf("Expected the start time with seconds, got %q", buf.String())
+	}
+}
+
+func TestWriteTimeEntriesCSVIncludesCategory(t *testing.T) {
+	entry := domain.NewCategoryTimeEntry("meetings", "Standup")
+	service := NewService(nil, nil, &listTimeEntryRepository{entries: []*domain.TimeEntry{entry}})
+
+	var buf bytes.Buffer
+	if err := service.WriteTimeEntriesCSV(context.Background(), domain.TimeEntryFilter{}, DefaultExportOptions(), &buf); err != nil {
+		t.Fatalf("WriteTimeEntriesCSV failed: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+	if len(records) != 2 || records[0][2] != "Category" {
+		t.Fatalf("Expected a header with a Category column and one row, got %v", records)
+	}
+	if records[1][1] != "" || records[1][2] != "meetings" {
+		t.Errorf("Expected the entry's category in place of a task, got %v", records[1])
+	}
+}
@@ -3,19 +3,38 @@ package project
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/adriannajera/project-manager-cli/internal/domain"
 )
 
+// DefaultColorPalette is used for new projects when no palette is configured
+var DefaultColorPalette = []string{
+	"#3b82f6", // blue
+	"#10b981", // green
+	"#f59e0b", // amber
+	"#ef4444", // red
+	"#8b5cf6", // violet
+	"#ec4899", // pink
+	"#14b8a6", // teal
+	"#f97316", // orange
+}
+
 // Service provides project management functionality
 type Service struct {
 	projectRepo domain.ProjectRepository
+	palette     []string
 }
 
-// NewService creates a new project service
-func NewService(projectRepo domain.ProjectRepository) *Service {
+// NewService creates a new project service. New projects without a color
+// cycle through palette, or DefaultColorPalette when it is empty.
+func NewService(projectRepo domain.ProjectRepository, palette []string) *Service {
+	if len(palette) == 0 {
+		palette = DefaultColorPalette
+	}
 	return &Service{
 		projectRepo: projectRepo,
+		palette:     palette,
 	}
 }
 
@@ -57,6 +76,8 @@ func (s *Service) CreateProject(ctx context.Context, input CreateProjectInput) (
 	project := domain.NewProject(input.Name, input.Description)
 	if input.Color != "" {
 		project.Color = input.Color
+	} else {
+		project.Color = s.nextColor(ctx)
 	}
 
 	if err := s.projectRepo.Create(ctx, project); err != nil {
@@ -66,6 +87,31 @@ func (s *Service) CreateProject(ctx context.Context, input CreateProjectInput) (
 	return project, nil
 }
 
+// nextColor returns the palette color after the one used by the most recently
+// created project, so consecutive projects get different colors
+func (s *Service) nextColor(ctx context.Context) string {
+	projects, err := s.projectRepo.List(ctx, domain.ProjectFilter{})
+	if err != nil || len(projects) == 0 {
+		return s.palette[0]
+	}
+
+	latest := projects[0]
+	for _, project := range projects[1:] {
+		if project.CreatedAt.After(latest.CreatedAt) {
+			latest = project
+		}
+	}
+
+	for i, color := range s.palette {
+		if strings.EqualFold(color, latest.Color) {
+			return s.palette[(i+1)%len(s.palette)]
+		}
+	}
+
+	// The latest color isn't in the palette; fall back to cycling by count
+	return s.palette[len(projects)%len(s.palette)]
+}
+
 // GetProject retrieves a project by ID
 func (s *Service) GetProject(ctx context.Context, id string) (*domain.Project, error) {
 	if id == "" {
@@ -0,0 +1,80 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+// LogKind says what a task log entry records
+type LogKind string
+
+const (
+	LogCreated     LogKind = "created"
+	LogCompleted   LogKind = "completed"
+	LogComment     LogKind = "comment"
+	LogTime        LogKind = "time"
+	LogNoteCreated LogKind = "note_created"
+	LogNoteUpdated LogKind = "note_updated"
+)
+
+// LogEntry is one thing that happened to a task
+type LogEntry struct {
+	At   time.Time
+	Kind LogKind
+	// Text is the comment body, or the time entry's description when it
+	// differs from the task title
+	Text string
+	// Entry is the time entry a LogTime entry records
+	Entry *domain.TimeEntry
+}
+
+// TaskLog returns everything recorded against a task, oldest first: its
+// creation and completion, comments, time entries, and linked note. Status
+// changes other than completion are not recorded, so they are not listed.
+func (s *Service) TaskLog(ctx context.Context, taskID string) (*domain.Task, []LogEntry, error) {
+	t, err := s.taskService.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	log := []LogEntry{{At: t.CreatedAt, Kind: LogCreated}}
+	if t.CompletedAt != nil {
+		log = append(log, LogEntry{At: *t.CompletedAt, Kind: LogCompleted})
+	}
+	if t.NoteCreatedAt != nil {
+		log = append(log, LogEntry{At: *t.NoteCreatedAt, Kind: LogNoteCreated})
+	}
+	// A note only keeps its latest change
+	if t.NoteUpdatedAt != nil && (t.NoteCreatedAt == nil || t.NoteUpdatedAt.After(*t.NoteCreatedAt)) {
+		log = append(log, LogEntry{At: *t.NoteUpdatedAt, Kind: LogNoteUpdated})
+	}
+
+	comments, err := s.taskService.ListComments(ctx, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, c := range comments {
+		log = append(log, LogEntry{At: c.CreatedAt, Kind: LogComment, Text: c.Body})
+	}
+
+	entries, err := s.timeService.GetTimeEntriesByTask(ctx, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, entry := range entries {
+		text := ""
+		if entry.Description != t.Title {
+			text = entry.Description
+		}
+		log = append(log, LogEntry{At: entry.StartTime, Kind: LogTime, Text: text, Entry: entry})
+	}
+
+	sort.SliceStable(log, func(i, j int) bool {
+		return log[i].At.Before(log[j].At)
+	})
+	return t, log, nil
+}
@@ -0,0 +1,105 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+	"github.com/adriannajera/project-manager-cli/internal/service/task"
+	timeService "github.com/adriannajera/project-manager-cli/internal/service/time"
+)
+
+// Service builds retrospective reports from the task and time services
+type Service struct {
+	taskService *task.Service
+	timeService *timeService.Service
+}
+
+// NewService creates a new review service
+func NewService(taskService *task.Service, timeService *timeService.Service) *Service {
+	return &Service{
+		taskService: taskService,
+		timeService: timeService,
+	}
+}
+
+// Review gathers what happened over a period for a retrospective
+type Review struct {
+	Start time.Time
+	End   time.Time
+
+	// Completed holds the tasks completed in the period, in the order they
+	// were completed
+	Completed []*domain.Task
+	// Overdue holds the open tasks past their due date now, soonest due first
+	Overdue []*domain.Task
+	// Created holds the tasks created in the period, oldest first
+	Created []*domain.Task
+
+	// Tracked holds the completed time tracked in the period by project,
+	// most time first
+	Tracked      []timeService.ProjectTimeReport
+	TotalTracked time.Duration
+	// Running is the timer running in the period, which is not counted
+	Running *domain.TimeEntry
+}
+
+// GenerateReview builds the review of the period in the configured time zone
+func (s *Service) GenerateReview(ctx context.Context, period timeService.ReportPeriod) (*Review, error) {
+	start, end := s.timeService.ReportRange(period)
+	review := &Review{Start: start, End: end}
+
+	done, err := s.taskService.ListTasks(ctx, task.ListOptions{Status: []domain.TaskStatus{domain.StatusDone}})
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range done {
+		if t.CompletedAt != nil && !t.CompletedAt.Before(start) && t.CompletedAt.Before(end) {
+			review.Completed = append(review.Completed, t)
+		}
+	}
+	sort.SliceStable(review.Completed, func(i, j int) bool {
+		return review.Completed[i].CompletedAt.Before(*review.Completed[j].CompletedAt)
+	})
+
+	if review.Overdue, err = s.taskService.GetOverdueTasks(ctx); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(review.Overdue, func(i, j int) bool {
+		return review.Overdue[i].DueDate.Before(*review.Overdue[j].DueDate)
+	})
+
+	created, err := s.taskService.ListTasks(ctx, task.ListOptions{CreatedAfter: &start, CreatedBefore: &end})
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range created {
+		// CreatedBefore is inclusive, while the period ends just before end
+		if t.CreatedAt.Before(end) {
+			review.Created = append(review.Created, t)
+		}
+	}
+	sort.SliceStable(review.Created, func(i, j int) bool {
+		return review.Created[i].CreatedAt.Before(review.Created[j].CreatedAt)
+	})
+
+	report, err := s.timeService.GenerateSummaryReport(ctx, start, end, timeService.ReportOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate time report: %w", err)
+	}
+	for _, projectReport := range report.ByProject {
+		review.Tracked = append(review.Tracked, projectReport)
+	}
+	sort.Slice(review.Tracked, func(i, j int) bool {
+		if review.Tracked[i].TotalDuration != review.Tracked[j].TotalDuration {
+			return review.Tracked[i].TotalDuration > review.Tracked[j].TotalDuration
+		}
+		return review.Tracked[i].ProjectName < review.Tracked[j].ProjectName
+	})
+	review.TotalTracked = report.TotalDuration
+	review.Running = report.Running
+
+	return review, nil
+}
@@ -0,0 +1,121 @@
+package review
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+	"github.com/adriannajera/project-manager-cli/internal/repository/memory"
+	"github.com/adriannajera/project-manager-cli/internal/service/task"
+	timeService "github.com/adriannajera/project-manager-cli/internal/service/time"
+)
+
+func TestGenerateReviewCoversTheWeek(t *testing.T) {
+	store := memory.NewStore()
+	taskRepo := memory.NewTaskRepository(store)
+	projectRepo := memory.NewProjectRepository(store)
+	entryRepo := memory.NewTimeEntryRepository(store)
+	ctx := context.Background()
+
+	timeSvc := timeService.NewService(entryRepo, taskRepo, store, timeService.Options{})
+	timeSvc.SetProjectRepository(projectRepo)
+	service := NewService(task.NewService(taskRepo, nil), timeSvc)
+
+	start, _ := timeSvc.ReportRange(timeService.ReportWeek)
+	lastWeek := start.AddDate(0, 0, -3)
+
+	website := domain.NewProject("Website", "")
+	projectRepo.Create(ctx, website)
+
+	create := func(title string, created time.Time, mutate func(*domain.Task)) *domain.Task {
+		t.Helper()
+		task := domain.NewTask(title, "")
+		task.CreatedAt = created
+		if mutate != nil {
+			mutate(task)
+		}
+		if err := taskRepo.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		return task
+	}
+	complete := func(at time.Time) func(*domain.Task) {
+		return func(task *domain.Task) {
+			task.Status = domain.StatusDone
+			task.CompletedAt = &at
+		}
+	}
+
+	create("Done later", lastWeek, complete(start.Add(2*time.Hour)))
+	create("Done first", start.Add(time.Minute), complete(start.Add(time.Hour)))
+	create("Done last week", lastWeek, complete(lastWeek))
+	create("Late", lastWeek, func(task *domain.Task) { task.DueDate = &lastWeek })
+
+	entry := domain.NewTimeEntry("", website.ID, "Design")
+	entry.StartTime = start.Add(time.Hour)
+	entry.StopAt(entry.StartTime.Add(90 * time.Minute))
+	entryRepo.Create(ctx, entry)
+
+	r, err := service.GenerateReview(ctx, timeService.ReportWeek)
+	if err != nil {
+		t.Fatalf("Failed to generate review: %v", err)
+	}
+
+	if len(r.Completed) != 2 || r.Completed[0].Title != "Done first" || r.Completed[1].Title != "Done later" {
+		t.Errorf("Expected this week's completed tasks in completion order, got %d", len(r.Completed))
+	}
+	if len(r.Overdue) != 1 || r.Overdue[0].Title != "Late" {
+		t.Errorf("Expected the late task to be overdue, got %d tasks", len(r.Overdue))
+	}
+	if len(r.Created) != 1 || r.Created[0].Title != "Done first" {
+		t.Errorf("Expected only the task created this week, got %d", len(r.Created))
+	}
+	if r.TotalTracked != 90*time.Minute || len(r.Tracked) != 1 || r.Tracked[0].ProjectName != "Website" {
+		t.Errorf("Expected 90m tracked on Website, got %s over %d projects", r.TotalTracked, len(r.Tracked))
+	}
+}
+
+func TestTaskLogIsChronological(t *testing.T) {
+	store := memory.NewStore()
+	taskRepo := memory.NewTaskRepository(store)
+	entryRepo := memory.NewTimeEntryRepository(store)
+	ctx := context.Background()
+
+	timeSvc := timeService.NewService(entryRepo, taskRepo, store, timeService.Options{})
+	service := NewService(task.NewService(taskRepo, nil), timeSvc)
+
+	created := time.Now().Add(-3 * time.Hour)
+	completed := time.Now().Add(-time.Hour)
+	fix := domain.NewTask("Fix login", "")
+	fix.CreatedAt = created
+	fix.Status = domain.StatusDone
+	fix.CompletedAt = &completed
+	taskRepo.Create(ctx, fix)
+
+	entry := domain.NewTimeEntry(fix.ID, "", "Reproduce the bug")
+	entry.StartTime = created.Add(30 * time.Minute)
+	entry.StopAt(entry.StartTime.Add(45 * time.Minute))
+	entryRepo.Create(ctx, entry)
+
+	comment := domain.NewComment(fix.ID, "Shipped")
+	taskRepo.AddComment(ctx, comment)
+
+	_, log, err := service.TaskLog(ctx, fix.ID)
+	if err != nil {
+		t.Fatalf("Failed to build task log: %v", err)
+	}
+
+	want := []LogKind{LogCreated, LogTime, LogCompleted, LogComment}
+	if len(log) != len(want) {
+		t.Fatalf("Expected %d log entries, got %d", len(want), len(log))
+	}
+	for i, kind := range want {
+		if log[i].Kind != kind {
+			t.Errorf("Entry %d: expected %s, got %s", i, kind, log[i].Kind)
+		}
+	}
+	if log[1].Text != "Reproduce the bug" || log[1].Entry.Duration != 45*time.Minute {
+		t.Errorf("Expected the time entry's description and duration, got %q %s", log[1].Text, log[1].Entry.Duration)
+	}
+}
@@ -0,0 +1,200 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+	timeService "github.com/adriannajera/project-manager-cli/internal/service/time"
+)
+
+// Service provides productivity metrics across tasks and time entries
+type Service struct {
+	taskRepo      domain.TaskRepository
+	projectRepo   domain.ProjectRepository
+	timeEntryRepo domain.TimeEntryRepository
+	timeOptions   timeService.Options
+}
+
+// NewService creates a new stats service
+func NewService(taskRepo domain.TaskRepository, projectRepo domain.ProjectRepository, timeEntryRepo domain.TimeEntryRepository, timeOptions timeService.Options) *Service {
+	return &Service{
+		taskRepo:      taskRepo,
+		projectRepo:   projectRepo,
+		timeEntryRepo: timeEntryRepo,
+		timeOptions:   timeOptions,
+	}
+}
+
+// Report summarizes task throughput and tracked time
+type Report struct {
+	GeneratedAt            time.Time       `json:"generated_at"`
+	WeekStart              time.Time       `json:"week_start"`
+	TotalTasks             int             `json:"total_tasks"`
+	CompletedTasks         int             `json:"completed_tasks"`
+	CompletedThisWeek      int             `json:"completed_this_week"`
+	AverageCompletionTime  time.Duration   `json:"-"`
+	AverageCompletionHours float64         `json:"average_completion_hours"`
+	TrackedTime            time.Duration   `json:"-"`
+	TrackedHours           float64         `json:"tracked_hours"`
+	TrackedThisWeek        time.Duration   `json:"-"`
+	TrackedHoursThisWeek   float64         `json:"tracked_hours_this_week"`
+	Projects               []ProjectReport `json:"projects"`
+}
+
+// ProjectReport represents task completion for a single project
+type ProjectReport struct {
+	ProjectID      string  `json:"project_id"`
+	ProjectName    string  `json:"project_name"`
+	TotalTasks     int     `json:"total_tasks"`
+	CompletedTasks int     `json:"completed_tasks"`
+	CompletionRate float64 `json:"completion_rate"`
+}
+
+// ProjectHealth summarizes the state of a single project
+type ProjectHealth struct {
+	Project        *domain.Project           `json:"project"`
+	TotalTasks     int                       `json:"total_tasks"`
+	StatusCounts   map[domain.TaskStatus]int `json:"status_counts"`
+	Overdue        int                       `json:"overdue"`
+	TrackedTime    time.Duration             `json:"-"`
+	TrackedHours   float64                   `json:"tracked_hours"`
+	OldestOpenTask *domain.Task              `json:"oldest_open_task,omitempty"`
+}
+
+// GenerateReport builds a report from all tasks, projects, and time entries
+func (s *Service) GenerateReport(ctx context.Context) (*Report, error) {
+	tasks, err := s.taskRepo.List(ctx, domain.TaskFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	projects, err := s.projectRepo.List(ctx, domain.ProjectFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	entries, err := s.timeEntryRepo.List(ctx, domain.TimeEntryFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list time entries: %w", err)
+	}
+
+	return buildReport(tasks, projects, entries, s.timeOptions.Now(), s.timeOptions.WeekStart), nil
+}
+
+// GenerateProjectHealth builds a health snapshot for the project with projectID
+func (s *Service) GenerateProjectHealth(ctx context.Context, projectID string) (*ProjectHealth, error) {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	tasks, err := s.taskRepo.GetByProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project tasks: %w", err)
+	}
+
+	entries, err := s.timeEntryRepo.GetByProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project time entries: %w", err)
+	}
+
+	health := &ProjectHealth{
+		Project:      project,
+		TotalTasks:   len(tasks),
+		StatusCounts: make(map[domain.TaskStatus]int),
+	}
+
+	for _, task := range tasks {
+		health.StatusCounts[task.Status]++
+		if task.IsOverdue() {
+			health.Overdue++
+		}
+		if task.Status == domain.StatusDone {
+			continue
+		}
+		if health.OldestOpenTask == nil || task.CreatedAt.Before(health.OldestOpenTask.CreatedAt) {
+			health.OldestOpenTask = task
+		}
+	}
+
+	for _, entry := range entries {
+		health.TrackedTime += entry.GetDuration()
+	}
+	health.TrackedHours = health.TrackedTime.Hours()
+
+	return health, nil
+}
+
+// buildReport computes the report as of now
+func buildReport(tasks []*domain.Task, projects []*domain.Project, entries []*domain.TimeEntry, now time.Time, weekStart timeService.WeekStart) *Report {
+	report := &Report{
+		GeneratedAt: now,
+		WeekStart:   timeService.StartOfWeek(now, weekStart),
+		TotalTasks:  len(tasks),
+	}
+
+	projectNames := make(map[string]string, len(projects))
+	for _, project := range projects {
+		projectNames[project.ID] = project.Name
+	}
+
+	byProject := make(map[string]*ProjectReport)
+	var completionTotal time.Duration
+
+	for _, task := range tasks {
+		projectReport, exists := byProject[task.ProjectID]
+		if !exists {
+			name := projectNames[task.ProjectID]
+			if task.ProjectID == "" {
+				name = "(no project)"
+			} else if name == "" {
+				name = task.ProjectID
+			}
+			projectReport = &ProjectReport{ProjectID: task.ProjectID, ProjectName: name}
+			byProject[task.ProjectID] = projectReport
+		}
+		projectReport.TotalTasks++
+
+		if task.Status != domain.StatusDone || task.CompletedAt == nil {
+			continue
+		}
+
+		report.CompletedTasks++
+		projectReport.CompletedTasks++
+		completionTotal += task.CompletedAt.Sub(task.CreatedAt)
+
+		if !task.CompletedAt.Before(report.WeekStart) {
+			report.CompletedThisWeek++
+		}
+	}
+
+	if report.CompletedTasks > 0 {
+		report.AverageCompletionTime = completionTotal / time.Duration(report.CompletedTasks)
+	}
+
+	for _, entry := range entries {
+		duration := entry.GetDuration()
+		report.TrackedTime += duration
+		if !entry.StartTime.Before(report.WeekStart) {
+			report.TrackedThisWeek += duration
+		}
+	}
+
+	report.AverageCompletionHours = report.AverageCompletionTime.Hours()
+	report.TrackedHours = report.TrackedTime.Hours()
+	report.TrackedHoursThisWeek = report.TrackedThisWeek.Hours()
+
+	report.Projects = make([]ProjectReport, 0, len(byProject))
+	for _, projectReport := range byProject {
+		projectReport.CompletionRate = float64(projectReport.CompletedTasks) / float64(projectReport.TotalTasks)
+		report.Projects = append(report.Projects, *projectReport)
+	}
+	sort.Slice(report.Projects, func(i, j int) bool {
+		return report.Projects[i].ProjectName < report.Projects[j].ProjectName
+	})
+
+	return report
+}
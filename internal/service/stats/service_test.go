@@ -0,0 +1,145 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+	"github.com/adriannajera/project-manager-cli/internal/repository/memory"
+	timeService "github.com/adriannajera/project-manager-cli/internal/service/time"
+)
+
+func TestBuildReportCountsTheWeekAndAveragesCompletion(t *testing.T) {
+	// A Wednesday, so the week started on Monday the 13th
+	now := time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	website := domain.NewProject("Website", "")
+	task := func(projectID string, created time.Time, completed *time.Time) *domain.Task {
+		task := domain.NewTask("Task", "")
+		task.ProjectID = projectID
+		task.CreatedAt = created
+		if completed != nil {
+			task.Status = domain.StatusDone
+			task.CompletedAt = completed
+		}
+		return task
+	}
+	thisWeek := time.Date(2025, 10, 14, 9, 0, 0, 0, time.UTC)
+	lastWeek := time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)
+	doneWithoutDate := task("", now, nil)
+	doneWithoutDate.Status = domain.StatusDone
+	tasks := []*domain.Task{
+		task(website.ID, thisWeek.Add(-13*day), &thisWeek),
+		task(website.ID, lastWeek.Add(-5*day), &lastWeek),
+		task("", now.Add(-day), nil),
+		doneWithoutDate,
+	}
+
+	entry := func(start time.Time, length time.Duration) *domain.TimeEntry {
+		entry := domain.NewTimeEntry("", website.ID, "")
+		entry.StartTime = start
+		entry.StopAt(start.Add(length))
+		return entry
+	}
+	entries := []*domain.TimeEntry{entry(thisWeek, time.Hour), entry(lastWeek, 2*time.Hour)}
+
+	report := buildReport(tasks, []*domain.Project{website}, entries, now, timeService.WeekStartMonday)
+
+	if want := time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC); !report.WeekStart.Equal(want) {
+		t.Errorf("Expected the week to start on %s, got %s", want, report.WeekStart)
+	}
+	if report.TotalTasks != 4 || report.CompletedTasks != 2 {
+		t.Errorf("Expected 2 of 4 tasks completed, got %d of %d", report.CompletedTasks, report.TotalTasks)
+	}
+	if report.CompletedThisWeek != 1 {
+		t.Errorf("Expected 1 task completed this week, got %d", report.CompletedThisWeek)
+	}
+	if report.AverageCompletionTime != 9*day || report.AverageCompletionHours != 216 {
+		t.Errorf("Expected an average of 9 days between 13 and 5, got %s", report.AverageCompletionTime)
+	}
+	if report.TrackedTime != 3*time.Hour || report.TrackedThisWeek != time.Hour {
+		t.Errorf("Expected 3h tracked with 1h this week, got %s and %s", report.TrackedTime, report.TrackedThisWeek)
+	}
+
+	if len(report.Projects) != 2 {
+		t.Fatalf("Expected a row per project and one without, got %+v", report.Projects)
+	}
+	if loose := report.Projects[0]; loose.ProjectName != "(no project)" || loose.TotalTasks != 2 || loose.CompletionRate != 0 {
+		t.Errorf("Expected 2 open tasks without a project, got %+v", loose)
+	}
+	if web := report.Projects[1]; web.ProjectName != "Website" || web.CompletedTasks != 2 || web.CompletionRate != 1 {
+		t.Errorf("Expected Website fully completed, got %+v", web)
+	}
+}
+
+func TestGenerateProjectHealth(t *testing.T) {
+	store := memory.NewStore()
+	taskRepo := memory.NewTaskRepository(store)
+	projectRepo := memory.NewProjectRepository(store)
+	entryRepo := memory.NewTimeEntryRepository(store)
+	service := NewService(taskRepo, projectRepo, entryRepo, timeService.Options{})
+	ctx := context.Background()
+
+	website := domain.NewProject("Website", "")
+	other := domain.NewProject("Other", "")
+	projectRepo.Create(ctx, website)
+	projectRepo.Create(ctx, other)
+
+	now := time.Now()
+	yesterday := now.AddDate(0, 0, -1)
+	create := func(projectID string, status domain.TaskStatus, created time.Time, due *time.Time) *domain.Task {
+		t.Helper()
+		task := domain.NewTask("Task", "")
+		task.ProjectID = projectID
+		task.Status = status
+		task.CreatedAt = created
+		task.DueDate = due
+		if err := taskRepo.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		return task
+	}
+	create(website.ID, domain.StatusDone, now.AddDate(0, 0, -30), &yesterday)
+	oldest := create(website.ID, domain.StatusTodo, now.AddDate(0, 0, -10), nil)
+	create(website.ID, domain.StatusDoing, now.AddDate(0, 0, -2), &yesterday)
+	create(website.ID, domain.StatusTodo, now.AddDate(0, 0, -1), nil)
+	create(other.ID, domain.StatusTodo, now.AddDate(0, 0, -60), &yesterday)
+
+	for _, projectID := range []string{website.ID, website.ID, other.ID} {
+		entry := domain.NewTimeEntry("", projectID, "")
+		entry.StartTime = now.Add(-3 * time.Hour)
+		entry.StopAt(entry.StartTime.Add(45 * time.Minute))
+		entryRepo.Create(ctx, entry)
+	}
+
+	health, err := service.GenerateProjectHealth(ctx, website.ID)
+	if err != nil {
+		t.Fatalf("GenerateProjectHealth failed: %v", err)
+	}
+
+	if health.Project.ID != website.ID || health.TotalTasks != 4 {
+		t.Errorf("Expected the 4 Website tasks, got %d", health.TotalTasks)
+	}
+	want := map[domain.TaskStatus]int{domain.StatusTodo: 2, domain.StatusDoing: 1, domain.StatusDone: 1}
+	for status, count := range want {
+		if health.StatusCounts[status] != count {
+			t.Errorf("Expected %d %s tasks, got %d", count, status, health.StatusCounts[status])
+		}
+	}
+	if health.Overdue != 1 {
+		t.Errorf("Expected only the open late task to be overdue, got %d", health.Overdue)
+	}
+	if health.TrackedTime != 90*time.Minute || health.TrackedHours != 1.5 {
+		t.Errorf("Expected 1.5h tracked on the project, got %s", health.TrackedTime)
+	}
+	if health.OldestOpenTask == nil || health.OldestOpenTask.ID != oldest.ID {
+		t.Errorf("Expected the oldest open task, not the older done one, got %+v", health.OldestOpenTask)
+	}
+
+	if _, err := service.GenerateProjectHealth(ctx, "no-such-project"); !errors.Is(err, domain.ErrProjectNotFound) {
+		t.Errorf("Expected ErrProjectNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+// agingKey is the metadata key recording how many levels aging has already
+// raised a task for its current due date, as "levels@due"
+const agingKey = "priority_aging"
+
+// AgedTask is a task whose priority AgeOverdueTasks raised
+type AgedTask struct {
+	Task    *domain.Task
+	From    domain.Priority
+	To      domain.Priority
+	Overdue time.Duration
+}
+
+// AgeOverdueTasks raises the priority of each open task by one level for
+// every full interval it has been overdue at now, up to critical. Levels
+// already applied for a task's due date are not applied again, so lowering
+// the priority by hand sticks until the next interval passes, and moving the
+// due date starts over. Each change is recorded as a comment on the task.
+// With dryRun, the changes are worked out but not saved.
+func (s *Service) AgeOverdueTasks(ctx context.Context, interval time.Duration, now time.Time, dryRun bool) ([]AgedTask, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("priority aging interval must be positive")
+	}
+
+	tasks, err := s.ListTasks(ctx, ListOptions{
+		Status:                  []domain.TaskStatus{domain.StatusBacklog, domain.StatusTodo, domain.StatusDoing, domain.StatusBlocked},
+		DueBefore:               &now,
+		ExcludeArchivedProjects: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var aged []AgedTask
+	for _, task := range tasks {
+		if task.Status == domain.StatusDone || task.DueDate == nil || !task.DueDate.Before(now) {
+			continue
+		}
+		if task.Priority >= domain.PriorityCritical {
+			continue
+		}
+
+		overdue := now.Sub(*task.DueDate)
+		levels := int(overdue / interval)
+		applied := appliedAgingLevels(task)
+		if levels <= applied {
+			continue
+		}
+
+		from := task.Priority
+		to := from + domain.Priority(levels-applied)
+		if to > domain.PriorityCritical {
+			to = domain.PriorityCritical
+		}
+		aged = append(aged, AgedTask{Task: task, From: from, To: to, Overdue: overdue})
+
+		if dryRun {
+			continue
+		}
+
+		task.Priority = to
+		task.SetMeta(agingKey, strconv.Itoa(levels)+"@"+agingDue(task))
+		if err := s.taskRepo.Update(ctx, task); err != nil {
+			return nil, fmt.Errorf("failed to update task: %w", err)
+		}
+
+		days := int(overdue.Hours() / 24)
+		body := fmt.Sprintf("Priority raised from %s to %s after %d days overdue", from, to, days)
+		if err := s.taskRepo.AddComment(ctx, domain.NewComment(task.ID, body)); err != nil {
+			return nil, fmt.Errorf("failed to add comment: %w", err)
+		}
+	}
+
+	sort.Slice(aged, func(i, j int) bool {
+		return aged[i].Task.DueDate.Before(*aged[j].Task.DueDate)
+	})
+	return aged, nil
+}
+
+// appliedAgingLevels returns how many levels aging has raised the task for
+// its current due date
+func appliedAgingLevels(task *domain.Task) int {
+	value, _ := task.Metadata[agingKey].(string)
+	levels, due, ok := strings.Cut(value, "@")
+	if !ok || due != agingDue(task) {
+		return 0
+	}
+	applied, err := strconv.Atoi(levels)
+	if err != nil {
+		return 0
+	}
+	return applied
+}
+
+// agingDue identifies the task's due date in the aging record
+func agingDue(task *domain.Task) string {
+	return task.DueDate.UTC().Format(time.RFC3339)
+}
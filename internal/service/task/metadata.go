@@ -0,0 +1,112 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+// SetMetadata stores value under key in a task's metadata, replacing any
+// value already there
+func (s *Service) SetMetadata(ctx context.Context, taskID, key, value string) error {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return domain.ErrEmptyMetadataKey
+	}
+
+	task, err := s.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	task.SetMeta(key, value)
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+
+	return nil
+}
+
+// GetMetadata returns the value stored under key in a task's metadata
+func (s *Service) GetMetadata(ctx context.Context, taskID, key string) (interface{}, error) {
+	metadata, err := s.ListMetadata(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := metadata[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrMetadataNotFound, key)
+	}
+
+	return value, nil
+}
+
+// ListMetadata returns all of a task's metadata, including keys written by
+// integrations such as git_branch
+func (s *Service) ListMetadata(ctx context.Context, taskID string) (map[string]interface{}, error) {
+	task, err := s.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if task.Metadata == nil {
+		return map[string]interface{}{}, nil
+	}
+	return task.Metadata, nil
+}
+
+// UnsetMetadata removes key from a task's metadata
+func (s *Service) UnsetMetadata(ctx context.Context, taskID, key string) error {
+	task, err := s.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if !task.UnsetMeta(key) {
+		return fmt.Errorf("%w: %s", domain.ErrMetadataNotFound, key)
+	}
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+
+	return nil
+}
+
+// AttachToTask adds a URL or file path to a task's attachments, reporting
+// false when it was already attached
+func (s *Service) AttachToTask(ctx context.Context, taskID, ref string) (bool, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return false, domain.ErrEmptyAttachment
+	}
+
+	task, err := s.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if !task.AddAttachment(ref) {
+		return false, nil
+	}
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return false, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	return true, nil
+}
+
+// ListAttachments returns the URLs and file paths attached to a task
+func (s *Service) ListAttachments(ctx context.Context, taskID string) ([]string, error) {
+	task, err := s.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	return task.Attachments(), nil
+}
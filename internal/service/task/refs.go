@@ -0,0 +1,101 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+// IDScheme decides how new tasks are identified to users
+type IDScheme string
+
+const (
+	// IDSchemeUUID shows tasks by the prefix of their UUID
+	IDSchemeUUID IDScheme = "uuid"
+	// IDSchemeSequential also gives each new task a reference such as
+	// "WEB-42", numbered per project
+	IDSchemeSequential IDScheme = "sequential"
+)
+
+// ParseIDScheme validates an ID scheme name; an empty name means uuid
+func ParseIDScheme(name string) (IDScheme, error) {
+	switch IDScheme(strings.ToLower(name)) {
+	case "", IDSchemeUUID:
+		return IDSchemeUUID, nil
+	case IDSchemeSequential:
+		return IDSchemeSequential, nil
+	default:
+		return IDSchemeUUID, fmt.Errorf("unknown task ID scheme: %s (must be uuid or sequential)", name)
+	}
+}
+
+// defaultRefPrefix numbers tasks that belong to no project
+const defaultRefPrefix = "TASK"
+
+// maxRefPrefix is the longest prefix taken from a project name
+const maxRefPrefix = 8
+
+// refPattern matches input that could be a sequential reference
+var refPattern = regexp.MustCompile(`^[A-Za-z0-9]+-[0-9]+$`)
+
+// EnableSequentialIDs gives each task created from now on a reference such
+// as "WEB-42", using projectRepo to name the prefix after the task's project.
+// Tasks keep their UUIDs, and either form resolves through ResolveID.
+func (s *Service) EnableSequentialIDs(projectRepo domain.ProjectRepository) {
+	s.projectRepo = projectRepo
+	s.sequentialIDs = true
+}
+
+// AssignRef gives task the next reference for its project when sequential
+// IDs are enabled. Tasks that already have a reference keep it.
+func (s *Service) AssignRef(ctx context.Context, task *domain.Task) error {
+	if !s.sequentialIDs || task.Ref != "" {
+		return nil
+	}
+
+	prefix := defaultRefPrefix
+	if task.ProjectID != "" {
+		project, err := s.projectRepo.GetByID(ctx, task.ProjectID)
+		if err != nil {
+			return fmt.Errorf("failed to get project: %w", err)
+		}
+		prefix = RefPrefix(project.Name)
+	}
+
+	number, err := s.taskRepo.NextTaskNumber(ctx, prefix)
+	if err != nil {
+		return err
+	}
+
+	task.Ref = fmt.Sprintf("%s-%d", prefix, number)
+	return nil
+}
+
+// RefPrefix derives a reference prefix from a project name: the initials of
+// a name of several words, or the whole of a single word, upper-cased and
+// cut to eight characters. Names without letters or digits use "TASK".
+func RefPrefix(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+
+	var prefix string
+	switch len(words) {
+	case 0:
+		return defaultRefPrefix
+	case 1:
+		prefix = words[0]
+	default:
+		for _, word := range words {
+			prefix += word[:1]
+		}
+	}
+
+	if len(prefix) > maxRefPrefix {
+		prefix = prefix[:maxRefPrefix]
+	}
+	return strings.ToUpper(prefix)
+}
@@ -2,7 +2,10 @@ package task
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/olebedev/when"
@@ -16,6 +19,17 @@ type Service struct {
 	taskRepo domain.TaskRepository
 	gitRepo  domain.GitRepository
 	parser   *when.Parser
+	notifier domain.Notifier
+
+	// projectRepo is set when projects complete along with their last task,
+	// or when sequential IDs are named after projects
+	projectRepo          domain.ProjectRepository
+	autoCompleteProjects bool
+	sequentialIDs        bool
+
+	// rejectPastDue refuses due dates before today unless the input allows
+	// them
+	rejectPastDue bool
 }
 
 // NewService creates a new task service
@@ -32,6 +46,32 @@ func NewService(taskRepo domain.TaskRepository, gitRepo domain.GitRepository) *S
 	}
 }
 
+// SetNotifier sets the receiver of task_created and task_completed events
+func (s *Service) SetNotifier(notifier domain.Notifier) {
+	s.notifier = notifier
+}
+
+// EnableProjectAutoCompletion makes completing the last open task of an
+// active project mark the project completed, firing project_completed
+func (s *Service) EnableProjectAutoCompletion(projectRepo domain.ProjectRepository) {
+	s.projectRepo = projectRepo
+	s.autoCompleteProjects = true
+}
+
+// RejectPastDueDates makes CreateTask and UpdateTask return
+// domain.ErrInvalidDueDate for a due date before today, unless the input
+// sets AllowPastDue. This catches typos such as "last friday".
+func (s *Service) RejectPastDueDates() {
+	s.rejectPastDue = true
+}
+
+// notify passes an event to the notifier, if one is set
+func (s *Service) notify(name domain.EventName, task *domain.Task) {
+	if s.notifier != nil {
+		s.notifier.Notify(domain.Event{Name: name, Task: task})
+	}
+}
+
 // CreateTaskInput represents input for creating a task
 type CreateTaskInput struct {
 	Title       string
@@ -43,6 +83,11 @@ type CreateTaskInput struct {
 	Changelist  string
 	Workspace   string
 	DueDate     string // Natural language date
+	Estimate    string // Duration such as "2h" or "1h30m"
+
+	// AllowPastDue accepts a due date before today even when past due
+	// dates are rejected
+	AllowPastDue bool
 }
 
 // UpdateTaskInput represents input for updating a task
@@ -58,6 +103,15 @@ type UpdateTaskInput struct {
 	Changelist  *string
 	Workspace   *string
 	DueDate     *string // Natural language date
+	Estimate    *string // Duration such as "2h"; empty clears the estimate
+
+	// BlockedReason records why a blocked task is blocked. The reason is
+	// dropped once the task leaves the blocked status.
+	BlockedReason *string
+
+	// AllowPastDue accepts a due date before today even when past due
+	// dates are rejected
+	AllowPastDue bool
 }
 
 // ListOptions represents options for listing tasks
@@ -72,6 +126,20 @@ type ListOptions struct {
 	DueAfter  *time.Time
 	Limit     int
 	Offset    int
+
+	// CreatedAfter and CreatedBefore bound the creation time, inclusive
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+
+	// ExcludeArchivedProjects hides tasks that belong to archived projects
+	ExcludeArchivedProjects bool
+
+	// SortBy picks the time tasks are listed by, newest first
+	SortBy domain.TaskSort
+
+	// OverdueFirst moves overdue tasks ahead of the rest, keeping the order
+	// within each group
+	OverdueFirst bool
 }
 
 // CreateTask creates a new task
@@ -80,6 +148,15 @@ func (s *Service) CreateTask(ctx context.Context, input CreateTaskInput) (*domai
 		return nil, domain.ErrEmptyTitle
 	}
 
+	if input.ParentID != nil {
+		if _, err := s.taskRepo.GetByID(ctx, *input.ParentID); err != nil {
+			if errors.Is(err, domain.ErrTaskNotFound) {
+				return nil, fmt.Errorf("%w: %s", domain.ErrParentNotFound, *input.ParentID)
+			}
+			return nil, fmt.Errorf("failed to get parent task: %w", err)
+		}
+	}
+
 	task := domain.NewTask(input.Title, input.Description)
 	task.Priority = input.Priority
 	task.ProjectID = input.ProjectID
@@ -90,11 +167,22 @@ func (s *Service) CreateTask(ctx context.Context, input CreateTaskInput) (*domai
 
 	// Parse due date if provided
 	if input.DueDate != "" {
-		if dueDate, err := s.parseDueDate(input.DueDate); err == nil {
+		if dueDate, err := s.ParseDate(input.DueDate); err == nil {
 			task.DueDate = dueDate
 		} else {
 			return nil, fmt.Errorf("invalid due date format: %w", err)
 		}
+		if err := s.checkDueDate(task.DueDate, input.AllowPastDue); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.Estimate != "" {
+		estimate, err := parseEstimate(input.Estimate)
+		if err != nil {
+			return nil, err
+		}
+		task.Estimate = estimate
 	}
 
 	// Add Git integration if available
@@ -105,13 +193,137 @@ func (s *Service) CreateTask(ctx context.Context, input CreateTaskInput) (*domai
 		}
 	}
 
+	if err := s.AssignRef(ctx, task); err != nil {
+		return nil, err
+	}
+
 	if err := s.taskRepo.Create(ctx, task); err != nil {
 		return nil, fmt.Errorf("failed to create task: %w", err)
 	}
 
+	s.notify(domain.EventTaskCreated, task)
 	return task, nil
 }
 
+// minIDPrefix is the shortest ID prefix ResolveID will match, so a stray
+// character cannot select a task
+const minIDPrefix = 4
+
+// ResolveID returns the full ID of the task whose ID is idOrPrefix or starts
+// with it, or whose sequential reference is idOrPrefix. A prefix matching
+// several tasks fails with ErrAmbiguousID.
+func (s *Service) ResolveID(ctx context.Context, idOrPrefix string) (string, error) {
+	if idOrPrefix == "" {
+		return "", domain.ErrInvalidTaskID
+	}
+
+	if task, err := s.taskRepo.GetByID(ctx, idOrPrefix); err == nil {
+		return task.ID, nil
+	} else if !errors.Is(err, domain.ErrTaskNotFound) {
+		return "", fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if refPattern.MatchString(idOrPrefix) {
+		if task, err := s.taskRepo.GetByRef(ctx, strings.ToUpper(idOrPrefix)); err == nil {
+			return task.ID, nil
+		} else if !errors.Is(err, domain.ErrTaskNotFound) {
+			return "", fmt.Errorf("failed to get task: %w", err)
+		}
+	}
+
+	if len(idOrPrefix) < minIDPrefix {
+		return "", fmt.Errorf("%w: %s", domain.ErrTaskNotFound, idOrPrefix)
+	}
+
+	tasks, err := s.taskRepo.List(ctx, domain.TaskFilter{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	var matches []string
+	for _, task := range tasks {
+		if strings.HasPrefix(task.ID, idOrPrefix) {
+			matches = append(matches, task.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%w: %s", domain.ErrTaskNotFound, idOrPrefix)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%w: %s matches %d tasks, type more characters", domain.ErrAmbiguousID, idOrPrefix, len(matches))
+	}
+}
+
+// CloneTask creates a todo copy of the task with id, titled "<title> (copy)",
+// with the same description, priority, tags, project, changelist, and parent.
+// With withSubtasks the whole subtree is copied beneath the clone.
+func (s *Service) CloneTask(ctx context.Context, id string, withSubtasks bool) (*domain.Task, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidTaskID
+	}
+
+	source, err := s.taskRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	clone, err := s.cloneOne(ctx, source, source.Title+" (copy)", source.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if withSubtasks {
+		visited := map[string]bool{source.ID: true}
+		if err := s.cloneSubtasks(ctx, source.ID, clone.ID, visited); err != nil {
+			return nil, err
+		}
+	}
+
+	return clone, nil
+}
+
+// cloneSubtasks copies the subtasks of sourceID, and theirs, under parentID
+func (s *Service) cloneSubtasks(ctx context.Context, sourceID, parentID string, visited map[string]bool) error {
+	subtasks, err := s.GetSubtasks(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+
+	for _, subtask := range subtasks {
+		if visited[subtask.ID] {
+			continue
+		}
+		visited[subtask.ID] = true
+
+		newParentID := parentID
+		clone, err := s.cloneOne(ctx, subtask, subtask.Title, &newParentID)
+		if err != nil {
+			return err
+		}
+		if err := s.cloneSubtasks(ctx, subtask.ID, clone.ID, visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cloneOne creates a fresh task from source through the normal create path
+func (s *Service) cloneOne(ctx context.Context, source *domain.Task, title string, parentID *string) (*domain.Task, error) {
+	return s.CreateTask(ctx, CreateTaskInput{
+		Title:       title,
+		Description: source.Description,
+		Priority:    source.Priority,
+		ProjectID:   source.ProjectID,
+		ParentID:    parentID,
+		Tags:        append([]string(nil), source.Tags...),
+		Changelist:  source.Changelist,
+	})
+}
+
 // GetTask retrieves a task by ID
 func (s *Service) GetTask(ctx context.Context, id string) (*domain.Task, error) {
 	if id == "" {
@@ -136,6 +348,7 @@ func (s *Service) UpdateTask(ctx context.Context, input UpdateTaskInput) (*domai
 	if err != nil {
 		return nil, fmt.Errorf("failed to get task for update: %w", err)
 	}
+	wasDone := task.Status == domain.StatusDone
 
 	// Update fields if provided
 	if input.Title != nil {
@@ -168,7 +381,14 @@ func (s *Service) UpdateTask(ctx context.Context, input UpdateTaskInput) (*domai
 	}
 
 	if input.ParentID != nil {
-		task.ParentID = input.ParentID
+		if *input.ParentID == "" {
+			task.ParentID = nil
+		} else {
+			if err := s.checkParent(ctx, task.ID, *input.ParentID); err != nil {
+				return nil, err
+			}
+			task.ParentID = input.ParentID
+		}
 	}
 
 	if input.Tags != nil {
@@ -186,22 +406,141 @@ func (s *Service) UpdateTask(ctx context.Context, input UpdateTaskInput) (*domai
 	if input.DueDate != nil {
 		if *input.DueDate == "" {
 			task.DueDate = nil
-		} else if dueDate, err := s.parseDueDate(*input.DueDate); err == nil {
+		} else if dueDate, err := s.ParseDate(*input.DueDate); err == nil {
+			if err := s.checkDueDate(dueDate, input.AllowPastDue); err != nil {
+				return nil, err
+			}
 			task.DueDate = dueDate
 		} else {
 			return nil, fmt.Errorf("invalid due date format: %w", err)
 		}
 	}
 
+	if input.Estimate != nil {
+		if *input.Estimate == "" {
+			task.Estimate = 0
+		} else if estimate, err := parseEstimate(*input.Estimate); err == nil {
+			task.Estimate = estimate
+		} else {
+			return nil, err
+		}
+	}
+
+	if input.BlockedReason != nil {
+		if *input.BlockedReason != "" && task.Status != domain.StatusBlocked {
+			return nil, domain.ErrReasonNotBlocked
+		}
+		task.SetBlockedReason(*input.BlockedReason)
+	} else if task.Status != domain.StatusBlocked {
+		task.SetBlockedReason("")
+	}
+
 	task.UpdatedAt = time.Now()
 
 	if err := s.taskRepo.Update(ctx, task); err != nil {
 		return nil, fmt.Errorf("failed to update task: %w", err)
 	}
 
+	if !wasDone && task.Status == domain.StatusDone {
+		s.notify(domain.EventTaskCompleted, task)
+		if err := s.completeProjectIfDone(ctx, task.ProjectID); err != nil {
+			return nil, err
+		}
+	}
 	return task, nil
 }
 
+// AddTask stores a task built outside CreateTask, such as in the
+// dashboard's form, checking its due date and giving it a reference as
+// CreateTask does
+func (s *Service) AddTask(ctx context.Context, task *domain.Task) error {
+	if task.DueDate != nil {
+		if err := s.checkDueDate(task.DueDate, false); err != nil {
+			return err
+		}
+	}
+	if err := s.AssignRef(ctx, task); err != nil {
+		return err
+	}
+
+	if err := s.taskRepo.Create(ctx, task); err != nil {
+		return fmt.Errorf("failed to create task: %w", err)
+	}
+
+	s.notify(domain.EventTaskCreated, task)
+	return nil
+}
+
+// SaveTask stores a task edited outside UpdateTask, such as in the
+// dashboard's form. A new due date is checked, and completing the task fires
+// task_completed and, when enabled, completes its project, as UpdateTask
+// does.
+func (s *Service) SaveTask(ctx context.Context, task *domain.Task) error {
+	stored, err := s.taskRepo.GetByID(ctx, task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get task for update: %w", err)
+	}
+	wasDone := stored.Status == domain.StatusDone
+
+	dueChanged := task.DueDate != nil && (stored.DueDate == nil || !stored.DueDate.Equal(*task.DueDate))
+	if dueChanged {
+		if err := s.checkDueDate(task.DueDate, false); err != nil {
+			return err
+		}
+	}
+
+	task.UpdatedAt = time.Now()
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+
+	if !wasDone && task.Status == domain.StatusDone {
+		s.notify(domain.EventTaskCompleted, task)
+		if err := s.completeProjectIfDone(ctx, task.ProjectID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// completeProjectIfDone marks the active project with projectID completed
+// once none of its tasks are left open. It does nothing unless project
+// auto-completion is enabled.
+func (s *Service) completeProjectIfDone(ctx context.Context, projectID string) error {
+	if !s.autoCompleteProjects || projectID == "" {
+		return nil
+	}
+
+	tasks, err := s.taskRepo.GetByProject(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get tasks by project: %w", err)
+	}
+	for _, t := range tasks {
+		if t.Status != domain.StatusDone {
+			return nil
+		}
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	// Archived and on-hold projects keep the status they were given
+	if project.Status != domain.ProjectStatusActive {
+		return nil
+	}
+
+	project.Complete()
+	if err := s.projectRepo.Update(ctx, project); err != nil {
+		return fmt.Errorf("failed to complete project: %w", err)
+	}
+
+	if s.notifier != nil {
+		s.notifier.Notify(domain.Event{Name: domain.EventProjectCompleted, Project: project})
+	}
+	return nil
+}
+
 // DeleteTask deletes a task
 func (s *Service) DeleteTask(ctx context.Context, id string) error {
 	if id == "" {
@@ -228,6 +567,11 @@ func (s *Service) ListTasks(ctx context.Context, options ListOptions) ([]*domain
 		DueAfter:  options.DueAfter,
 		Limit:     options.Limit,
 		Offset:    options.Offset,
+
+		CreatedAfter:            options.CreatedAfter,
+		CreatedBefore:           options.CreatedBefore,
+		ExcludeArchivedProjects: options.ExcludeArchivedProjects,
+		SortBy:                  options.SortBy,
 	}
 
 	tasks, err := s.taskRepo.List(ctx, filter)
@@ -235,6 +579,23 @@ func (s *Service) ListTasks(ctx context.Context, options ListOptions) ([]*domain
 		return nil, fmt.Errorf("failed to list tasks: %w", err)
 	}
 
+	if options.OverdueFirst {
+		sort.SliceStable(tasks, func(i, j int) bool {
+			return tasks[i].IsOverdue() && !tasks[j].IsOverdue()
+		})
+	}
+
+	return tasks, nil
+}
+
+// SearchTasks performs a ranked full-text search across task titles,
+// descriptions, and tags
+func (s *Service) SearchTasks(ctx context.Context, query string) ([]*domain.Task, error) {
+	tasks, err := s.taskRepo.SearchTasks(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tasks: %w", err)
+	}
+
 	return tasks, nil
 }
 
@@ -262,18 +623,148 @@ func (s *Service) StartTask(ctx context.Context, id string) error {
 	return err
 }
 
-// BlockTask marks a task as blocked
-func (s *Service) BlockTask(ctx context.Context, id string) error {
+// BlockTask marks a task as blocked, recording reason when it is not empty
+func (s *Service) BlockTask(ctx context.Context, id, reason string) error {
 	status := domain.StatusBlocked
 	input := UpdateTaskInput{
 		ID:     id,
 		Status: &status,
 	}
+	if reason != "" {
+		input.BlockedReason = &reason
+	}
 
 	_, err := s.UpdateTask(ctx, input)
 	return err
 }
 
+// ListBlockedTasks returns the blocked tasks outside archived projects, most
+// urgent first
+func (s *Service) ListBlockedTasks(ctx context.Context) ([]*domain.Task, error) {
+	tasks, err := s.ListTasks(ctx, ListOptions{
+		Status:                  []domain.TaskStatus{domain.StatusBlocked},
+		ExcludeArchivedProjects: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].Priority > tasks[j].Priority
+	})
+	return tasks, nil
+}
+
+// NextTask suggests what to work on next: the highest priority task that is
+// neither done nor blocked, with ties going to the earliest due date and then
+// the oldest task. It returns nil when nothing is left to do.
+func (s *Service) NextTask(ctx context.Context) (*domain.Task, error) {
+	tasks, err := s.ListTasks(ctx, ListOptions{
+		Status:                  []domain.TaskStatus{domain.StatusBacklog, domain.StatusTodo, domain.StatusDoing},
+		ExcludeArchivedProjects: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var next *domain.Task
+	for _, t := range tasks {
+		if next == nil || comesBefore(t, next) {
+			next = t
+		}
+	}
+	return next, nil
+}
+
+// comesBefore reports whether a should be worked on before b
+func comesBefore(a, b *domain.Task) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	switch {
+	case a.DueDate != nil && b.DueDate == nil:
+		return true
+	case a.DueDate == nil && b.DueDate != nil:
+		return false
+	case a.DueDate != nil && !a.DueDate.Equal(*b.DueDate):
+		return a.DueDate.Before(*b.DueDate)
+	}
+	return a.CreatedAt.Before(b.CreatedAt)
+}
+
+// GetOpenSubtasks returns every incomplete descendant of the task with
+// parentID, however deeply nested
+func (s *Service) GetOpenSubtasks(ctx context.Context, parentID string) ([]*domain.Task, error) {
+	var open []*domain.Task
+	visited := map[string]bool{parentID: true}
+	queue := []string{parentID}
+
+	for len(queue) > 0 {
+		subtasks, err := s.GetSubtasks(ctx, queue[0])
+		if err != nil {
+			return nil, err
+		}
+		queue = queue[1:]
+
+		for _, subtask := range subtasks {
+			if visited[subtask.ID] {
+				continue
+			}
+			visited[subtask.ID] = true
+			queue = append(queue, subtask.ID)
+			if subtask.Status != domain.StatusDone {
+				open = append(open, subtask)
+			}
+		}
+	}
+
+	return open, nil
+}
+
+// CompleteTaskWithSubtasks completes a task together with all of its open
+// subtasks in a single transaction. It returns the subtasks it completed.
+func (s *Service) CompleteTaskWithSubtasks(ctx context.Context, id string) ([]*domain.Task, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidTaskID
+	}
+
+	task, err := s.taskRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	subtasks, err := s.GetOpenSubtasks(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Subtasks are all open; the parent may already be done
+	completed := subtasks
+	if task.Status != domain.StatusDone {
+		task.Complete()
+		completed = append([]*domain.Task{task}, subtasks...)
+	}
+	for _, subtask := range subtasks {
+		subtask.Complete()
+	}
+
+	if err := s.taskRepo.UpdateTasks(ctx, append([]*domain.Task{task}, subtasks...)); err != nil {
+		return nil, fmt.Errorf("failed to complete task: %w", err)
+	}
+
+	projectIDs := make(map[string]bool)
+	for _, done := range completed {
+		s.notify(domain.EventTaskCompleted, done)
+		projectIDs[done.ProjectID] = true
+	}
+	for projectID := range projectIDs {
+		if err := s.completeProjectIfDone(ctx, projectID); err != nil {
+			return nil, err
+		}
+	}
+	return subtasks, nil
+}
+
 // GetSubtasks retrieves all subtasks for a given parent task
 func (s *Service) GetSubtasks(ctx context.Context, parentID string) ([]*domain.Task, error) {
 	if parentID == "" {
@@ -334,6 +825,163 @@ func (s *Service) RemoveTag(ctx context.Context, taskID, tag string) error {
 	return nil
 }
 
+// AddComment records a comment on a task
+func (s *Service) AddComment(ctx context.Context, taskID, body string) (*domain.Comment, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, domain.ErrEmptyComment
+	}
+
+	if _, err := s.taskRepo.GetByID(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	comment := domain.NewComment(taskID, body)
+	if err := s.taskRepo.AddComment(ctx, comment); err != nil {
+		return nil, fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+// ListComments returns a task's comments, oldest first
+func (s *Service) ListComments(ctx context.Context, taskID string) ([]*domain.Comment, error) {
+	comments, err := s.taskRepo.ListComments(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// TagCount is a tag and the number of tasks carrying it
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// ListTags returns every tag in use, most used first and then by name
+func (s *Service) ListTags(ctx context.Context) ([]TagCount, error) {
+	tasks, err := s.taskRepo.List(ctx, domain.TaskFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	counts := make(map[string]int)
+	for _, task := range tasks {
+		for _, tag := range task.Tags {
+			counts[tag]++
+		}
+	}
+
+	tags := make([]TagCount, 0, len(counts))
+	for tag, count := range counts {
+		tags = append(tags, TagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Tag < tags[j].Tag
+	})
+
+	return tags, nil
+}
+
+// RenameTag replaces oldTag with newTag on every task carrying it, in a single
+// transaction, and returns the number of tasks changed. Tasks that already
+// have newTag simply lose oldTag.
+func (s *Service) RenameTag(ctx context.Context, oldTag, newTag string) (int, error) {
+	oldTag = strings.TrimSpace(oldTag)
+	newTag = strings.TrimSpace(newTag)
+	if oldTag == "" || newTag == "" {
+		return 0, fmt.Errorf("tag names cannot be empty")
+	}
+	if oldTag == newTag {
+		return 0, nil
+	}
+
+	tasks, err := s.taskRepo.List(ctx, domain.TaskFilter{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	var changed []*domain.Task
+	for _, task := range tasks {
+		if !task.HasTag(oldTag) {
+			continue
+		}
+		task.RemoveTag(oldTag)
+		task.AddTag(newTag)
+		changed = append(changed, task)
+	}
+
+	if len(changed) == 0 {
+		return 0, nil
+	}
+	if err := s.taskRepo.UpdateTasks(ctx, changed); err != nil {
+		return 0, fmt.Errorf("failed to rename tag: %w", err)
+	}
+
+	return len(changed), nil
+}
+
+// AddProjectTag adds tag to every task in the project, returning how many
+// tasks gained it
+func (s *Service) AddProjectTag(ctx context.Context, projectID, tag string) (int, error) {
+	return s.retagProject(ctx, projectID, tag, func(task *domain.Task) bool {
+		if task.HasTag(tag) {
+			return false
+		}
+		task.AddTag(tag)
+		return true
+	})
+}
+
+// RemoveProjectTag removes tag from every task in the project, returning how
+// many tasks carried it
+func (s *Service) RemoveProjectTag(ctx context.Context, projectID, tag string) (int, error) {
+	return s.retagProject(ctx, projectID, tag, func(task *domain.Task) bool {
+		if !task.HasTag(tag) {
+			return false
+		}
+		task.RemoveTag(tag)
+		return true
+	})
+}
+
+// retagProject applies change to each task in the project and saves the
+// tasks it reports as changed in one transaction
+func (s *Service) retagProject(ctx context.Context, projectID, tag string, change func(*domain.Task) bool) (int, error) {
+	if projectID == "" {
+		return 0, domain.ErrInvalidProjectID
+	}
+	if strings.TrimSpace(tag) == "" {
+		return 0, fmt.Errorf("tag name cannot be empty")
+	}
+
+	tasks, err := s.taskRepo.GetByProject(ctx, projectID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get tasks by project: %w", err)
+	}
+
+	var changed []*domain.Task
+	for _, task := range tasks {
+		if change(task) {
+			changed = append(changed, task)
+		}
+	}
+
+	if len(changed) == 0 {
+		return 0, nil
+	}
+	if err := s.taskRepo.UpdateTasks(ctx, changed); err != nil {
+		return 0, fmt.Errorf("failed to update tasks: %w", err)
+	}
+
+	return len(changed), nil
+}
+
 // GetOverdueTasks retrieves all overdue tasks
 func (s *Service) GetOverdueTasks(ctx context.Context) ([]*domain.Task, error) {
 	now := time.Now()
@@ -360,6 +1008,32 @@ func (s *Service) GetTasksDueToday(ctx context.Context) ([]*domain.Task, error)
 	return s.ListTasks(ctx, options)
 }
 
+// checkParent walks up the parent chain from parentID and returns
+// domain.ErrCircularDependency if it reaches taskID
+func (s *Service) checkParent(ctx context.Context, taskID, parentID string) error {
+	visited := make(map[string]bool)
+	for current := parentID; current != ""; {
+		if current == taskID {
+			return domain.ErrCircularDependency
+		}
+		if visited[current] {
+			// An existing loop above us; stop rather than spin
+			return domain.ErrCircularDependency
+		}
+		visited[current] = true
+
+		parent, err := s.taskRepo.GetByID(ctx, current)
+		if err != nil {
+			return fmt.Errorf("failed to get parent task: %w", err)
+		}
+		if parent.ParentID == nil {
+			break
+		}
+		current = *parent.ParentID
+	}
+	return nil
+}
+
 // parseDueDate parses a natural language due date
 func (s *Service) parseDueDate(dateStr string) (*time.Time, error) {
 	result, err := s.parser.Parse(dateStr, time.Now())
@@ -372,4 +1046,38 @@ func (s *Service) parseDueDate(dateStr string) (*time.Time, error) {
 	}
 
 	return &result.Time, nil
+}
+
+// checkDueDate returns domain.ErrInvalidDueDate when past due dates are
+// rejected and due falls before today. Any time today is accepted, so a bare
+// YYYY-MM-DD date for today passes.
+func (s *Service) checkDueDate(due *time.Time, allowPast bool) error {
+	if !s.rejectPastDue || allowPast {
+		return nil
+	}
+
+	now := time.Now()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if due.Before(startOfDay) {
+		return fmt.Errorf("%w: %s", domain.ErrInvalidDueDate, due.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// ParseDate parses a YYYY-MM-DD date or natural language such as "next
+// friday", in local time
+func (s *Service) ParseDate(dateStr string) (*time.Time, error) {
+	if date, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(dateStr), time.Local); err == nil {
+		return &date, nil
+	}
+	return s.parseDueDate(dateStr)
+}
+
+// parseEstimate parses a time estimate such as "2h" or "45m"
+func parseEstimate(estimateStr string) (time.Duration, error) {
+	estimate, err := time.ParseDuration(strings.TrimSpace(estimateStr))
+	if err != nil || estimate < 0 {
+		return 0, fmt.Errorf("%w: %s (use a duration such as 2h or 45m)", domain.ErrInvalidEstimate, estimateStr)
+	}
+	return estimate, nil
 }
\ No newline at end of file
@@ -0,0 +1,887 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+	"github.com/adriannajera/project-manager-cli/internal/repository/memory"
+)
+
+// storedTask returns the repository's current copy of a task
+func storedTask(t *testing.T, repo domain.TaskRepository, id string) *domain.Task {
+	t.Helper()
+	task, err := repo.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("Failed to get task: %v", err)
+	}
+	return task
+}
+
+func TestUpdateTaskRejectsCircularParent(t *testing.T) {
+	repo := memory.NewTaskRepository(memory.NewStore())
+	service := NewService(repo, nil)
+	ctx := context.Background()
+
+	a := domain.NewTask("A", "")
+	b := domain.NewTask("B", "")
+	c := domain.NewTask("C", "")
+	for _, task := range []*domain.Task{a, b, c} {
+		repo.Create(ctx, task)
+	}
+
+	setParent := func(task, parent *domain.Task) error {
+		_, err := service.UpdateTask(ctx, UpdateTaskInput{ID: task.ID, ParentID: &parent.ID})
+		return err
+	}
+
+	// B -> A and C -> B are fine
+	if err := setParent(b, a); err != nil {
+		t.Fatalf("Failed to set parent: %v", err)
+	}
+	if err := setParent(c, b); err != nil {
+		t.Fatalf("Failed to set parent: %v", err)
+	}
+
+	// Self-reference
+	if err := setParent(a, a); !errors.Is(err, domain.ErrCircularDependency) {
+		t.Errorf("Expected ErrCircularDependency for self parent, got %v", err)
+	}
+
+	// Direct cycle: A -> B while B -> A
+	if err := setParent(a, b); !errors.Is(err, domain.ErrCircularDependency) {
+		t.Errorf("Expected ErrCircularDependency for direct cycle, got %v", err)
+	}
+
+	// Indirect cycle: A -> C while C -> B -> A
+	if err := setParent(a, c); !errors.Is(err, domain.ErrCircularDependency) {
+		t.Errorf("Expected ErrCircularDependency for indirect cycle, got %v", err)
+	}
+
+	if parent := storedTask(t, repo, a.ID).ParentID; parent != nil {
+		t.Errorf("Expected rejected updates to leave A without a parent, got %s", *parent)
+	}
+}
+
+func TestCloneTaskWithSubtasks(t *testing.T) {
+	repo := memory.NewTaskRepository(memory.NewStore())
+	service := NewService(repo, nil)
+	ctx := context.Background()
+
+	source := domain.NewTask("Release", "Cut the release")
+	source.Priority = domain.PriorityHigh
+	source.ProjectID = "project-1"
+	source.Tags = []string{"ops"}
+	source.Changelist = "CL-7"
+	source.Complete()
+	child := domain.NewTask("Tag build", "")
+	child.ParentID = &source.ID
+	grandchild := domain.NewTask("Push tag", "")
+	grandchild.ParentID = &child.ID
+	for _, task := range []*domain.Task{source, child, grandchild} {
+		repo.Create(ctx, task)
+	}
+
+	clone, err := service.CloneTask(ctx, source.ID, true)
+	if err != nil {
+		t.Fatalf("CloneTask failed: %v", err)
+	}
+
+	if clone.ID == source.ID {
+		t.Error("Expected the clone to get a fresh ID")
+	}
+	if clone.Title != "Release (copy)" {
+		t.Errorf("Expected title %q, got %q", "Release (copy)", clone.Title)
+	}
+	if clone.Status != domain.StatusTodo || clone.CompletedAt != nil {
+		t.Errorf("Expected an uncompleted todo clone, got status %s", clone.Status)
+	}
+	if clone.Description != source.Description || clone.Priority != source.Priority ||
+		clone.ProjectID != source.ProjectID || clone.Changelist != source.Changelist {
+		t.Errorf("Expected copied fields, got %+v", clone)
+	}
+	if len(clone.Tags) != 1 || clone.Tags[0] != "ops" {
+		t.Errorf("Expected tags [ops], got %v", clone.Tags)
+	}
+
+	children, _ := repo.GetSubtasks(ctx, clone.ID)
+	if len(children) != 1 || children[0].Title != "Tag build" {
+		t.Fatalf("Expected the subtask to be cloned under the copy, got %v", children)
+	}
+	grandchildren, _ := repo.GetSubtasks(ctx, children[0].ID)
+	if len(grandchildren) != 1 || grandchildren[0].Title != "Push tag" {
+		t.Errorf("Expected the nested subtask to be cloned, got %v", grandchildren)
+	}
+	if all, _ := repo.List(ctx, domain.TaskFilter{}); len(all) != 6 {
+		t.Errorf("Expected 6 tasks after cloning a 3-task tree, got %d", len(all))
+	}
+}
+
+func TestListTagsCountsAndOrders(t *testing.T) {
+	repo := memory.NewTaskRepository(memory.NewStore())
+	service := NewService(repo, nil)
+	ctx := context.Background()
+
+	for _, tags := range [][]string{{"bug", "ui"}, {"bug"}, {"api"}, nil} {
+		task := domain.NewTask("Task", "")
+		task.Tags = tags
+		repo.Create(ctx, task)
+	}
+
+	tags, err := service.ListTags(ctx)
+	if err != nil {
+		t.Fatalf("ListTags failed: %v", err)
+	}
+
+	want := []TagCount{{"bug", 2}, {"api", 1}, {"ui", 1}}
+	if len(tags) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, tags)
+	}
+	for i := range want {
+		if tags[i] != want[i] {
+			t.Errorf("Expected %v at %d, got %v", want[i], i, tags[i])
+		}
+	}
+}
+
+func TestRenameTag(t *testing.T) {
+	repo := memory.NewTaskRepository(memory.NewStore())
+	service := NewService(repo, nil)
+	ctx := context.Background()
+
+	tagged := domain.NewTask("Tagged", "")
+	tagged.Tags = []string{"bug", "ui"}
+	both := domain.NewTask("Both", "")
+	both.Tags = []string{"bug", "defect"}
+	other := domain.NewTask("Other", "")
+	other.Tags = []string{"ui"}
+	for _, task := range []*domain.Task{tagged, both, other} {
+		repo.Create(ctx, task)
+	}
+
+	count, err := service.RenameTag(ctx, "bug", "defect")
+	if err != nil {
+		t.Fatalf("RenameTag failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 tasks renamed, got %d", count)
+	}
+
+	tagged = storedTask(t, repo, tagged.ID)
+	both = storedTask(t, repo, both.ID)
+	other = storedTask(t, repo, other.ID)
+
+	if tagged.HasTag("bug") || !tagged.HasTag("defect") || !tagged.HasTag("ui") {
+		t.Errorf("Expected [ui defect], got %v", tagged.Tags)
+	}
+	if len(both.Tags) != 1 || both.Tags[0] != "defect" {
+		t.Errorf("Expected a single defect tag, got %v", both.Tags)
+	}
+	if len(other.Tags) != 1 || other.Tags[0] != "ui" {
+		t.Errorf("Expected untouched tags, got %v", other.Tags)
+	}
+}
+
+func TestAddAndRemoveProjectTag(t *testing.T) {
+	repo := memory.NewTaskRepository(memory.NewStore())
+	service := NewService(repo, nil)
+	ctx := context.Background()
+
+	first := domain.NewTask("First", "")
+	first.ProjectID = "release"
+	second := domain.NewTask("Second", "")
+	second.ProjectID = "release"
+	second.Tags = []string{"v2"}
+	elsewhere := domain.NewTask("Elsewhere", "")
+	elsewhere.ProjectID = "other"
+	for _, task := range []*domain.Task{first, second, elsewhere} {
+		repo.Create(ctx, task)
+	}
+
+	count, err := service.AddProjectTag(ctx, "release", "v2")
+	if err != nil {
+		t.Fatalf("AddProjectTag failed: %v", err)
+	}
+	first, second, elsewhere = storedTask(t, repo, first.ID), storedTask(t, repo, second.ID), storedTask(t, repo, elsewhere.ID)
+	if count != 1 || !first.HasTag("v2") || len(second.Tags) != 1 {
+		t.Errorf("Expected only the untagged task to change, got %d changed", count)
+	}
+	if elsewhere.HasTag("v2") {
+		t.Errorf("Expected tasks of other projects to be untouched")
+	}
+
+	count, err = service.RemoveProjectTag(ctx, "release", "v2")
+	if err != nil {
+		t.Fatalf("RemoveProjectTag failed: %v", err)
+	}
+	first, second = storedTask(t, repo, first.ID), storedTask(t, repo, second.ID)
+	if count != 2 || first.HasTag("v2") || second.HasTag("v2") {
+		t.Errorf("Expected the tag removed from both tasks, got %d changed", count)
+	}
+}
+
+func TestParseDateAcceptsISOAndNaturalLanguage(t *testing.T) {
+	service := NewService(memory.NewTaskRepository(memory.NewStore()), nil)
+
+	date, err := service.ParseDate("2025-10-31")
+	if err != nil {
+		t.Fatalf("ParseDate(ISO) failed: %v", err)
+	}
+	if date.Year() != 2025 || date.Month() != 10 || date.Day() != 31 {
+		t.Errorf("Expected 2025-10-31, got %v", date)
+	}
+
+	if _, err := service.ParseDate("tomorrow"); err != nil {
+		t.Errorf("ParseDate(tomorrow) failed: %v", err)
+	}
+	if _, err := service.ParseDate("not a date"); err == nil {
+		t.Error("Expected an error for an unparseable date")
+	}
+}
+
+func TestCreateTaskRequiresExistingParent(t *testing.T) {
+	repo := memory.NewTaskRepository(memory.NewStore())
+	service := NewService(repo, nil)
+	ctx := context.Background()
+
+	missing := "no-such-task"
+	if _, err := service.CreateTask(ctx, CreateTaskInput{Title: "Orphan", ParentID: &missing}); !errors.Is(err, domain.ErrParentNotFound) {
+		t.Errorf("Expected ErrParentNotFound, got %v", err)
+	}
+
+	parent, err := service.CreateTask(ctx, CreateTaskInput{Title: "Parent"})
+	if err != nil {
+		t.Fatalf("Failed to create parent: %v", err)
+	}
+	child, err := service.CreateTask(ctx, CreateTaskInput{Title: "Child", ParentID: &parent.ID})
+	if err != nil {
+		t.Fatalf("Failed to create subtask: %v", err)
+	}
+	if child.ParentID == nil || *child.ParentID != parent.ID {
+		t.Errorf("Expected the subtask to point at its parent, got %v", child.ParentID)
+	}
+}
+
+func TestResolveIDByPrefix(t *testing.T) {
+	repo := memory.NewTaskRepository(memory.NewStore())
+	service := NewService(repo, nil)
+	ctx := context.Background()
+
+	for _, id := range []string{"abcd1234-0000", "abcd5678-0000", "ef012345-0000"} {
+		task := domain.NewTask("Task", "")
+		task.ID = id
+		repo.Create(ctx, task)
+	}
+
+	tests := []struct {
+		input   string
+		want    string
+		wantErr error
+	}{
+		{input: "abcd5678-0000", want: "abcd5678-0000"},
+		{input: "ef01", want: "ef012345-0000"},
+		{input: "abcd1", want: "abcd1234-0000"},
+		{input: "abcd", wantErr: domain.ErrAmbiguousID},
+		{input: "ef0", wantErr: domain.ErrTaskNotFound},
+		{input: "9999", wantErr: domain.ErrTaskNotFound},
+	}
+
+	for _, tt := range tests {
+		got, err := service.ResolveID(ctx, tt.input)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ResolveID(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ResolveID(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+}
+
+// recordingNotifier collects the events it receives
+type recordingNotifier struct {
+	events []domain.Event
+}
+
+func (n *recordingNotifier) Notify(event domain.Event) {
+	n.events = append(n.events, event)
+}
+
+func TestCompletionEventFiresOnlyOnTransitionToDone(t *testing.T) {
+	repo := memory.NewTaskRepository(memory.NewStore())
+	service := NewService(repo, nil)
+	notifier := &recordingNotifier{}
+	service.SetNotifier(notifier)
+	ctx := context.Background()
+
+	created, err := service.CreateTask(ctx, CreateTaskInput{Title: "Write docs"})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	done := domain.StatusDone
+	for i := 0; i < 2; i++ {
+		if _, err := service.UpdateTask(ctx, UpdateTaskInput{ID: created.ID, Status: &done}); err != nil {
+			t.Fatalf("Failed to complete task: %v", err)
+		}
+	}
+
+	var names []domain.EventName
+	for _, event := range notifier.events {
+		names = append(names, event.Name)
+	}
+	want := []domain.EventName{domain.EventTaskCreated, domain.EventTaskCompleted}
+	if len(names) != len(want) || names[0] != want[0] || names[1] != want[1] {
+		t.Errorf("Expected events %v, got %v", want, names)
+	}
+	if last := notifier.events[len(notifier.events)-1]; last.Task == nil || last.Task.ID != created.ID {
+		t.Errorf("Expected the completion event to carry the task")
+	}
+}
+
+func TestAddComment(t *testing.T) {
+	repo := memory.NewTaskRepository(memory.NewStore())
+	service := NewService(repo, nil)
+	ctx := context.Background()
+
+	created, err := service.CreateTask(ctx, CreateTaskInput{Title: "Investigate flaky test"})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	if _, err := service.AddComment(ctx, created.ID, "   "); !errors.Is(err, domain.ErrEmptyComment) {
+		t.Errorf("Expected ErrEmptyComment, got %v", err)
+	}
+	if _, err := service.AddComment(ctx, "no-such-task", "Hello"); !errors.Is(err, domain.ErrTaskNotFound) {
+		t.Errorf("Expected ErrTaskNotFound, got %v", err)
+	}
+
+	if _, err := service.AddComment(ctx, created.ID, "  Fails on CI only  "); err != nil {
+		t.Fatalf("Failed to add comment: %v", err)
+	}
+	comments, err := service.ListComments(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Failed to list comments: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Body != "Fails on CI only" {
+		t.Errorf("Expected the trimmed comment, got %+v", comments)
+	}
+}
+
+func TestBlockedReasonFollowsStatus(t *testing.T) {
+	repo := memory.NewTaskRepository(memory.NewStore())
+	service := NewService(repo, nil)
+	ctx := context.Background()
+
+	low, _ := service.CreateTask(ctx, CreateTaskInput{Title: "Tidy docs", Priority: domain.PriorityLow})
+	high, _ := service.CreateTask(ctx, CreateTaskInput{Title: "Ship release", Priority: domain.PriorityHigh})
+
+	reason := "Waiting on review"
+	if _, err := service.UpdateTask(ctx, UpdateTaskInput{ID: low.ID, BlockedReason: &reason}); !errors.Is(err, domain.ErrReasonNotBlocked) {
+		t.Errorf("Expected ErrReasonNotBlocked, got %v", err)
+	}
+
+	if err := service.BlockTask(ctx, low.ID, ""); err != nil {
+		t.Fatalf("Failed to block task: %v", err)
+	}
+	if err := service.BlockTask(ctx, high.ID, reason); err != nil {
+		t.Fatalf("Failed to block task: %v", err)
+	}
+	if got, _ := service.GetTask(ctx, high.ID); got.BlockedReason() != reason {
+		t.Errorf("Expected reason %q, got %q", reason, got.BlockedReason())
+	}
+
+	blocked, err := service.ListBlockedTasks(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list blocked tasks: %v", err)
+	}
+	if len(blocked) != 2 || blocked[0].ID != high.ID {
+		t.Errorf("Expected the high priority task first, got %d tasks", len(blocked))
+	}
+
+	todo := domain.StatusTodo
+	unblocked, err := service.UpdateTask(ctx, UpdateTaskInput{ID: high.ID, Status: &todo})
+	if err != nil {
+		t.Fatalf("Failed to unblock task: %v", err)
+	}
+	if unblocked.BlockedReason() != "" {
+		t.Errorf("Expected the reason to be cleared, got %q", unblocked.BlockedReason())
+	}
+}
+
+func TestListTasksOverdueFirst(t *testing.T) {
+	repo := memory.NewTaskRepository(memory.NewStore())
+	service := NewService(repo, nil)
+	ctx := context.Background()
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	for _, title := range []string{"On time", "No date", "Late", "Done late"} {
+		task := domain.NewTask(title, "")
+		switch title {
+		case "On time":
+			due := time.Now().AddDate(0, 0, 1)
+			task.DueDate = &due
+		case "Late":
+			task.DueDate = &yesterday
+		case "Done late":
+			task.DueDate = &yesterday
+			task.Status = domain.StatusDone
+		}
+		repo.Create(ctx, task)
+	}
+
+	tasks, err := service.ListTasks(ctx, ListOptions{OverdueFirst: true})
+	if err != nil {
+		t.Fatalf("Failed to list tasks: %v", err)
+	}
+	if len(tasks) != 4 || tasks[0].Title != "Late" {
+		t.Fatalf("Expected 4 tasks with the overdue one first, got %d", len(tasks))
+	}
+	for _, task := range tasks[1:] {
+		if task.IsOverdue() {
+			t.Errorf("Expected %q to come after the overdue task", task.Title)
+		}
+	}
+}
+
+func TestNextTaskPrefersPriorityThenDueDate(t *testing.T) {
+	repo := memory.NewTaskRepository(memory.NewStore())
+	service := NewService(repo, nil)
+	ctx := context.Background()
+
+	next, err := service.NextTask(ctx)
+	if err != nil || next != nil {
+		t.Fatalf("Expected no suggestion without tasks, got %v (%v)", next, err)
+	}
+
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	nextWeek := time.Now().AddDate(0, 0, 7)
+	for _, tc := range []struct {
+		title    string
+		priority domain.Priority
+		due      *time.Time
+	}{
+		{"Low but urgent", domain.PriorityLow, &tomorrow},
+		{"High, no date", domain.PriorityHigh, nil},
+		{"High, next week", domain.PriorityHigh, &nextWeek},
+		{"High, tomorrow", domain.PriorityHigh, &tomorrow},
+	} {
+		task := domain.NewTask(tc.title, "")
+		task.Priority = tc.priority
+		task.DueDate = tc.due
+		repo.Create(ctx, task)
+	}
+
+	next, err = service.NextTask(ctx)
+	if err != nil {
+		t.Fatalf("NextTask failed: %v", err)
+	}
+	if next == nil || next.Title != "High, tomorrow" {
+		t.Errorf("Expected the high priority task due tomorrow, got %v", next)
+	}
+}
+
+func TestAgeOverdueTasks(t *testing.T) {
+	repo := memory.NewTaskRepository(memory.NewStore())
+	service := NewService(repo, nil)
+	ctx := context.Background()
+
+	now := time.Now()
+	due := now.Add(-7*24*time.Hour - time.Hour)
+	task := domain.NewTask("Renew certificate", "")
+	task.Priority = domain.PriorityLow
+	task.DueDate = &due
+	repo.Create(ctx, task)
+
+	week := 7 * 24 * time.Hour
+	day := 24 * time.Hour
+
+	aged, err := service.AgeOverdueTasks(ctx, 3*day, now, true)
+	if err != nil || len(aged) != 1 || aged[0].To != domain.PriorityHigh {
+		t.Fatalf("Expected a dry run to raise low to high, got %+v (%v)", aged, err)
+	}
+	if stored := storedTask(t, repo, task.ID); stored.Priority != domain.PriorityLow {
+		t.Errorf("Expected a dry run to leave the priority alone, got %s", stored.Priority)
+	}
+
+	if _, err := service.AgeOverdueTasks(ctx, 3*day, now, false); err != nil {
+		t.Fatalf("AgeOverdueTasks failed: %v", err)
+	}
+	if stored := storedTask(t, repo, task.ID); stored.Priority != domain.PriorityHigh {
+		t.Errorf("Expected priority high after two intervals, got %s", stored.Priority)
+	}
+	if comments, _ := repo.ListComments(ctx, task.ID); len(comments) != 1 {
+		t.Errorf("Expected the change recorded as a comment, got %d comments", len(comments))
+	}
+
+	// Lowered by hand, the task stays put until another interval passes
+	task = storedTask(t, repo, task.ID)
+	task.Priority = domain.PriorityNormal
+	repo.Update(ctx, task)
+	if aged, _ := service.AgeOverdueTasks(ctx, 3*day, now, false); len(aged) != 0 {
+		t.Errorf("Expected no change within the same interval, got %+v", aged)
+	}
+	if aged, _ := service.AgeOverdueTasks(ctx, 3*day, now.Add(2*day), false); len(aged) != 1 || aged[0].To != domain.PriorityHigh {
+		t.Errorf("Expected one level after the next interval, got %+v", aged)
+	}
+
+	// Capped at critical, however long it is overdue
+	if _, err := service.AgeOverdueTasks(ctx, 3*day, now.Add(10*week), false); err != nil {
+		t.Fatalf("AgeOverdueTasks failed: %v", err)
+	}
+	if stored := storedTask(t, repo, task.ID); stored.Priority != domain.PriorityCritical {
+		t.Errorf("Expected priority capped at critical, got %s", stored.Priority)
+	}
+
+	if _, err := service.AgeOverdueTasks(ctx, 0, now, false); err == nil {
+		t.Error("Expected an error for a zero interval")
+	}
+}
+
+func TestTaskMetadata(t *testing.T) {
+	repo := memory.NewTaskRepository(memory.NewStore())
+	service := NewService(repo, nil)
+	ctx := context.Background()
+
+	created, err := service.CreateTask(ctx, CreateTaskInput{Title: "Sync with tracker"})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	if err := service.SetMetadata(ctx, created.ID, " ", "x"); !errors.Is(err, domain.ErrEmptyMetadataKey) {
+		t.Errorf("Expected ErrEmptyMetadataKey, got %v", err)
+	}
+	if err := service.SetMetadata(ctx, created.ID, "ticket", "JIRA-1042"); err != nil {
+		t.Fatalf("Failed to set metadata: %v", err)
+	}
+
+	value, err := service.GetMetadata(ctx, created.ID, "ticket")
+	if err != nil || value != "JIRA-1042" {
+		t.Errorf("Expected JIRA-1042, got %v, %v", value, err)
+	}
+	if _, err := service.GetMetadata(ctx, created.ID, "missing"); !errors.Is(err, domain.ErrMetadataNotFound) {
+		t.Errorf("Expected ErrMetadataNotFound, got %v", err)
+	}
+
+	if err := service.UnsetMetadata(ctx, created.ID, "ticket"); err != nil {
+		t.Fatalf("Failed to unset metadata: %v", err)
+	}
+	if err := service.UnsetMetadata(ctx, created.ID, "ticket"); !errors.Is(err, domain.ErrMetadataNotFound) {
+		t.Errorf("Expected ErrMetadataNotFound on a second unset, got %v", err)
+	}
+	if metadata, _ := service.ListMetadata(ctx, created.ID); len(metadata) != 0 {
+		t.Errorf("Expected no metadata left, got %v", metadata)
+	}
+}
+
+func TestAttachToTask(t *testing.T) {
+	repo := memory.NewTaskRepository(memory.NewStore())
+	service := NewService(repo, nil)
+	ctx := context.Background()
+
+	created, err := service.CreateTask(ctx, CreateTaskInput{Title: "Ship login fix"})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	if _, err := service.AttachToTask(ctx, created.ID, "  "); !errors.Is(err, domain.ErrEmptyAttachment) {
+		t.Errorf("Expected ErrEmptyAttachment, got %v", err)
+	}
+	if added, err := service.AttachToTask(ctx, created.ID, "https://example.com/pr/7"); err != nil || !added {
+		t.Fatalf("Expected the link to be attached, got %v (%v)", added, err)
+	}
+	if added, _ := service.AttachToTask(ctx, created.ID, "https://example.com/pr/7"); added {
+		t.Error("Expected attaching the same link twice to report false")
+	}
+
+	attachments, err := service.ListAttachments(ctx, created.ID)
+	if err != nil || len(attachments) != 1 {
+		t.Errorf("Expected one attachment, got %v (%v)", attachments, err)
+	}
+}
+
+func TestRejectPastDueDates(t *testing.T) {
+	repo := memory.NewTaskRepository(memory.NewStore())
+	service := NewService(repo, nil)
+	ctx := context.Background()
+
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	today := time.Now().Format("2006-01-02")
+
+	if _, err := service.CreateTask(ctx, CreateTaskInput{Title: "Off by default", DueDate: yesterday}); err != nil {
+		t.Errorf("Expected past due dates to be accepted by default, got %v", err)
+	}
+
+	service.RejectPastDueDates()
+	if _, err := service.CreateTask(ctx, CreateTaskInput{Title: "Typo", DueDate: yesterday}); !errors.Is(err, domain.ErrInvalidDueDate) {
+		t.Errorf("Expected ErrInvalidDueDate, got %v", err)
+	}
+	if _, err := service.CreateTask(ctx, CreateTaskInput{Title: "Backfill", DueDate: yesterday, AllowPastDue: true}); err != nil {
+		t.Errorf("Expected AllowPastDue to accept the date, got %v", err)
+	}
+
+	created, err := service.CreateTask(ctx, CreateTaskInput{Title: "Due today", DueDate: today})
+	if err != nil {
+		t.Fatalf("Expected today to be accepted, got %v", err)
+	}
+	if _, err := service.UpdateTask(ctx, UpdateTaskInput{ID: created.ID, DueDate: &yesterday}); !errors.Is(err, domain.ErrInvalidDueDate) {
+		t.Errorf("Expected ErrInvalidDueDate on update, got %v", err)
+	}
+
+	// The dashboard's form builds tasks itself and saves them whole
+	past := time.Now().AddDate(0, 0, -1)
+	formTask := domain.NewTask("From the form", "")
+	formTask.DueDate = &past
+	if err := service.AddTask(ctx, formTask); !errors.Is(err, domain.ErrInvalidDueDate) {
+		t.Errorf("Expected ErrInvalidDueDate from AddTask, got %v", err)
+	}
+	edited := *created
+	edited.DueDate = &past
+	if err := service.SaveTask(ctx, &edited); !errors.Is(err, domain.ErrInvalidDueDate) {
+		t.Errorf("Expected ErrInvalidDueDate from SaveTask, got %v", err)
+	}
+}
+
+func TestSnoozeTask(t *testing.T) {
+	repo := memory.NewTaskRepository(memory.NewStore())
+	service := NewService(repo, nil)
+	ctx := context.Background()
+
+	created, err := service.CreateTask(ctx, CreateTaskInput{Title: "Renew certificate", DueDate: "2030-01-10"})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	result, err := service.SnoozeTask(ctx, created.ID, "3d")
+	if err != nil {
+		t.Fatalf("Failed to snooze task: %v", err)
+	}
+	if result.PreviousDue.Format("2006-01-02") != "2030-01-10" || result.Task.DueDate.Format("2006-01-02") != "2030-01-13" {
+		t.Errorf("Expected 2030-01-10 -> 2030-01-13, got %s -> %s", result.PreviousDue, result.Task.DueDate)
+	}
+
+	result, err = service.SnoozeTask(ctx, created.ID, "1W")
+	if err != nil || result.Task.DueDate.Format("2006-01-02") != "2030-01-20" {
+		t.Errorf("Expected a week later on 2030-01-20, got %v, %v", result, err)
+	}
+
+	result, err = service.SnoozeTask(ctx, created.ID, "2030-03-01")
+	if err != nil || result.Task.DueDate.Format("2006-01-02") != "2030-03-01" {
+		t.Errorf("Expected the date to be replaced, got %v, %v", result, err)
+	}
+
+	if _, err := service.SnoozeTask(ctx, created.ID, "soonish"); err == nil {
+		t.Error("Expected an error for an unparseable snooze")
+	}
+}
+
+func TestSnoozingAnOverdueTaskStartsFromNow(t *testing.T) {
+	repo := memory.NewTaskRepository(memory.NewStore())
+	service := NewService(repo, nil)
+	ctx := context.Background()
+
+	late := time.Now().AddDate(0, 0, -3).Format("2006-01-02")
+	created, err := service.CreateTask(ctx, CreateTaskInput{Title: "Renew certificate", DueDate: late})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	before := time.Now()
+	result, err := service.SnoozeTask(ctx, created.ID, "1d")
+	if err != nil {
+		t.Fatalf("Failed to snooze task: %v", err)
+	}
+	due := *result.Task.DueDate
+	if due.Before(before.AddDate(0, 0, 1)) || due.After(time.Now().AddDate(0, 0, 1)) {
+		t.Errorf("Expected a day from now, got %s", due)
+	}
+	if result.Task.IsOverdue() {
+		t.Error("Expected the snoozed task to no longer be overdue")
+	}
+}
+
+func TestCompletingLastTaskCompletesProject(t *testing.T) {
+	store := memory.NewStore()
+	repo := memory.NewTaskRepository(store)
+	projects := memory.NewProjectRepository(store)
+	service := NewService(repo, nil)
+	service.EnableProjectAutoCompletion(projects)
+	notifier := &recordingNotifier{}
+	service.SetNotifier(notifier)
+	ctx := context.Background()
+
+	project := domain.NewProject("Launch", "")
+	projects.Create(ctx, project)
+
+	var ids []string
+	for _, title := range []string{"Write copy", "Ship it"} {
+		created, err := service.CreateTask(ctx, CreateTaskInput{Title: title, ProjectID: project.ID})
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		ids = append(ids, created.ID)
+	}
+
+	if err := service.CompleteTask(ctx, ids[0]); err != nil {
+		t.Fatalf("Failed to complete task: %v", err)
+	}
+	if project, _ = projects.GetByID(ctx, project.ID); project.Status != domain.ProjectStatusActive {
+		t.Fatalf("Expected the project to stay active with a task open, got %s", project.Status)
+	}
+
+	if err := service.CompleteTask(ctx, ids[1]); err != nil {
+		t.Fatalf("Failed to complete task: %v", err)
+	}
+	if project, _ = projects.GetByID(ctx, project.ID); project.Status != domain.ProjectStatusCompleted {
+		t.Errorf("Expected the project to be completed, got %s", project.Status)
+	}
+	last := notifier.events[len(notifier.events)-1]
+	if last.Name != domain.EventProjectCompleted || last.Project == nil || last.Project.ID != project.ID {
+		t.Errorf("Expected a project_completed event for the project, got %+v", last)
+	}
+}
+
+func TestSaveTaskCompletesProject(t *testing.T) {
+	store := memory.NewStore()
+	repo := memory.NewTaskRepository(store)
+	projects := memory.NewProjectRepository(store)
+	service := NewService(repo, nil)
+	service.EnableProjectAutoCompletion(projects)
+	notifier := &recordingNotifier{}
+	service.SetNotifier(notifier)
+	ctx := context.Background()
+
+	project := domain.NewProject("Launch", "")
+	projects.Create(ctx, project)
+
+	created, err := service.CreateTask(ctx, CreateTaskInput{Title: "Ship it", ProjectID: project.ID})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	// An edited copy, as the dashboard's form hands back
+	edited := *created
+	edited.Complete()
+	if err := service.SaveTask(ctx, &edited); err != nil {
+		t.Fatalf("SaveTask failed: %v", err)
+	}
+	if project, _ = projects.GetByID(ctx, project.ID); project.Status != domain.ProjectStatusCompleted {
+		t.Errorf("Expected the project to be completed, got %s", project.Status)
+	}
+	if len(notifier.events) < 2 || notifier.events[len(notifier.events)-2].Name != domain.EventTaskCompleted {
+		t.Errorf("Expected task_completed before project_completed, got %+v", notifier.events)
+	}
+}
+
+func TestParseTaskSyntax(t *testing.T) {
+	input, project, err := ParseTaskSyntax("Fix login #bug !high @website due:friday #auth")
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if input.Title != "Fix login" {
+		t.Errorf("Expected title %q, got %q", "Fix login", input.Title)
+	}
+	if input.Priority != domain.PriorityHigh {
+		t.Errorf("Expected high priority, got %s", input.Priority)
+	}
+	if project != "website" {
+		t.Errorf("Expected project %q, got %q", "website", project)
+	}
+	if input.DueDate != "friday" {
+		t.Errorf("Expected due date %q, got %q", "friday", input.DueDate)
+	}
+	if len(input.Tags) != 2 || input.Tags[0] != "bug" || input.Tags[1] != "auth" {
+		t.Errorf("Expected tags [bug auth], got %v", input.Tags)
+	}
+
+	input, project, err = ParseTaskSyntax("Email # to @ team")
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if input.Title != "Email # to @ team" || project != "" || len(input.Tags) != 0 {
+		t.Errorf("Expected bare markers to stay in the title, got %q, %q, %v", input.Title, project, input.Tags)
+	}
+
+	for line, want := range map[string]string{
+		"Renew due:2025-03-01":  "2025-03-01",
+		"Renew due:next-friday": "next friday",
+		"Renew DUE:in-3-days":   "in 3 days",
+	} {
+		input, _, err := ParseTaskSyntax(line)
+		if err != nil || input.DueDate != want || input.Title != "Renew" {
+			t.Errorf("ParseTaskSyntax(%q) = %q, %q, %v; want title %q and due date %q", line, input.Title, input.DueDate, err, "Renew", want)
+		}
+	}
+
+	if _, _, err := ParseTaskSyntax("Deploy !urgent"); !errors.Is(err, domain.ErrInvalidPriority) {
+		t.Errorf("Expected ErrInvalidPriority, got %v", err)
+	}
+	if _, _, err := ParseTaskSyntax("#bug !high"); !errors.Is(err, domain.ErrEmptyTitle) {
+		t.Errorf("Expected ErrEmptyTitle, got %v", err)
+	}
+}
+
+func TestCreateTaskAcceptsISODueDate(t *testing.T) {
+	service := NewService(memory.NewTaskRepository(memory.NewStore()), nil)
+
+	created, err := service.CreateTask(context.Background(), CreateTaskInput{Title: "Renew", DueDate: "2025-03-01"})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	if created.DueDate == nil || created.DueDate.Format("2006-01-02") != "2025-03-01" {
+		t.Errorf("Expected due date 2025-03-01, got %v", created.DueDate)
+	}
+}
+
+func TestSequentialIDsArePerProjectAndResolve(t *testing.T) {
+	store := memory.NewStore()
+	service := NewService(memory.NewTaskRepository(store), nil)
+	ctx := context.Background()
+
+	website := domain.NewProject("Website Redesign", "")
+	projects := memory.NewProjectRepository(store)
+	projects.Create(ctx, website)
+	service.EnableSequentialIDs(projects)
+
+	create := func(projectID string) *domain.Task {
+		created, err := service.CreateTask(ctx, CreateTaskInput{Title: "Task", ProjectID: projectID})
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		return created
+	}
+	first := create(website.ID)
+	second := create(website.ID)
+	loose := create("")
+
+	for task, want := range map[*domain.Task]string{first: "WR-1", second: "WR-2", loose: "TASK-1"} {
+		if task.Ref != want {
+			t.Errorf("Expected ref %s, got %q", want, task.Ref)
+		}
+	}
+
+	id, err := service.ResolveID(ctx, "wr-2")
+	if err != nil || id != second.ID {
+		t.Errorf("Expected wr-2 to resolve to the second task, got %q, %v", id, err)
+	}
+	if _, err := service.ResolveID(ctx, "WR-9"); !errors.Is(err, domain.ErrTaskNotFound) {
+		t.Errorf("Expected ErrTaskNotFound for an unknown ref, got %v", err)
+	}
+}
+
+func TestRefPrefix(t *testing.T) {
+	for name, want := range map[string]string{
+		"Website":             "WEBSITE",
+		"project-manager cli": "PMC",
+		"Infrastructure":      "INFRASTR",
+		"???":                 "TASK",
+	} {
+		if got := RefPrefix(name); got != want {
+			t.Errorf("RefPrefix(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+// snoozePattern matches a relative snooze such as "3d", "1w", or "12h"
+var snoozePattern = regexp.MustCompile(`^(\d+)\s*([hdw])$`)
+
+// SnoozeResult is a task after SnoozeTask moved its due date
+type SnoozeResult struct {
+	Task        *domain.Task
+	PreviousDue *time.Time
+}
+
+// SnoozeTask pushes a task's due date back. until is either an amount such
+// as "3d", "1w", or "12h", added to the current due date (or to now when the
+// task has none or is overdue), or a date that ParseDate understands, such
+// as "next monday" or "2025-11-03", which replaces it.
+func (s *Service) SnoozeTask(ctx context.Context, id, until string) (*SnoozeResult, error) {
+	task, err := s.taskRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	previous := task.DueDate
+	due, err := s.snoozeUntil(previous, until)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkDueDate(due, false); err != nil {
+		return nil, err
+	}
+
+	task.DueDate = due
+	task.UpdatedAt = time.Now()
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	return &SnoozeResult{Task: task, PreviousDue: previous}, nil
+}
+
+// snoozeUntil works out the due date that until names, starting from due or
+// now, whichever is later, so that snoozing an overdue task leaves it due in
+// the future
+func (s *Service) snoozeUntil(due *time.Time, until string) (*time.Time, error) {
+	until = strings.ToLower(strings.TrimSpace(until))
+
+	if match := snoozePattern.FindStringSubmatch(until); match != nil {
+		amount, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid snooze amount: %s", until)
+		}
+
+		from := time.Now()
+		if due != nil && due.After(from) {
+			from = *due
+		}
+
+		var snoozed time.Time
+		switch match[2] {
+		case "h":
+			snoozed = from.Add(time.Duration(amount) * time.Hour)
+		case "d":
+			snoozed = from.AddDate(0, 0, amount)
+		case "w":
+			snoozed = from.AddDate(0, 0, 7*amount)
+		}
+		return &snoozed, nil
+	}
+
+	snoozed, err := s.ParseDate(until)
+	if err != nil {
+		return nil, fmt.Errorf("invalid due date format: %w (use an amount such as 3d or 1w, or a date)", err)
+	}
+	return snoozed, nil
+}
@@ -0,0 +1,62 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+// ParseTaskSyntax reads a one-line task description such as
+// "Fix login #bug !high @website due:friday". #tag adds a tag, !priority sets
+// the priority, @project names the project and due:<date> sets the due date;
+// the remaining words form the title. The project is returned by name for the
+// caller to resolve.
+//
+// A due date is a YYYY-MM-DD date or natural language for CreateTask's date
+// parser, with hyphens standing in for spaces: due:next-friday.
+func ParseTaskSyntax(line string) (CreateTaskInput, string, error) {
+	input := CreateTaskInput{Priority: domain.PriorityNormal}
+	var projectName string
+	var words []string
+
+	for _, word := range strings.Fields(line) {
+		switch {
+		case len(word) > 1 && word[0] == '#':
+			input.Tags = append(input.Tags, word[1:])
+
+		case len(word) > 1 && word[0] == '!':
+			priority, err := domain.ParsePriority(word[1:])
+			if err != nil {
+				return CreateTaskInput{}, "", fmt.Errorf("%w: %s (must be low, normal, high, or critical)", err, word[1:])
+			}
+			input.Priority = priority
+
+		case len(word) > 1 && word[0] == '@':
+			projectName = word[1:]
+
+		case len(word) > len("due:") && strings.HasPrefix(strings.ToLower(word), "due:"):
+			input.DueDate = dueDateToken(word[len("due:"):])
+
+		default:
+			words = append(words, word)
+		}
+	}
+
+	input.Title = strings.Join(words, " ")
+	if input.Title == "" {
+		return CreateTaskInput{}, "", domain.ErrEmptyTitle
+	}
+
+	return input, projectName, nil
+}
+
+// dueDateToken turns the value of a due: marker back into the words of a
+// natural language date, leaving YYYY-MM-DD dates alone
+func dueDateToken(value string) string {
+	if _, err := time.Parse("2006-01-02", value); err == nil {
+		return value
+	}
+	return strings.ReplaceAll(value, "-", " ")
+}
@@ -3,28 +3,185 @@ package time
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/adriannajera/project-manager-cli/internal/domain"
 )
 
+// DefaultMaxTimerDuration is how long a timer may run before it is treated
+// as forgotten
+const DefaultMaxTimerDuration = 12 * time.Hour
+
 // Service provides time tracking functionality
 type Service struct {
 	timeEntryRepo domain.TimeEntryRepository
 	taskRepo      domain.TaskRepository
+	projectRepo   domain.ProjectRepository
+	transactor    domain.Transactor
+	notifier      domain.Notifier
+	options       Options
+}
+
+// Options configures a time tracking service
+type Options struct {
+	// MaxTimerDuration flags entries that ran longer than this; zero means
+	// DefaultMaxTimerDuration
+	MaxTimerDuration time.Duration
+	// Rounding is the default rounding applied to reports
+	Rounding Rounding
+	// WeekStart is the first day of weekly reports
+	WeekStart WeekStart
+	// Location is the time zone for day, week, and month boundaries; nil
+	// means the local time zone
+	Location *time.Location
+}
+
+// Now returns the current time in the configured time zone
+func (o Options) Now() time.Time {
+	return time.Now().In(o.location())
+}
+
+func (o Options) location() *time.Location {
+	if o.Location == nil {
+		return time.Local
+	}
+	return o.Location
+}
+
+// ParseTimezone loads an IANA time zone such as "Europe/Berlin"; an empty
+// name means the local time zone
+func ParseTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone: %s", name)
+	}
+	return location, nil
 }
 
-// NewService creates a new time tracking service
-func NewService(timeEntryRepo domain.TimeEntryRepository, taskRepo domain.TaskRepository) *Service {
+// WeekStart names the first day of the week; the zero value means Monday
+type WeekStart string
+
+const (
+	WeekStartMonday WeekStart = "monday"
+	WeekStartSunday WeekStart = "sunday"
+)
+
+// ParseWeekStart validates a week start name; an empty name means Monday
+func ParseWeekStart(name string) (WeekStart, error) {
+	switch WeekStart(strings.ToLower(name)) {
+	case "", WeekStartMonday:
+		return WeekStartMonday, nil
+	case WeekStartSunday:
+		return WeekStartSunday, nil
+	default:
+		return WeekStartMonday, fmt.Errorf("unknown week start: %s (must be monday or sunday)", name)
+	}
+}
+
+// Weekday returns the weekday the week starts on
+func (w WeekStart) Weekday() time.Weekday {
+	if w == WeekStartSunday {
+		return time.Sunday
+	}
+	return time.Monday
+}
+
+// StartOfWeek returns midnight at the start of the week containing t, in t's
+// location
+func StartOfWeek(t time.Time, weekStart WeekStart) time.Time {
+	offset := (int(t.Weekday()) - int(weekStart.Weekday()) + 7) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+// RoundingMode selects how a partial increment is resolved when rounding
+type RoundingMode string
+
+const (
+	RoundNearest RoundingMode = "nearest"
+	RoundUp      RoundingMode = "up"
+	RoundDown    RoundingMode = "down"
+)
+
+// Rounding describes how report durations are rounded for billing
+type Rounding struct {
+	// Increment is the unit durations are rounded to; zero disables rounding
+	Increment time.Duration
+	Mode      RoundingMode
+}
+
+// ParseRoundingMode validates a rounding mode name; an empty name means nearest
+func ParseRoundingMode(name string) (RoundingMode, error) {
+	switch RoundingMode(strings.ToLower(name)) {
+	case "", RoundNearest:
+		return RoundNearest, nil
+	case RoundUp:
+		return RoundUp, nil
+	case RoundDown:
+		return RoundDown, nil
+	default:
+		return RoundNearest, fmt.Errorf("unknown rounding mode: %s (must be nearest, up, or down)", name)
+	}
+}
+
+// RoundDuration rounds d to a multiple of increment. Durations are left
+// unchanged when increment is zero.
+func RoundDuration(d, increment time.Duration, mode RoundingMode) time.Duration {
+	if increment <= 0 {
+		return d
+	}
+	switch mode {
+	case RoundUp:
+		return (d + increment - 1) / increment * increment
+	case RoundDown:
+		return d.Truncate(increment)
+	default:
+		return d.Round(increment)
+	}
+}
+
+// NewService creates a new time tracking service. Multi-step writes run in a
+// transaction through transactor; with a nil transactor they run directly.
+func NewService(timeEntryRepo domain.TimeEntryRepository, taskRepo domain.TaskRepository, transactor domain.Transactor, options Options) *Service {
+	if options.MaxTimerDuration <= 0 {
+		options.MaxTimerDuration = DefaultMaxTimerDuration
+	}
 	return &Service{
 		timeEntryRepo: timeEntryRepo,
 		taskRepo:      taskRepo,
+		transactor:    transactor,
+		options:       options,
 	}
 }
 
-// StartTimeEntryInput represents input for starting time tracking
+// SetNotifier sets the receiver of timer_started events
+func (s *Service) SetNotifier(notifier domain.Notifier) {
+	s.notifier = notifier
+}
+
+// SetProjectRepository sets where reports look up project names; without
+// one, report projects are named by ID
+func (s *Service) SetProjectRepository(projectRepo domain.ProjectRepository) {
+	s.projectRepo = projectRepo
+}
+
+// inTransaction runs fn in a transaction when the service has a transactor
+func (s *Service) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.transactor == nil {
+		return fn(ctx)
+	}
+	return s.transactor.WithinTransaction(ctx, fn)
+}
+
+// StartTimeEntryInput represents input for starting time tracking. Exactly
+// one of TaskID and Category must be set.
 type StartTimeEntryInput struct {
-	TaskID      string
+	TaskID   string
+	Category string
+	// Description defaults to the task's title for task timers
 	Description string
 }
 
@@ -46,14 +203,32 @@ type TimeReport struct {
 	ByTask        map[string]TaskTimeReport
 	ByProject     map[string]ProjectTimeReport
 	ByDay         map[string]DayTimeReport
+	ByCategory    map[string]CategoryTimeReport
+	// Rounding is the rounding applied by Round, if any
+	Rounding Rounding
+	// Running is the timer running in the report's range, when it was left
+	// out of the totals
+	Running *domain.TimeEntry
+}
+
+// ReportOptions adjusts what a report counts
+type ReportOptions struct {
+	// IncludeActive counts the running timer up to now. Its share grows
+	// while it runs, so the same report gives a different total each time.
+	IncludeActive bool
 }
 
 // TaskTimeReport represents time tracking for a specific task
 type TaskTimeReport struct {
 	TaskID        string
 	TaskTitle     string
+	Estimate      time.Duration
 	TotalDuration time.Duration
 	Entries       []*domain.TimeEntry
+
+	// Variance is how far all the time ever tracked against the task, not
+	// just in the report's range, is over (positive) or under its estimate
+	Variance time.Duration
 }
 
 // ProjectTimeReport represents time tracking for a specific project
@@ -64,6 +239,13 @@ type ProjectTimeReport struct {
 	Entries       []*domain.TimeEntry
 }
 
+// CategoryTimeReport represents time tracked under a category without a task
+type CategoryTimeReport struct {
+	Category      string
+	TotalDuration time.Duration
+	Entries       []*domain.TimeEntry
+}
+
 // DayTimeReport represents time tracking for a specific day
 type DayTimeReport struct {
 	Date          time.Time
@@ -73,42 +255,81 @@ type DayTimeReport struct {
 
 // StartTimeTracking starts time tracking for a task
 func (s *Service) StartTimeTracking(ctx context.Context, input StartTimeEntryInput) (*domain.TimeEntry, error) {
-	if input.TaskID == "" {
-		return nil, domain.ErrInvalidTaskID
+	input.Category = strings.TrimSpace(input.Category)
+	if (input.TaskID == "") == (input.Category == "") {
+		return nil, domain.ErrTaskOrCategory
 	}
 
-	// Check if there's already an active time entry
-	if activeEntry, err := s.timeEntryRepo.GetActive(ctx); err == nil && activeEntry != nil {
-		return nil, domain.ErrActiveTimeEntry
-	}
+	// The active-timer check, the new entry, and the task status change
+	// succeed or fail together
+	var entry *domain.TimeEntry
+	var task *domain.Task
+	err := s.inTransaction(ctx, func(ctx context.Context) error {
+		// Check if there's already an active time entry
+		if activeEntry, err := s.timeEntryRepo.GetActive(ctx); err == nil && activeEntry != nil {
+			return domain.ErrActiveTimeEntry
+		}
 
-	// Verify the task exists
-	task, err := s.taskRepo.GetByID(ctx, input.TaskID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get task: %w", err)
-	}
+		if input.Category != "" {
+			entry = domain.NewCategoryTimeEntry(input.Category, input.Description)
+			if err := s.timeEntryRepo.Create(ctx, entry); err != nil {
+				return fmt.Errorf("failed to create time entry: %w", err)
+			}
+			return nil
+		}
 
-	// Create new time entry
-	entry := domain.NewTimeEntry(input.TaskID, task.ProjectID, input.Description)
+		// Verify the task exists
+		var err error
+		task, err = s.taskRepo.GetByID(ctx, input.TaskID)
+		if err != nil {
+			return fmt.Errorf("failed to get task: %w", err)
+		}
 
-	if err := s.timeEntryRepo.Create(ctx, entry); err != nil {
-		return nil, fmt.Errorf("failed to create time entry: %w", err)
-	}
+		// Untitled entries take the task's title so lists and exports read
+		// without looking the task up
+		description := input.Description
+		if strings.TrimSpace(description) == "" {
+			description = task.Title
+		}
 
-	// Update task status to "doing" if it's not already
-	if task.Status != domain.StatusDoing {
-		task.Start()
-		if err := s.taskRepo.Update(ctx, task); err != nil {
-			// Log error but don't fail the time tracking start
-			fmt.Printf("Warning: failed to update task status: %v\n", err)
+		// Create new time entry
+		entry = domain.NewTimeEntry(input.TaskID, task.ProjectID, description)
+		if err := s.timeEntryRepo.Create(ctx, entry); err != nil {
+			return fmt.Errorf("failed to create time entry: %w", err)
+		}
+
+		// Update task status to "doing" if it's not already
+		if task.Status != domain.StatusDoing {
+			task.Start()
+			if err := s.taskRepo.Update(ctx, task); err != nil {
+				return fmt.Errorf("failed to update task status: %w", err)
+			}
 		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	if s.notifier != nil {
+		s.notifier.Notify(domain.Event{Name: domain.EventTimerStarted, Task: task, TimeEntry: entry})
+	}
 	return entry, nil
 }
 
 // StopTimeTracking stops the currently active time tracking
 func (s *Service) StopTimeTracking(ctx context.Context) (*domain.TimeEntry, error) {
+	return s.stopTimeTracking(ctx, false)
+}
+
+// StopTimeTrackingCapped stops time tracking like StopTimeTracking, but ends
+// an entry that ran past the maximum timer duration at that maximum
+func (s *Service) StopTimeTrackingCapped(ctx context.Context) (*domain.TimeEntry, error) {
+	return s.stopTimeTracking(ctx, true)
+}
+
+func (s *Service) stopTimeTracking(ctx context.Context, capAtMax bool) (*domain.TimeEntry, error) {
 	// Get the active time entry
 	entry, err := s.timeEntryRepo.GetActive(ctx)
 	if err != nil {
@@ -120,7 +341,11 @@ func (s *Service) StopTimeTracking(ctx context.Context) (*domain.TimeEntry, erro
 	}
 
 	// Stop the time entry
-	entry.Stop()
+	if capAtMax && s.ExceedsMaxDuration(entry) {
+		entry.StopAt(entry.StartTime.Add(s.options.MaxTimerDuration))
+	} else {
+		entry.Stop()
+	}
 
 	if err := s.timeEntryRepo.Update(ctx, entry); err != nil {
 		return nil, fmt.Errorf("failed to update time entry: %w", err)
@@ -129,6 +354,27 @@ func (s *Service) StopTimeTracking(ctx context.Context) (*domain.TimeEntry, erro
 	return entry, nil
 }
 
+// MaxTimerDuration returns how long a timer may run before it is flagged
+func (s *Service) MaxTimerDuration() time.Duration {
+	return s.options.MaxTimerDuration
+}
+
+// Rounding returns the default rounding for reports
+func (s *Service) Rounding() Rounding {
+	return s.options.Rounding
+}
+
+// Location returns the time zone reports are grouped in
+func (s *Service) Location() *time.Location {
+	return s.options.location()
+}
+
+// ExceedsMaxDuration reports whether entry has run longer than the maximum
+// timer duration, which usually means the timer was forgotten
+func (s *Service) ExceedsMaxDuration(entry *domain.TimeEntry) bool {
+	return entry.GetDuration() > s.options.MaxTimerDuration
+}
+
 // GetActiveTimeEntry returns the currently active time entry, if any
 func (s *Service) GetActiveTimeEntry(ctx context.Context) (*domain.TimeEntry, error) {
 	entry, err := s.timeEntryRepo.GetActive(ctx)
@@ -190,6 +436,59 @@ func (s *Service) GetTimeEntriesByProject(ctx context.Context, projectID string)
 	return entries, nil
 }
 
+// GetTrackedTime returns the total time tracked against a task, including
+// any running entry
+func (s *Service) GetTrackedTime(ctx context.Context, taskID string) (time.Duration, error) {
+	entries, err := s.GetTimeEntriesByTask(ctx, taskID)
+	if err != nil {
+		return 0, err
+	}
+
+	var total time.Duration
+	for _, entry := range entries {
+		total += entry.GetDuration()
+	}
+	return total, nil
+}
+
+// GetTimeEntry retrieves a time entry by ID
+func (s *Service) GetTimeEntry(ctx context.Context, id string) (*domain.TimeEntry, error) {
+	if id == "" {
+		return nil, fmt.Errorf("time entry ID cannot be empty")
+	}
+
+	entry, err := s.timeEntryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// DeleteTimeEntriesByTask deletes every time entry recorded for a task and
+// returns how many were deleted. On failure nothing is deleted.
+func (s *Service) DeleteTimeEntriesByTask(ctx context.Context, taskID string) (int, error) {
+	entries, err := s.GetTimeEntriesByTask(ctx, taskID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Either every entry goes or none does
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		for _, entry := range entries {
+			if err := s.timeEntryRepo.Delete(ctx, entry.ID); err != nil {
+				return fmt.Errorf("failed to delete time entry %s: %w", entry.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(entries), nil
+}
+
 // DeleteTimeEntry deletes a time entry
 func (s *Service) DeleteTimeEntry(ctx context.Context, id string) error {
 	if id == "" {
@@ -218,48 +517,61 @@ func (s *Service) UpdateTimeEntry(ctx context.Context, entry *domain.TimeEntry)
 	return nil
 }
 
-// GenerateReport generates a time tracking report for the given time range
-func (s *Service) GenerateReport(ctx context.Context, startDate, endDate time.Time) (*TimeReport, error) {
+// GenerateReport generates a time tracking report for the given time range.
+// Only completed entries count unless options include the running timer.
+func (s *Service) GenerateReport(ctx context.Context, startDate, endDate time.Time, options ReportOptions) (*TimeReport, error) {
+	completed := false
 	entries, err := s.ListTimeEntries(ctx, ListOptions{
 		StartAfter: &startDate,
 		EndBefore:  &endDate,
+		Active:     &completed,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get time entries for report: %w", err)
 	}
 
+	running, err := s.runningEntryIn(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	// The running timer started after every completed entry, so it leads
+	if running != nil && options.IncludeActive {
+		entries = append([]*domain.TimeEntry{running}, entries...)
+	}
+
 	report := &TimeReport{
-		Entries:   entries,
-		ByTask:    make(map[string]TaskTimeReport),
-		ByProject: make(map[string]ProjectTimeReport),
-		ByDay:     make(map[string]DayTimeReport),
+		Entries:    entries,
+		ByTask:     make(map[string]TaskTimeReport),
+		ByProject:  make(map[string]ProjectTimeReport),
+		ByDay:      make(map[string]DayTimeReport),
+		ByCategory: make(map[string]CategoryTimeReport),
+	}
+	if !options.IncludeActive {
+		report.Running = running
 	}
 
-	// Calculate totals and group by task, project, and day
+	// Calculate totals and group by task or category, project, and day
 	for _, entry := range entries {
 		duration := entry.GetDuration()
 		report.TotalDuration += duration
 
-		// Group by task
+		// Group by task, or by category for entries without one
 		taskKey := entry.TaskID
-		if taskReport, exists := report.ByTask[taskKey]; exists {
+		if entry.TaskID == "" {
+			categoryReport := report.ByCategory[entry.Category]
+			categoryReport.Category = entry.Category
+			categoryReport.TotalDuration += duration
+			categoryReport.Entries = append(categoryReport.Entries, entry)
+			report.ByCategory[entry.Category] = categoryReport
+		} else if taskReport, exists := report.ByTask[taskKey]; exists {
 			taskReport.TotalDuration += duration
 			taskReport.Entries = append(taskReport.Entries, entry)
 			report.ByTask[taskKey] = taskReport
 		} else {
-			// Get task title
-			task, err := s.taskRepo.GetByID(ctx, entry.TaskID)
-			taskTitle := entry.TaskID // fallback
-			if err == nil {
-				taskTitle = task.Title
-			}
-
-			report.ByTask[taskKey] = TaskTimeReport{
-				TaskID:        entry.TaskID,
-				TaskTitle:     taskTitle,
-				TotalDuration: duration,
-				Entries:       []*domain.TimeEntry{entry},
-			}
+			taskReport := s.newTaskTimeReport(ctx, entry.TaskID)
+			taskReport.TotalDuration = duration
+			taskReport.Entries = []*domain.TimeEntry{entry}
+			report.ByTask[taskKey] = taskReport
 		}
 
 		// Group by project
@@ -271,61 +583,270 @@ func (s *Service) GenerateReport(ctx context.Context, startDate, endDate time.Ti
 		} else {
 			report.ByProject[projectKey] = ProjectTimeReport{
 				ProjectID:     entry.ProjectID,
-				ProjectName:   entry.ProjectID, // TODO: Get actual project name
+				ProjectName:   entry.ProjectID, // replaced by nameProjects
 				TotalDuration: duration,
 				Entries:       []*domain.TimeEntry{entry},
 			}
 		}
 
-		// Group by day
-		dayKey := entry.StartTime.Format("2006-01-02")
-		if dayReport, exists := report.ByDay[dayKey]; exists {
-			dayReport.TotalDuration += duration
-			dayReport.Entries = append(dayReport.Entries, entry)
-			report.ByDay[dayKey] = dayReport
+		// Group by day in the configured time zone, splitting entries that
+		// run past midnight
+		for _, share := range splitByDay(entry.StartTime.In(s.options.location()), duration) {
+			dayKey := share.Date.Format("2006-01-02")
+			if dayReport, exists := report.ByDay[dayKey]; exists {
+				dayReport.TotalDuration += share.Duration
+				dayReport.Entries = append(dayReport.Entries, entry)
+				report.ByDay[dayKey] = dayReport
+			} else {
+				report.ByDay[dayKey] = DayTimeReport{
+					Date:          share.Date,
+					TotalDuration: share.Duration,
+					Entries:       []*domain.TimeEntry{entry},
+				}
+			}
+		}
+	}
+
+	if err := s.nameProjects(ctx, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// GenerateSummaryReport totals the time tracked in the given range by task,
+// category, and project, letting the database do the summing. It is much
+// cheaper than GenerateReport over long ranges, but leaves the report's
+// entries and days empty.
+func (s *Service) GenerateSummaryReport(ctx context.Context, startDate, endDate time.Time, options ReportOptions) (*TimeReport, error) {
+	filter := domain.TimeEntryFilter{StartAfter: &startDate, EndBefore: &endDate}
+	totals, err := s.timeEntryRepo.SumDurations(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum time entries for report: %w", err)
+	}
+
+	running, err := s.runningEntryIn(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// The running timer has no stored duration, so it is added as it stands
+	if running != nil && options.IncludeActive {
+		totals = append(totals, &domain.TimeTotal{
+			TaskID:    running.TaskID,
+			Category:  running.Category,
+			ProjectID: running.ProjectID,
+			Duration:  running.GetDuration(),
+			Entries:   1,
+		})
+	}
+
+	report := &TimeReport{
+		ByTask:     make(map[string]TaskTimeReport),
+		ByProject:  make(map[string]ProjectTimeReport),
+		ByDay:      make(map[string]DayTimeReport),
+		ByCategory: make(map[string]CategoryTimeReport),
+	}
+	if !options.IncludeActive {
+		report.Running = running
+	}
+
+	for _, total := range totals {
+		report.TotalDuration += total.Duration
+
+		if total.TaskID == "" {
+			categoryReport := report.ByCategory[total.Category]
+			categoryReport.Category = total.Category
+			categoryReport.TotalDuration += total.Duration
+			report.ByCategory[total.Category] = categoryReport
 		} else {
-			report.ByDay[dayKey] = DayTimeReport{
-				Date:          entry.StartTime.Truncate(24 * time.Hour),
-				TotalDuration: duration,
-				Entries:       []*domain.TimeEntry{entry},
+			taskReport, exists := report.ByTask[total.TaskID]
+			if !exists {
+				taskReport = s.newTaskTimeReport(ctx, total.TaskID)
 			}
+			taskReport.TotalDuration += total.Duration
+			report.ByTask[total.TaskID] = taskReport
 		}
+
+		projectReport := report.ByProject[total.ProjectID]
+		projectReport.ProjectID = total.ProjectID
+		projectReport.ProjectName = total.ProjectID // replaced by nameProjects
+		projectReport.TotalDuration += total.Duration
+		report.ByProject[total.ProjectID] = projectReport
+	}
+
+	if err := s.nameProjects(ctx, report); err != nil {
+		return nil, err
 	}
 
 	return report, nil
 }
 
+// runningEntryIn returns the running timer if it started in the range from
+// start up to end, and nil otherwise
+func (s *Service) runningEntryIn(ctx context.Context, start, end time.Time) (*domain.TimeEntry, error) {
+	entry, err := s.timeEntryRepo.GetActive(ctx)
+	if err == domain.ErrNoActiveTimeEntry {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active time entry: %w", err)
+	}
+	if entry.StartTime.Before(start) || !entry.StartTime.Before(end) {
+		return nil, nil
+	}
+	return entry, nil
+}
+
+// newTaskTimeReport starts the report line for a task, titled after it; a
+// task that no longer exists is shown by its ID
+func (s *Service) newTaskTimeReport(ctx context.Context, taskID string) TaskTimeReport {
+	taskReport := TaskTimeReport{TaskID: taskID, TaskTitle: taskID}
+	if task, err := s.taskRepo.GetByID(ctx, taskID); err == nil {
+		taskReport.TaskTitle = task.Title
+		taskReport.Estimate = task.Estimate
+		if task.Estimate > 0 {
+			if tracked, err := s.GetTrackedTime(ctx, taskID); err == nil {
+				taskReport.Variance = task.EstimateVariance(tracked)
+			}
+		}
+	}
+	return taskReport
+}
+
+// nameProjects replaces the project IDs standing in for names in the report
+// with the projects' names, fetching them all in one query
+func (s *Service) nameProjects(ctx context.Context, report *TimeReport) error {
+	if s.projectRepo == nil || len(report.ByProject) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(report.ByProject))
+	for id := range report.ByProject {
+		ids = append(ids, id)
+	}
+	projects, err := s.projectRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get projects for report: %w", err)
+	}
+
+	for id, projectReport := range report.ByProject {
+		if project, ok := projects[id]; ok {
+			projectReport.ProjectName = project.Name
+			report.ByProject[id] = projectReport
+		}
+	}
+	return nil
+}
+
+// Round rounds each task, category, project, and day total for billing. The
+// report total becomes the sum of the rounded task and category totals so
+// that an invoice built from those lines adds up; entries keep their exact
+// durations.
+func (r *TimeReport) Round(rounding Rounding) {
+	if rounding.Increment <= 0 {
+		return
+	}
+	r.Rounding = rounding
+
+	r.TotalDuration = 0
+	for key, taskReport := range r.ByTask {
+		taskReport.TotalDuration = RoundDuration(taskReport.TotalDuration, rounding.Increment, rounding.Mode)
+		r.ByTask[key] = taskReport
+		r.TotalDuration += taskReport.TotalDuration
+	}
+	for key, categoryReport := range r.ByCategory {
+		categoryReport.TotalDuration = RoundDuration(categoryReport.TotalDuration, rounding.Increment, rounding.Mode)
+		r.ByCategory[key] = categoryReport
+		r.TotalDuration += categoryReport.TotalDuration
+	}
+	for key, projectReport := range r.ByProject {
+		projectReport.TotalDuration = RoundDuration(projectReport.TotalDuration, rounding.Increment, rounding.Mode)
+		r.ByProject[key] = projectReport
+	}
+	for key, dayReport := range r.ByDay {
+		dayReport.TotalDuration = RoundDuration(dayReport.TotalDuration, rounding.Increment, rounding.Mode)
+		r.ByDay[key] = dayReport
+	}
+}
+
+// dayShare is the part of a time entry that falls on one calendar day
+type dayShare struct {
+	Date     time.Time
+	Duration time.Duration
+}
+
+// splitByDay splits the span starting at start and lasting duration at each
+// local midnight it crosses
+func splitByDay(start time.Time, duration time.Duration) []dayShare {
+	end := start.Add(duration)
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+
+	var shares []dayShare
+	for current := start; ; {
+		nextDay := day.AddDate(0, 0, 1)
+		if !end.After(nextDay) {
+			shares = append(shares, dayShare{Date: day, Duration: end.Sub(current)})
+			return shares
+		}
+		shares = append(shares, dayShare{Date: day, Duration: nextDay.Sub(current)})
+		current, day = nextDay, nextDay
+	}
+}
+
+// ReportPeriod names the range a report covers
+type ReportPeriod string
+
+const (
+	ReportToday     ReportPeriod = "today"
+	ReportYesterday ReportPeriod = "yesterday"
+	ReportWeek      ReportPeriod = "week"
+	ReportMonth     ReportPeriod = "month"
+)
+
+// ReportRange returns the start and end of period, in the configured time
+// zone and week start; unknown periods cover today
+func (s *Service) ReportRange(period ReportPeriod) (time.Time, time.Time) {
+	now := s.options.Now()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch period {
+	case ReportYesterday:
+		start := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, now.Location())
+		return start, start.AddDate(0, 0, 1)
+	case ReportWeek:
+		start := StartOfWeek(now, s.options.WeekStart)
+		return start, start.AddDate(0, 0, 7)
+	case ReportMonth:
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		return start, start.AddDate(0, 1, 0)
+	default:
+		return startOfDay, startOfDay.AddDate(0, 0, 1)
+	}
+}
+
 // GetTodayReport generates a report for today's time tracking
 func (s *Service) GetTodayReport(ctx context.Context) (*TimeReport, error) {
-	now := time.Now()
-	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	endOfDay := startOfDay.Add(24 * time.Hour)
+	start, end := s.ReportRange(ReportToday)
+	return s.GenerateReport(ctx, start, end, ReportOptions{})
+}
 
-	return s.GenerateReport(ctx, startOfDay, endOfDay)
+// GetYesterdayReport generates a report for yesterday's time tracking
+func (s *Service) GetYesterdayReport(ctx context.Context) (*TimeReport, error) {
+	start, end := s.ReportRange(ReportYesterday)
+	return s.GenerateReport(ctx, start, end, ReportOptions{})
 }
 
 // GetWeekReport generates a report for this week's time tracking
 func (s *Service) GetWeekReport(ctx context.Context) (*TimeReport, error) {
-	now := time.Now()
-
-	// Calculate start of week (Monday)
-	weekday := int(now.Weekday())
-	if weekday == 0 { // Sunday
-		weekday = 7
-	}
-	startOfWeek := now.AddDate(0, 0, 1-weekday).Truncate(24 * time.Hour)
-	endOfWeek := startOfWeek.Add(7 * 24 * time.Hour)
-
-	return s.GenerateReport(ctx, startOfWeek, endOfWeek)
+	start, end := s.ReportRange(ReportWeek)
+	return s.GenerateReport(ctx, start, end, ReportOptions{})
 }
 
 // GetMonthReport generates a report for this month's time tracking
 func (s *Service) GetMonthReport(ctx context.Context) (*TimeReport, error) {
-	now := time.Now()
-	startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
-	endOfMonth := startOfMonth.AddDate(0, 1, 0)
-
-	return s.GenerateReport(ctx, startOfMonth, endOfMonth)
+	start, end := s.ReportRange(ReportMonth)
+	return s.GenerateReport(ctx, start, end, ReportOptions{})
 }
 
 // FormatDuration formats a duration in a human-readable way
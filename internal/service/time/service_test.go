@@ -0,0 +1,599 @@
+package time
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+	"github.com/adriannajera/project-manager-cli/internal/repository/memory"
+)
+
+// stubTimeEntryRepository returns a fixed set of entries from List
+type stubTimeEntryRepository struct {
+	domain.TimeEntryRepository
+	entries []*domain.TimeEntry
+}
+
+func (r *stubTimeEntryRepository) GetActive(ctx context.Context) (*domain.TimeEntry, error) {
+	for _, entry := range r.entries {
+		if entry.IsActive() {
+			return entry, nil
+		}
+	}
+	return nil, domain.ErrNoActiveTimeEntry
+}
+
+func (r *stubTimeEntryRepository) Update(ctx context.Context, entry *domain.TimeEntry) error {
+	return nil
+}
+
+func (r *stubTimeEntryRepository) Create(ctx context.Context, entry *domain.TimeEntry) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *stubTimeEntryRepository) List(ctx context.Context, filter domain.TimeEntryFilter) ([]*domain.TimeEntry, error) {
+	return r.entries, nil
+}
+
+// stubTaskRepository reports every task as missing
+type stubTaskRepository struct {
+	domain.TaskRepository
+}
+
+func (r *stubTaskRepository) GetByID(ctx context.Context, id string) (*domain.Task, error) {
+	return nil, domain.ErrTaskNotFound
+}
+
+// stubProjectRepository serves projects from a map and counts lookups
+type stubProjectRepository struct {
+	domain.ProjectRepository
+	projects map[string]*domain.Project
+	lookups  int
+}
+
+func (r *stubProjectRepository) GetByIDs(ctx context.Context, ids []string) (map[string]*domain.Project, error) {
+	r.lookups++
+	found := make(map[string]*domain.Project)
+	for _, id := range ids {
+		if project, ok := r.projects[id]; ok {
+			found[id] = project
+		}
+	}
+	return found, nil
+}
+
+func TestGenerateReportSplitsOvernightEntries(t *testing.T) {
+	start := time.Date(2024, 3, 4, 23, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+
+	entry := domain.NewTimeEntry("task-1", "", "Late deploy")
+	entry.StartTime = start
+	entry.EndTime = &end
+	entry.Duration = end.Sub(start)
+
+	service := NewService(&stubTimeEntryRepository{entries: []*domain.TimeEntry{entry}}, &stubTaskRepository{}, nil, Options{})
+	report, err := service.GenerateReport(context.Background(), start.AddDate(0, 0, -1), end.AddDate(0, 0, 1), ReportOptions{})
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+
+	if report.TotalDuration != 2*time.Hour {
+		t.Errorf("Expected total of 2h, got %s", report.TotalDuration)
+	}
+
+	expected := map[string]time.Duration{
+		"2024-03-04": time.Hour,
+		"2024-03-05": time.Hour,
+	}
+	if len(report.ByDay) != len(expected) {
+		t.Fatalf("Expected %d days, got %d", len(expected), len(report.ByDay))
+	}
+	for day, duration := range expected {
+		if got := report.ByDay[day].TotalDuration; got != duration {
+			t.Errorf("Expected %s on %s, got %s", duration, day, got)
+		}
+	}
+}
+
+func TestSplitByDay(t *testing.T) {
+	start := time.Date(2024, 3, 4, 22, 30, 0, 0, time.UTC)
+
+	// Within a single day
+	if shares := splitByDay(start, time.Hour); len(shares) != 1 || shares[0].Duration != time.Hour {
+		t.Errorf("Expected a single 1h share, got %v", shares)
+	}
+
+	// Ending exactly at midnight stays on the start day
+	if shares := splitByDay(start, 90*time.Minute); len(shares) != 1 {
+		t.Errorf("Expected a single share for an entry ending at midnight, got %v", shares)
+	}
+
+	// Spanning two midnights
+	shares := splitByDay(start, 26*time.Hour)
+	if len(shares) != 3 {
+		t.Fatalf("Expected 3 shares, got %d", len(shares))
+	}
+	expected := []time.Duration{90 * time.Minute, 24 * time.Hour, 30 * time.Minute}
+	for i, share := range shares {
+		if share.Duration != expected[i] {
+			t.Errorf("Share %d: expected %s, got %s", i, expected[i], share.Duration)
+		}
+	}
+}
+
+func TestStopTimeTrackingCappedEndsAtMaxDuration(t *testing.T) {
+	entry := domain.NewTimeEntry("task-1", "", "Forgotten timer")
+	entry.StartTime = time.Now().Add(-30 * time.Hour)
+
+	service := NewService(&stubTimeEntryRepository{entries: []*domain.TimeEntry{entry}}, &stubTaskRepository{}, nil, Options{MaxTimerDuration: 8 * time.Hour})
+	stopped, err := service.StopTimeTrackingCapped(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to stop time tracking: %v", err)
+	}
+
+	if stopped.GetDuration() != 8*time.Hour {
+		t.Errorf("Expected capped duration of 8h, got %s", stopped.GetDuration())
+	}
+	if !stopped.EndTime.Equal(entry.StartTime.Add(8 * time.Hour)) {
+		t.Errorf("Expected end time at start + 8h, got %s", stopped.EndTime)
+	}
+	if service.ExceedsMaxDuration(stopped) {
+		t.Error("Expected capped entry to be within the max duration")
+	}
+}
+
+func TestRoundDuration(t *testing.T) {
+	tests := []struct {
+		duration  time.Duration
+		increment time.Duration
+		mode      RoundingMode
+		expected  time.Duration
+	}{
+		{52 * time.Minute, 15 * time.Minute, RoundNearest, 45 * time.Minute},
+		{53 * time.Minute, 15 * time.Minute, RoundNearest, time.Hour},
+		{46 * time.Minute, 15 * time.Minute, RoundUp, time.Hour},
+		{45 * time.Minute, 15 * time.Minute, RoundUp, 45 * time.Minute},
+		{59 * time.Minute, 15 * time.Minute, RoundDown, 45 * time.Minute},
+		{52 * time.Minute, 0, RoundUp, 52 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := RoundDuration(tt.duration, tt.increment, tt.mode); got != tt.expected {
+			t.Errorf("RoundDuration(%s, %s, %s) = %s, expected %s", tt.duration, tt.increment, tt.mode, got, tt.expected)
+		}
+	}
+}
+
+func TestStartOfWeek(t *testing.T) {
+	tests := []struct {
+		name      string
+		at        time.Time
+		weekStart WeekStart
+		expected  time.Time
+	}{
+		{"sunday in monday week", time.Date(2024, 3, 10, 22, 0, 0, 0, time.UTC), WeekStartMonday, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"sunday in sunday week", time.Date(2024, 3, 10, 22, 0, 0, 0, time.UTC), WeekStartSunday, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{"saturday in sunday week", time.Date(2024, 3, 9, 23, 0, 0, 0, time.UTC), WeekStartSunday, time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{"monday in monday week", time.Date(2024, 3, 11, 0, 30, 0, 0, time.UTC), WeekStartMonday, time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{"monday in sunday week", time.Date(2024, 3, 11, 0, 30, 0, 0, time.UTC), WeekStartSunday, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{"zero value means monday", time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC), "", time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StartOfWeek(tt.at, tt.weekStart); !got.Equal(tt.expected) {
+				t.Errorf("Expected week to start %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGenerateReportGroupsDaysInConfiguredTimezone(t *testing.T) {
+	// 04:00-06:00 UTC is 23:00-01:00 five hours west of UTC
+	start := time.Date(2024, 3, 5, 4, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+
+	entry := domain.NewTimeEntry("task-1", "", "Evening work")
+	entry.StartTime = start
+	entry.EndTime = &end
+	entry.Duration = end.Sub(start)
+
+	service := NewService(&stubTimeEntryRepository{entries: []*domain.TimeEntry{entry}}, &stubTaskRepository{}, nil, Options{
+		Location: time.FixedZone("UTC-5", -5*60*60),
+	})
+	report, err := service.GenerateReport(context.Background(), start.AddDate(0, 0, -1), end.AddDate(0, 0, 1), ReportOptions{})
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+
+	for _, day := range []string{"2024-03-04", "2024-03-05"} {
+		if got := report.ByDay[day].TotalDuration; got != time.Hour {
+			t.Errorf("Expected 1h on %s, got %s", day, got)
+		}
+	}
+}
+
+func TestStartTimeTrackingReportsMissingTask(t *testing.T) {
+	service := NewService(&stubTimeEntryRepository{}, &stubTaskRepository{}, nil, Options{})
+	_, err := service.StartTimeTracking(context.Background(), StartTimeEntryInput{TaskID: "missing"})
+	if !errors.Is(err, domain.ErrTaskNotFound) {
+		t.Errorf("Expected ErrTaskNotFound, got %v", err)
+	}
+}
+
+func TestStartTimeTrackingDefaultsDescriptionToTaskTitle(t *testing.T) {
+	store := memory.NewStore()
+	taskRepo := memory.NewTaskRepository(store)
+	entryRepo := memory.NewTimeEntryRepository(store)
+	service := NewService(entryRepo, taskRepo, store, Options{})
+	ctx := context.Background()
+
+	task := domain.NewTask("Write release notes", "")
+	taskRepo.Create(ctx, task)
+
+	entry, err := service.StartTimeTracking(ctx, StartTimeEntryInput{TaskID: task.ID})
+	if err != nil {
+		t.Fatalf("Failed to start time tracking: %v", err)
+	}
+	if entry.Description != "Write release notes" {
+		t.Errorf("Expected the task title as description, got %q", entry.Description)
+	}
+	service.StopTimeTracking(ctx)
+
+	entry, err = service.StartTimeTracking(ctx, StartTimeEntryInput{TaskID: task.ID, Description: "Changelog"})
+	if err != nil {
+		t.Fatalf("Failed to start time tracking: %v", err)
+	}
+	if entry.Description != "Changelog" {
+		t.Errorf("Expected the given description to be kept, got %q", entry.Description)
+	}
+}
+
+func TestStartTimeTrackingRequiresTaskOrCategory(t *testing.T) {
+	service := NewService(&stubTimeEntryRepository{}, &stubTaskRepository{}, nil, Options{})
+	ctx := context.Background()
+
+	if _, err := service.StartTimeTracking(ctx, StartTimeEntryInput{}); !errors.Is(err, domain.ErrTaskOrCategory) {
+		t.Errorf("Expected ErrTaskOrCategory without task or category, got %v", err)
+	}
+	if _, err := service.StartTimeTracking(ctx, StartTimeEntryInput{TaskID: "task-1", Category: "meetings"}); !errors.Is(err, domain.ErrTaskOrCategory) {
+		t.Errorf("Expected ErrTaskOrCategory with both task and category, got %v", err)
+	}
+
+	entry, err := service.StartTimeTracking(ctx, StartTimeEntryInput{Category: "meetings"})
+	if err != nil {
+		t.Fatalf("Failed to start category time: %v", err)
+	}
+	if entry.TaskID != "" || entry.Category != "meetings" {
+		t.Errorf("Expected a meetings entry without a task, got task %q category %q", entry.TaskID, entry.Category)
+	}
+}
+
+func TestGenerateReportGroupsCategoryEntries(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	end := start.Add(30 * time.Minute)
+
+	entry := domain.NewCategoryTimeEntry("meetings", "Standup")
+	entry.StartTime = start
+	entry.EndTime = &end
+	entry.Duration = end.Sub(start)
+
+	service := NewService(&stubTimeEntryRepository{entries: []*domain.TimeEntry{entry}}, &stubTaskRepository{}, nil, Options{})
+	report, err := service.GenerateReport(context.Background(), start.Add(-time.Hour), end.Add(time.Hour), ReportOptions{})
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+
+	if len(report.ByTask) != 0 {
+		t.Errorf("Expected no task groups, got %d", len(report.ByTask))
+	}
+	if got := report.ByCategory["meetings"].TotalDuration; got != 30*time.Minute {
+		t.Errorf("Expected 30m of meetings, got %s", got)
+	}
+}
+
+// failingUpdateTaskRepository finds a task but fails to save it
+type failingUpdateTaskRepository struct {
+	domain.TaskRepository
+	task *domain.Task
+}
+
+func (r *failingUpdateTaskRepository) GetByID(ctx context.Context, id string) (*domain.Task, error) {
+	return r.task, nil
+}
+
+func (r *failingUpdateTaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	return errors.New("disk full")
+}
+
+// stubTransactor restores the stub repository's entries when fn fails, as a
+// rolled back transaction would
+type stubTransactor struct {
+	repo *stubTimeEntryRepository
+}
+
+func (t *stubTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	saved := append([]*domain.TimeEntry(nil), t.repo.entries...)
+	if err := fn(ctx); err != nil {
+		t.repo.entries = saved
+		return err
+	}
+	return nil
+}
+
+func TestStartTimeTrackingRollsBackWhenTaskUpdateFails(t *testing.T) {
+	entryRepo := &stubTimeEntryRepository{}
+	taskRepo := &failingUpdateTaskRepository{task: domain.NewTask("Deploy", "")}
+	service := NewService(entryRepo, taskRepo, &stubTransactor{repo: entryRepo}, Options{})
+
+	if _, err := service.StartTimeTracking(context.Background(), StartTimeEntryInput{TaskID: taskRepo.task.ID}); err == nil {
+		t.Fatal("Expected an error when the task status cannot be saved")
+	}
+
+	if len(entryRepo.entries) != 0 {
+		t.Errorf("Expected the created entry to be rolled back, found %d entries", len(entryRepo.entries))
+	}
+}
+
+func TestGenerateReportNamesProjectsInOneLookup(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	var entries []*domain.TimeEntry
+	for _, projectID := range []string{"p1", "p2", "p1", "gone"} {
+		entry := domain.NewTimeEntry("task-1", projectID, "Work")
+		entry.StartTime = start
+		entry.EndTime = &end
+		entry.Duration = time.Hour
+		entries = append(entries, entry)
+	}
+
+	projects := &stubProjectRepository{projects: map[string]*domain.Project{
+		"p1": {ID: "p1", Name: "Website"},
+		"p2": {ID: "p2", Name: "Mobile"},
+	}}
+	service := NewService(&stubTimeEntryRepository{entries: entries}, &stubTaskRepository{}, nil, Options{})
+	service.SetProjectRepository(projects)
+
+	report, err := service.GenerateReport(context.Background(), start.AddDate(0, 0, -1), end.AddDate(0, 0, 1), ReportOptions{})
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+
+	if projects.lookups != 1 {
+		t.Errorf("Expected one project lookup, got %d", projects.lookups)
+	}
+	expected := map[string]string{"p1": "Website", "p2": "Mobile", "gone": "gone"}
+	for id, name := range expected {
+		if got := report.ByProject[id].ProjectName; got != name {
+			t.Errorf("Expected project %s to be named %q, got %q", id, name, got)
+		}
+	}
+	if report.ByProject["p1"].TotalDuration != 2*time.Hour {
+		t.Errorf("Expected 2h on p1, got %s", report.ByProject["p1"].TotalDuration)
+	}
+}
+
+func TestGenerateSummaryReportMatchesDetailedTotals(t *testing.T) {
+	store := memory.NewStore()
+	taskRepo := memory.NewTaskRepository(store)
+	entryRepo := memory.NewTimeEntryRepository(store)
+	ctx := context.Background()
+
+	task := domain.NewTask("Deploy", "")
+	taskRepo.Create(ctx, task)
+
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	for i, length := range []time.Duration{time.Hour, 20 * time.Minute, 45 * time.Minute} {
+		entry := domain.NewTimeEntry(task.ID, "project-1", "")
+		if i == 2 {
+			entry = domain.NewCategoryTimeEntry("meetings", "")
+		}
+		entry.StartTime = start.Add(time.Duration(i) * 2 * time.Hour)
+		entry.StopAt(entry.StartTime.Add(length))
+		entryRepo.Create(ctx, entry)
+	}
+
+	service := NewService(entryRepo, taskRepo, store, Options{})
+	from, to := start.Add(-time.Hour), start.AddDate(0, 0, 1)
+	detailed, err := service.GenerateReport(ctx, from, to, ReportOptions{})
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+	summary, err := service.GenerateSummaryReport(ctx, from, to, ReportOptions{})
+	if err != nil {
+		t.Fatalf("Failed to generate summary report: %v", err)
+	}
+
+	if summary.TotalDuration != detailed.TotalDuration {
+		t.Errorf("Expected total %s, got %s", detailed.TotalDuration, summary.TotalDuration)
+	}
+	if got := summary.ByTask[task.ID]; got.TotalDuration != 80*time.Minute || got.TaskTitle != "Deploy" {
+		t.Errorf("Expected 1h20m on Deploy, got %s on %q", got.TotalDuration, got.TaskTitle)
+	}
+	if got := summary.ByCategory["meetings"].TotalDuration; got != 45*time.Minute {
+		t.Errorf("Expected 45m of meetings, got %s", got)
+	}
+	for id, projectReport := range detailed.ByProject {
+		if got := summary.ByProject[id].TotalDuration; got != projectReport.TotalDuration {
+			t.Errorf("Expected %s for project %q, got %s", projectReport.TotalDuration, id, got)
+		}
+	}
+	if len(summary.Entries) != 0 {
+		t.Errorf("Expected a summary without entries, got %d", len(summary.Entries))
+	}
+}
+
+func TestReportsLeaveOutTheRunningTimer(t *testing.T) {
+	store := memory.NewStore()
+	taskRepo := memory.NewTaskRepository(store)
+	entryRepo := memory.NewTimeEntryRepository(store)
+	ctx := context.Background()
+
+	now := time.Now()
+	done := domain.NewCategoryTimeEntry("meetings", "")
+	done.StartTime = now.Add(-3 * time.Hour)
+	done.StopAt(done.StartTime.Add(time.Hour))
+	entryRepo.Create(ctx, done)
+
+	running := domain.NewCategoryTimeEntry("email", "")
+	running.StartTime = now.Add(-30 * time.Minute)
+	entryRepo.Create(ctx, running)
+
+	service := NewService(entryRepo, taskRepo, store, Options{})
+	from, to := now.Add(-24*time.Hour), now.Add(24*time.Hour)
+
+	generators := map[string]func(context.Context, time.Time, time.Time, ReportOptions) (*TimeReport, error){
+		"detailed": service.GenerateReport,
+		"summary":  service.GenerateSummaryReport,
+	}
+	for name, generate := range generators {
+		report, err := generate(ctx, from, to, ReportOptions{})
+		if err != nil {
+			t.Fatalf("%s: failed to generate report: %v", name, err)
+		}
+		if report.TotalDuration != time.Hour {
+			t.Errorf("%s: expected only the completed hour, got %s", name, report.TotalDuration)
+		}
+		if report.Running == nil || report.Running.ID != running.ID {
+			t.Errorf("%s: expected the running timer to be reported as not counted", name)
+		}
+
+		report, err = generate(ctx, from, to, ReportOptions{IncludeActive: true})
+		if err != nil {
+			t.Fatalf("%s: failed to generate report: %v", name, err)
+		}
+		if report.TotalDuration < 90*time.Minute || report.Running != nil {
+			t.Errorf("%s: expected the running timer to be counted, got %s", name, report.TotalDuration)
+		}
+	}
+}
+
+func TestReportsOfPastRangesLeaveOutTheRunningTimer(t *testing.T) {
+	store := memory.NewStore()
+	entryRepo := memory.NewTimeEntryRepository(store)
+	ctx := context.Background()
+
+	now := time.Now()
+	yesterday := domain.NewCategoryTimeEntry("meetings", "")
+	yesterday.StartTime = now.Add(-26 * time.Hour)
+	yesterday.StopAt(yesterday.StartTime.Add(time.Hour))
+	entryRepo.Create(ctx, yesterday)
+
+	running := domain.NewCategoryTimeEntry("email", "")
+	running.StartTime = now.Add(-30 * time.Minute)
+	entryRepo.Create(ctx, running)
+
+	service := NewService(entryRepo, memory.NewTaskRepository(store), store, Options{})
+	from, to := now.Add(-48*time.Hour), now.Add(-2*time.Hour)
+
+	generators := map[string]func(context.Context, time.Time, time.Time, ReportOptions) (*TimeReport, error){
+		"detailed": service.GenerateReport,
+		"summary":  service.GenerateSummaryReport,
+	}
+	for name, generate := range generators {
+		for _, includeActive := range []bool{false, true} {
+			report, err := generate(ctx, from, to, ReportOptions{IncludeActive: includeActive})
+			if err != nil {
+				t.Fatalf("%s: failed to generate report: %v", name, err)
+			}
+			if report.TotalDuration != time.Hour {
+				t.Errorf("%s (include active %v): expected only yesterday's hour, got %s", name, includeActive, report.TotalDuration)
+			}
+			if report.Running != nil {
+				t.Errorf("%s (include active %v): expected no note about a timer outside the range", name, includeActive)
+			}
+		}
+	}
+}
+
+func TestReportVarianceCountsAllTrackedTime(t *testing.T) {
+	store := memory.NewStore()
+	taskRepo := memory.NewTaskRepository(store)
+	entryRepo := memory.NewTimeEntryRepository(store)
+	ctx := context.Background()
+
+	task := domain.NewTask("Write migration", "")
+	task.Estimate = time.Hour
+	taskRepo.Create(ctx, task)
+
+	now := time.Now()
+	for _, start := range []time.Time{now.Add(-72 * time.Hour), now.Add(-3 * time.Hour)} {
+		entry := domain.NewTimeEntry(task.ID, "", "")
+		entry.StartTime = start
+		entry.StopAt(start.Add(45 * time.Minute))
+		entryRepo.Create(ctx, entry)
+	}
+
+	service := NewService(entryRepo, taskRepo, store, Options{})
+	if tracked, err := service.GetTrackedTime(ctx, task.ID); err != nil || tracked != 90*time.Minute {
+		t.Fatalf("Expected 1h30m tracked, got %s (%v)", tracked, err)
+	}
+
+	report, err := service.GenerateReport(ctx, now.Add(-24*time.Hour), now, ReportOptions{})
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+	taskReport := report.ByTask[task.ID]
+	if taskReport.TotalDuration != 45*time.Minute {
+		t.Errorf("Expected 45m in the report's range, got %s", taskReport.TotalDuration)
+	}
+	if taskReport.Variance != 30*time.Minute {
+		t.Errorf("Expected 30m over the estimate in total, got %s", taskReport.Variance)
+	}
+}
+
+func TestDeleteTimeEntries(t *testing.T) {
+	store := memory.NewStore()
+	taskRepo := memory.NewTaskRepository(store)
+	entryRepo := memory.NewTimeEntryRepository(store)
+	service := NewService(entryRepo, taskRepo, store, Options{})
+	ctx := context.Background()
+
+	track := func(taskID string) *domain.TimeEntry {
+		t.Helper()
+		entry := domain.NewTimeEntry(taskID, "", "")
+		entry.StartTime = time.Now().Add(-2 * time.Hour)
+		entry.StopAt(entry.StartTime.Add(30 * time.Minute))
+		if err := entryRepo.Create(ctx, entry); err != nil {
+			t.Fatalf("Failed to create time entry: %v", err)
+		}
+		return entry
+	}
+	first, second := track("task-a"), track("task-a")
+	other := track("task-b")
+
+	// A single entry
+	if err := service.DeleteTimeEntry(ctx, first.ID); err != nil {
+		t.Fatalf("DeleteTimeEntry failed: %v", err)
+	}
+	if _, err := service.GetTimeEntry(ctx, first.ID); !errors.Is(err, domain.ErrTimeEntryNotFound) {
+		t.Errorf("Expected the deleted entry to be gone, got %v", err)
+	}
+	if err := service.DeleteTimeEntry(ctx, first.ID); !errors.Is(err, domain.ErrTimeEntryNotFound) {
+		t.Errorf("Expected ErrTimeEntryNotFound deleting it again, got %v", err)
+	}
+
+	// Every entry of a task, leaving other tasks' entries alone
+	track("task-a")
+	deleted, err := service.DeleteTimeEntriesByTask(ctx, "task-a")
+	if err != nil || deleted != 2 {
+		t.Fatalf("Expected 2 entries deleted, got %d (%v)", deleted, err)
+	}
+	if _, err := service.GetTimeEntry(ctx, second.ID); !errors.Is(err, domain.ErrTimeEntryNotFound) {
+		t.Errorf("Expected the task's entries to be gone, got %v", err)
+	}
+	if _, err := service.GetTimeEntry(ctx, other.ID); err != nil {
+		t.Errorf("Expected another task's entry to be kept, got %v", err)
+	}
+
+	if deleted, err := service.DeleteTimeEntriesByTask(ctx, "task-a"); err != nil || deleted != 0 {
+		t.Errorf("Expected nothing left to delete, got %d (%v)", deleted, err)
+	}
+	if _, err := service.DeleteTimeEntriesByTask(ctx, ""); !errors.Is(err, domain.ErrInvalidTaskID) {
+		t.Errorf("Expected ErrInvalidTaskID without a task, got %v", err)
+	}
+}
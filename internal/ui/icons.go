@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// IconStyle selects the glyphs used for status, priority, and menu icons
+type IconStyle string
+
+const (
+	IconStyleASCII    IconStyle = "ascii"
+	IconStyleEmoji    IconStyle = "emoji"
+	IconStyleNerdFont IconStyle = "nerdfont"
+)
+
+// iconStyle is the active icon set; ASCII works on every terminal
+var iconStyle = IconStyleASCII
+
+// iconSet holds the glyphs for one icon style
+type iconSet struct {
+	status   map[string]string
+	priority [4]string
+	menu     map[string]string
+	// project marks a task's project in lists
+	project string
+}
+
+var iconSets = map[IconStyle]iconSet{
+	IconStyleASCII: {
+		status: map[string]string{
+			"backlog": "[-]",
+			"todo":    "[ ]",
+			"doing":   "[~]",
+			"done":    "[x]",
+			"blocked": "[!]",
+		},
+		priority: [4]string{"[LOW]", "[NORM]", "[HIGH]", "[CRIT]"},
+		menu: map[string]string{
+			"tasks":    "[T]",
+			"projects": "[P]",
+			"time":     "[TM]",
+			"new_task": "[+]",
+			"reports":  "[R]",
+		},
+		project: "*",
+	},
+	IconStyleEmoji: {
+		status: map[string]string{
+			"backlog": "◌",
+			"todo":    "○",
+			"doing":   "◐",
+			"done":    "✓",
+			"blocked": "⊘",
+		},
+		priority: [4]string{"↓", "·", "↑", "‼"},
+		menu: map[string]string{
+			"tasks":    "☰",
+			"projects": "▣",
+			"time":     "◷",
+			"new_task": "✚",
+			"reports":  "▤",
+		},
+		project: "●",
+	},
+	IconStyleNerdFont: {
+		status: map[string]string{
+			"backlog": "\uf1db", // circle-thin
+			"todo":    "\uf10c", // circle-o
+			"doing":   "\uf192", // dot-circle-o
+			"done":    "\uf058", // check-circle
+			"blocked": "\uf05e", // ban
+		},
+		priority: [4]string{
+			"\uf063", // arrow-down
+			"\uf068", // minus
+			"\uf062", // arrow-up
+			"\uf06a", // exclamation-circle
+		},
+		menu: map[string]string{
+			"tasks":    "\uf0ae", // tasks
+			"projects": "\uf07b", // folder
+			"time":     "\uf017", // clock-o
+			"new_task": "\uf067", // plus
+			"reports":  "\uf080", // bar-chart
+		},
+		project: "\uf111", // circle
+	},
+}
+
+// ParseIconStyle validates an icon style name; an empty name means ASCII
+func ParseIconStyle(name string) (IconStyle, error) {
+	if name == "" {
+		return IconStyleASCII, nil
+	}
+	style := IconStyle(strings.ToLower(name))
+	if _, ok := iconSets[style]; !ok {
+		return IconStyleASCII, fmt.Errorf("unknown icon style: %s (must be ascii, emoji, or nerdfont)", name)
+	}
+	return style, nil
+}
+
+// SetIconStyle selects the active icon set. Styles that need Unicode fall
+// back to ASCII when the terminal locale is not UTF-8; the style actually
+// applied is returned.
+func SetIconStyle(name string) (IconStyle, error) {
+	style, err := ParseIconStyle(name)
+	if err != nil {
+		return iconStyle, err
+	}
+	if style != IconStyleASCII && !localeSupportsUnicode() {
+		style = IconStyleASCII
+	}
+	iconStyle = style
+	return style, nil
+}
+
+// localeSupportsUnicode reports whether the locale, resolved the way libc
+// does, uses UTF-8
+func localeSupportsUnicode() bool {
+	for _, env := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if value := os.Getenv(env); value != "" {
+			value = strings.ToUpper(value)
+			return strings.Contains(value, "UTF-8") || strings.Contains(value, "UTF8")
+		}
+	}
+	return false
+}
+
+// statusIcon returns the unstyled icon for a task status
+func statusIcon(status string) string {
+	if icon, ok := iconSets[iconStyle].status[status]; ok {
+		return icon
+	}
+	return iconSets[iconStyle].status["todo"]
+}
+
+// priorityIcon returns the unstyled icon for a priority level
+func priorityIcon(priority int) string {
+	if priority < 0 || priority >= len(iconSets[iconStyle].priority) {
+		priority = 1 // Normal
+	}
+	return iconSets[iconStyle].priority[priority]
+}
+
+// MenuIcon returns the icon for a dashboard menu action
+func MenuIcon(action string) string {
+	return iconSets[iconStyle].menu[action]
+}
+
+// ProjectSwatch renders the project icon in the project's color
+func ProjectSwatch(color string) string {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(MenuIcon("projects"))
+}
+
+// ProjectDot renders a small marker in a project's color for task lists
+func ProjectDot(color string) string {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(iconSets[iconStyle].project)
+}
+
+// StatusLegend renders every status icon next to its name
+func StatusLegend() string {
+	statuses := []string{"backlog", "todo", "doing", "done", "blocked"}
+	parts := make([]string, len(statuses))
+	for i, status := range statuses {
+		parts[i] = FormatStatusIcon(status) + status
+	}
+	return strings.Join(parts, "  ")
+}
+
+// PriorityLegend renders every priority icon next to its name
+func PriorityLegend() string {
+	names := []string{"low", "normal", "high", "critical"}
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = FormatPriorityIcon(i) + " " + name
+	}
+	return strings.Join(parts, "  ")
+}
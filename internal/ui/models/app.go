@@ -2,12 +2,27 @@ package models
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/adriannajera/project-manager-cli/internal/clipboard"
 	"github.com/adriannajera/project-manager-cli/internal/domain"
+	"github.com/adriannajera/project-manager-cli/internal/notes"
+	taskService "github.com/adriannajera/project-manager-cli/internal/service/task"
+	timeService "github.com/adriannajera/project-manager-cli/internal/service/time"
+	"github.com/adriannajera/project-manager-cli/internal/ui"
 )
 
+// messageTimeout is how long error and success messages stay on screen
+const messageTimeout = 4 * time.Second
+
 // ViewState represents the current view state of the application
 type ViewState int
 
@@ -20,6 +35,7 @@ const (
 	TimeTrackingView
 	DashboardView
 	HelpView
+	TemplatePickerView
 )
 
 // AppModel represents the main application model
@@ -35,6 +51,10 @@ type AppModel struct {
 	timeEntryRepo domain.TimeEntryRepository
 	gitRepo       domain.GitRepository
 
+	// Services
+	taskService *taskService.Service
+	timeService *timeService.Service
+
 	// Sub-models
 	taskList    TaskListModel
 	taskDetail  TaskDetailModel
@@ -43,12 +63,23 @@ type AppModel struct {
 	projectForm ProjectFormModel
 	dashboard   DashboardModel
 
+	templatePicker TemplatePickerModel
+	timerPicker    TimerPickerModel
+
 	// Global state
+	projects        []*domain.Project
+	templates       map[string]domain.TaskTemplate
+	showArchived    bool
+	refreshInterval time.Duration
+	editor          string
+	saveFilter      func([]domain.TaskStatus) error
 	selectedTask    *domain.Task
 	selectedProject *domain.Project
 	activeTimeEntry *domain.TimeEntry
+	confirmingQuit  bool
 	error           string
 	success         string
+	messageID       int
 
 	// Key bindings
 	keys AppKeyMap
@@ -152,6 +183,8 @@ func NewAppModel(
 		projectRepo:   projectRepo,
 		timeEntryRepo: timeEntryRepo,
 		gitRepo:       gitRepo,
+		taskService:   taskService.NewService(taskRepo, gitRepo),
+		timeService:   timeService.NewService(timeEntryRepo, taskRepo, nil, timeService.Options{}),
 		keys:          DefaultKeyMap(),
 		taskList:      NewTaskListModel(),
 		taskDetail:    NewTaskDetailModel(),
@@ -162,11 +195,54 @@ func NewAppModel(
 	}
 }
 
+// SetTemplates sets the task templates offered when creating a task
+func (m *AppModel) SetTemplates(templates map[string]domain.TaskTemplate) {
+	m.templates = templates
+}
+
+// SetEditor sets the command notes are opened in, overriding $VISUAL and
+// $EDITOR; empty keeps them
+func (m *AppModel) SetEditor(editor string) {
+	m.editor = editor
+}
+
+// SetShowArchivedProjectTasks controls whether tasks of archived projects
+// appear in the task list and dashboard counts
+func (m *AppModel) SetShowArchivedProjectTasks(show bool) {
+	m.showArchived = show
+}
+
+// SetRefreshInterval makes the TUI reload the current view's data every
+// interval so changes made from the CLI show up; zero disables it
+func (m *AppModel) SetRefreshInterval(interval time.Duration) {
+	m.refreshInterval = interval
+}
+
+// SetTaskService replaces the task service used to create tasks, so that
+// configured options such as sequential IDs apply in the TUI
+func (m *AppModel) SetTaskService(service *taskService.Service) {
+	m.taskService = service
+}
+
+// SetTimeService replaces the time service used to start timers
+func (m *AppModel) SetTimeService(service *timeService.Service) {
+	m.timeService = service
+}
+
+// SetSavedFilter restores the task list's status filter from an earlier
+// session; save is called with each new filter so the next session starts
+// with it
+func (m *AppModel) SetSavedFilter(statuses []domain.TaskStatus, save func([]domain.TaskStatus) error) {
+	m.taskList.SetStatusFilter(statuses)
+	m.saveFilter = save
+}
+
 // Init implements tea.Model
 func (m AppModel) Init() tea.Cmd {
 	return tea.Batch(
 		m.loadActiveTimeEntry(),
 		m.loadInitialData(),
+		m.scheduleRefresh(),
 	)
 }
 
@@ -179,10 +255,25 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.resizeViews()
 
 	case tea.KeyMsg:
+		if m.confirmingQuit {
+			return m.updateQuitConfirm(msg)
+		}
+
+		// Views with text inputs get printable keys; only ctrl+c quits there
+		if m.capturesText() && msg.Type == tea.KeyRunes {
+			break
+		}
+
 		switch {
 		case key.Matches(msg, m.keys.Quit):
+			// Quitting would leave the timer running unseen, so ask first
+			if m.activeTimeEntry != nil {
+				m.confirmingQuit = true
+				return m, nil
+			}
 			return m, tea.Quit
 
 		case key.Matches(msg, m.keys.Help):
@@ -203,15 +294,80 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case ErrorMsg:
 		m.error = string(msg)
-		return m, nil
+		m.success = ""
+		cmd = m.scheduleClearMessage()
+		return m, cmd
 
 	case SuccessMsg:
 		m.success = string(msg)
+		m.error = ""
+		cmd = m.scheduleClearMessage()
+		return m, cmd
+
+	case ClearMessageMsg:
+		// Ignore timers for messages that have since been replaced
+		if msg.ID == m.messageID {
+			m.error = ""
+			m.success = ""
+		}
 		return m, nil
 
 	case ActiveTimeEntryMsg:
 		m.activeTimeEntry = msg.Entry
 		return m, nil
+
+	case RefreshTickMsg:
+		// Reloading under an open form or picker would clobber the input,
+		// so skip this round and try again next tick
+		if m.editing() {
+			return m, m.scheduleRefresh()
+		}
+		return m, tea.Batch(m.scheduleRefresh(), m.refreshCurrentView())
+
+	case ExternalChangeMsg:
+		if m.editing() {
+			return m, nil
+		}
+		return m, m.refreshCurrentView()
+
+	case TaskReloadedMsg:
+		if m.currentView == TaskDetailView && m.selectedTask != nil && m.selectedTask.ID == msg.Task.ID {
+			m.selectedTask = msg.Task
+			m.taskDetail.SetTask(msg.Task)
+		}
+		return m, nil
+
+	case TimerStartedMsg:
+		m.activeTimeEntry = msg.Entry
+		m.currentView = DashboardView
+		m.success = "Timer started for " + msg.Task.Title
+		m.error = ""
+		return m, tea.Batch(m.scheduleClearMessage(), m.loadInitialData())
+
+	case QuickAddMsg:
+		return m, tea.Sequence(m.quickAddTask(msg.Line), m.loadInitialData())
+
+	case DashboardStatsLoadedMsg:
+		m.dashboard.SetStats(msg.Stats)
+		return m, nil
+
+	case TrackedTimeLoadedMsg:
+		m.taskDetail.SetTrackedTime(msg.TaskID, msg.Tracked)
+		return m, nil
+
+	case NotePreviewLoadedMsg:
+		m.taskDetail.SetNotePreview(msg.TaskID, msg.Preview, msg.Truncated, msg.Err)
+		return m, nil
+
+	case CommentsLoadedMsg:
+		m.taskDetail.SetComments(msg.TaskID, msg.Comments)
+		return m, nil
+
+	case ProjectListLoadedMsg:
+		// Keep a copy for the task form's project selector and the task
+		// list's project colors
+		m.projects = msg.Projects
+		m.taskList.SetProjects(msg.Projects)
 	}
 
 	// Delegate to current view
@@ -229,15 +385,20 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "projects":
 				m.currentView = ProjectListView
 				cmds = append(cmds, m.loadProjects())
+			case "time":
+				m.timerPicker = NewTimerPickerModel()
+				m.timerPicker.SetSize(m.width, m.viewHeight())
+				m.currentView = TimeTrackingView
+				cmds = append(cmds, m.loadTimerTasks())
 			case "new_task":
-				m.currentView = TaskFormView
-				m.taskForm = NewTaskFormModel()
+				m.openNewTaskForm()
 			}
 		}
 
 	case TaskListView:
 		m.taskList, cmd = m.taskList.Update(msg)
 		cmds = append(cmds, cmd)
+		cmds = append(cmds, m.syncPreview())
 
 		// Handle task list actions
 		if taskMsg, ok := msg.(TaskActionMsg); ok {
@@ -246,23 +407,28 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.selectedTask = taskMsg.Task
 				m.taskDetail.SetTask(taskMsg.Task)
 				m.currentView = TaskDetailView
+				cmds = append(cmds, m.loadTrackedTime(taskMsg.Task.ID), m.loadComments(taskMsg.Task.ID), m.loadNotePreview(taskMsg.Task))
 			case "new":
-				m.currentView = TaskFormView
-				m.taskForm = NewTaskFormModel()
+				m.openNewTaskForm()
 			case "edit":
 				m.selectedTask = taskMsg.Task
 				m.currentView = TaskFormView
-				m.taskForm = NewTaskFormModel()
-				m.taskForm.LoadTask(taskMsg.Task)
+				m.taskForm = m.newTaskForm(taskMsg.Task)
 			case "delete":
 				cmds = append(cmds, m.deleteTask(taskMsg.Task))
 				cmds = append(cmds, m.loadTasks())
 			case "update":
 				cmds = append(cmds, m.saveTask(taskMsg.Task))
 				cmds = append(cmds, m.loadTasks())
+			case "copy_id":
+				cmds = append(cmds, copyTaskID(taskMsg.Task))
 			}
 		}
 
+		if filterMsg, ok := msg.(TaskFilterChangedMsg); ok {
+			cmds = append(cmds, m.loadTasks(), m.storeFilter(filterMsg.Status))
+		}
+
 	case TaskDetailView:
 		m.taskDetail, cmd = m.taskDetail.Update(msg)
 		cmds = append(cmds, cmd)
@@ -273,8 +439,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "edit":
 				m.selectedTask = taskMsg.Task
 				m.currentView = TaskFormView
-				m.taskForm = NewTaskFormModel()
-				m.taskForm.LoadTask(taskMsg.Task)
+				m.taskForm = m.newTaskForm(taskMsg.Task)
 			case "delete":
 				m.currentView = TaskListView
 				cmds = append(cmds, m.deleteTask(taskMsg.Task))
@@ -282,9 +447,41 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "update":
 				cmds = append(cmds, m.saveTask(taskMsg.Task))
 				m.taskDetail.SetTask(taskMsg.Task)
+			case "open_note":
+				cmds = append(cmds, m.openNote(taskMsg.Task))
+			case "copy_id":
+				cmds = append(cmds, copyTaskID(taskMsg.Task))
 			}
 		}
 
+	case TemplatePickerView:
+		m.templatePicker, cmd = m.templatePicker.Update(msg)
+		cmds = append(cmds, cmd)
+
+		// A template that cannot be applied keeps the picker open, so
+		// another can be chosen
+		if selected, ok := msg.(TemplateSelectedMsg); ok {
+			form := m.newTaskForm(nil)
+			if selected.Template != nil {
+				if err := form.ApplyTemplate(selected.Name, *selected.Template); err != nil {
+					cmds = append(cmds, func() tea.Msg {
+						return ErrorMsg("Failed to apply template: " + err.Error())
+					})
+					break
+				}
+			}
+			m.taskForm = form
+			m.currentView = TaskFormView
+		}
+
+	case TimeTrackingView:
+		m.timerPicker, cmd = m.timerPicker.Update(msg)
+		cmds = append(cmds, cmd)
+
+		if selected, ok := msg.(TimerTaskSelectedMsg); ok {
+			cmds = append(cmds, m.startTimer(selected.Task))
+		}
+
 	case TaskFormView:
 		m.taskForm, cmd = m.taskForm.Update(msg)
 		cmds = append(cmds, cmd)
@@ -292,7 +489,11 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Handle form submission
 		if formMsg, ok := msg.(TaskFormSubmitMsg); ok {
 			m.currentView = TaskListView
-			cmds = append(cmds, m.saveTask(formMsg.Task), m.loadTasks())
+			if formMsg.IsNew {
+				cmds = append(cmds, m.createTask(formMsg.Task), m.loadTasks())
+			} else {
+				cmds = append(cmds, m.saveTask(formMsg.Task), m.loadTasks())
+			}
 		}
 
 	case ProjectListView:
@@ -320,21 +521,191 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View implements tea.Model
 func (m AppModel) View() string {
+	var content string
 	switch m.currentView {
 	case DashboardView:
-		return m.dashboard.View()
+		content = m.dashboard.View()
 	case TaskListView:
-		return m.taskList.View()
+		content = m.renderTaskList()
 	case TaskDetailView:
-		return m.taskDetail.View()
+		content = m.taskDetail.View()
 	case TaskFormView:
-		return m.taskForm.View()
+		content = m.taskForm.View()
 	case ProjectListView:
-		return m.projectList.View()
+		content = m.projectList.View()
+	case TemplatePickerView:
+		content = m.templatePicker.View()
+	case TimeTrackingView:
+		content = m.timerPicker.View()
 	case HelpView:
-		return m.renderHelp()
+		content = m.renderHelp()
 	default:
-		return "Unknown view"
+		content = "Unknown view"
+	}
+
+	return m.renderHeader() + content + "\n" + m.renderFooter()
+}
+
+// updateQuitConfirm answers the prompt shown when quitting with a timer
+// running: y stops the timer and quits, n quits leaving it running, and q or
+// esc cancels. ctrl+c always quits.
+func (m AppModel) updateQuitConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		m.confirmingQuit = false
+		return m, m.stopTimerAndQuit()
+	case "n", "N", "ctrl+c":
+		return m, tea.Quit
+	case "q", "esc":
+		m.confirmingQuit = false
+	}
+	return m, nil
+}
+
+// stopTimerAndQuit stops the running timer and then quits, staying open to
+// show the error when the timer cannot be stopped
+func (m AppModel) stopTimerAndQuit() tea.Cmd {
+	return func() tea.Msg {
+		// A timer already stopped from the CLI leaves nothing to do
+		_, err := m.timeService.StopTimeTracking(context.Background())
+		if err != nil && !errors.Is(err, domain.ErrNoActiveTimeEntry) {
+			return ErrorMsg("Failed to stop timer: " + err.Error())
+		}
+		return tea.Quit()
+	}
+}
+
+// viewChromeHeight is the most lines the header and footer take around a
+// view: the timer bar above it, and the status line and keybar below it
+const viewChromeHeight = 3
+
+// viewHeight returns the lines a view has between the header and footer,
+// or zero while the terminal size is unknown
+func (m AppModel) viewHeight() int {
+	if m.height <= viewChromeHeight {
+		return 0
+	}
+	return m.height - viewChromeHeight
+}
+
+// resizeViews passes the terminal size on to every view
+func (m *AppModel) resizeViews() {
+	height := m.viewHeight()
+	m.dashboard.SetSize(m.width, height)
+	m.taskList.SetSize(m.width, height)
+	m.taskDetail.SetSize(m.width, height)
+	m.taskForm.SetSize(m.width, height)
+	m.projectList.SetSize(m.width, height)
+	m.templatePicker.SetSize(m.width, height)
+	m.timerPicker.SetSize(m.width, height)
+}
+
+// twoPaneMinWidth is the narrowest terminal that shows the selected task's
+// details beside the task list
+const twoPaneMinWidth = 120
+
+// twoPane reports whether the task list is shown with a detail pane
+func (m AppModel) twoPane() bool {
+	return m.currentView == TaskListView && m.width >= twoPaneMinWidth
+}
+
+// syncPreview shows the selected task in the detail pane, loading its
+// tracked time, comments, and note when the selection moves to another task
+func (m *AppModel) syncPreview() tea.Cmd {
+	task := m.taskList.SelectedTask()
+	if !m.twoPane() || task == nil {
+		return nil
+	}
+
+	moved := m.taskDetail.task == nil || m.taskDetail.task.ID != task.ID
+	m.taskDetail.SetTask(task)
+	if !moved {
+		return nil
+	}
+	return tea.Batch(m.loadTrackedTime(task.ID), m.loadComments(task.ID), m.loadNotePreview(task))
+}
+
+// renderTaskList renders the task list, with the selected task's details
+// beside it on wide terminals
+func (m AppModel) renderTaskList() string {
+	task := m.taskList.SelectedTask()
+	if !m.twoPane() || task == nil || m.taskDetail.task == nil || m.taskDetail.task.ID != task.ID {
+		return m.taskList.View()
+	}
+
+	list, detail := m.taskList, m.taskDetail
+	listWidth := m.width / 2
+	detailWidth := m.width - listWidth - ui.PaneStyle.GetHorizontalFrameSize()
+	list.SetSize(listWidth, m.viewHeight())
+	detail.SetSize(detailWidth, m.viewHeight())
+
+	listView := lipgloss.NewStyle().Width(listWidth).Render(list.View())
+	detailView := ui.PaneStyle.Width(detailWidth).Render(detail.View())
+	return lipgloss.JoinHorizontal(lipgloss.Top, listView, detailView)
+}
+
+// renderHeader renders the bar above every view, showing the running timer
+func (m AppModel) renderHeader() string {
+	if m.activeTimeEntry == nil {
+		return ""
+	}
+	timer := fmt.Sprintf("● Timer running: %s", m.activeTimeEntry.GetFormattedDuration())
+	return ui.FrameStyle.Render(ui.SuccessStyle.Render(timer)) + "\n"
+}
+
+// renderFooter renders the status line and the current view's keybar
+func (m AppModel) renderFooter() string {
+	if m.confirmingQuit {
+		prompt := ui.ErrorStyle.Render("A timer is running. Stop it before quitting? (y/n/q)")
+		help := ui.HelpStyle.Render("y: stop timer and quit • n: quit, leave it running • q/esc: cancel")
+		return ui.FrameStyle.Render(prompt + "\n" + help)
+	}
+
+	var lines []string
+	if statusLine := m.renderStatusLine(); statusLine != "" {
+		lines = append(lines, statusLine)
+	}
+	lines = append(lines, ui.HelpStyle.Render(m.helpText()))
+	return ui.FrameStyle.Render(strings.Join(lines, "\n"))
+}
+
+// renderStatusLine renders the latest error or success message, if any
+func (m AppModel) renderStatusLine() string {
+	switch {
+	case m.error != "":
+		return ui.ErrorStyle.Render(m.error)
+	case m.success != "":
+		return ui.SuccessStyle.Render(m.success)
+	default:
+		return ""
+	}
+}
+
+// capturesText reports whether the current view is typing into a text input
+func (m AppModel) capturesText() bool {
+	return m.currentView == TaskFormView || m.currentView == TimeTrackingView ||
+		(m.currentView == DashboardView && m.dashboard.Adding())
+}
+
+// helpText returns the keybar for the current view
+func (m AppModel) helpText() string {
+	switch m.currentView {
+	case DashboardView:
+		return m.dashboard.HelpText()
+	case TaskListView:
+		return m.taskList.HelpText()
+	case TaskDetailView:
+		return m.taskDetail.HelpText()
+	case TaskFormView:
+		return m.taskForm.HelpText()
+	case ProjectListView:
+		return m.projectList.HelpText()
+	case TemplatePickerView:
+		return m.templatePicker.HelpText()
+	case TimeTrackingView:
+		return m.timerPicker.HelpText()
+	default:
+		return "esc: back • q: quit"
 	}
 }
 
@@ -342,6 +713,23 @@ func (m AppModel) View() string {
 type ErrorMsg string
 type SuccessMsg string
 
+// ClearMessageMsg clears the status line if ID still matches the current message
+type ClearMessageMsg struct {
+	ID int
+}
+
+// RefreshTickMsg triggers a periodic reload of the current view's data
+type RefreshTickMsg struct{}
+
+// ExternalChangeMsg reports that a CLI command changed the data, so the
+// current view reloads without waiting for the next refresh
+type ExternalChangeMsg struct{}
+
+// TaskReloadedMsg carries a fresh copy of the task shown in the detail view
+type TaskReloadedMsg struct {
+	Task *domain.Task
+}
+
 type ActiveTimeEntryMsg struct {
 	Entry *domain.TimeEntry
 }
@@ -350,21 +738,109 @@ type DashboardActionMsg struct {
 	Action string
 }
 
+// QuickAddMsg carries a line typed into the dashboard's quick-add bar
+type QuickAddMsg struct {
+	Line string
+}
+
+type DashboardStatsLoadedMsg struct {
+	Stats *DashboardStats
+}
+
+// TrackedTimeLoadedMsg carries the total time tracked against a task
+type TrackedTimeLoadedMsg struct {
+	TaskID  string
+	Tracked time.Duration
+}
+
+// NotePreviewLoadedMsg carries the start of a task's linked note, or the
+// error that kept it from being read
+type NotePreviewLoadedMsg struct {
+	TaskID    string
+	Preview   string
+	Truncated bool
+	Err       error
+}
+
+// CommentsLoadedMsg carries a task's comments, oldest first
+type CommentsLoadedMsg struct {
+	TaskID   string
+	Comments []*domain.Comment
+}
+
 type TaskActionMsg struct {
 	Action string
 	Task   *domain.Task
 }
 
 type TaskFormSubmitMsg struct {
-	Task *domain.Task
+	Task  *domain.Task
+	IsNew bool
 }
 
 // Commands
+
+// scheduleClearMessage bumps the message ID and returns a timer that clears
+// the status line once the message has been visible for messageTimeout
+func (m *AppModel) scheduleClearMessage() tea.Cmd {
+	m.messageID++
+	id := m.messageID
+	return tea.Tick(messageTimeout, func(time.Time) tea.Msg {
+		return ClearMessageMsg{ID: id}
+	})
+}
+
+// scheduleRefresh waits out the refresh interval before the next reload, or
+// does nothing when periodic refresh is off
+func (m AppModel) scheduleRefresh() tea.Cmd {
+	if m.refreshInterval <= 0 {
+		return nil
+	}
+	return tea.Tick(m.refreshInterval, func(time.Time) tea.Msg {
+		return RefreshTickMsg{}
+	})
+}
+
+// editing reports whether the current view holds input that a reload could
+// clobber
+func (m AppModel) editing() bool {
+	switch m.currentView {
+	case TaskFormView, ProjectFormView, TimeTrackingView, TemplatePickerView:
+		return true
+	}
+	return false
+}
+
+// refreshCurrentView reloads the data behind the current view, picking up
+// changes made outside the TUI
+func (m AppModel) refreshCurrentView() tea.Cmd {
+	cmds := []tea.Cmd{m.loadActiveTimeEntry(), m.loadInitialData()}
+	if m.currentView == TaskDetailView && m.selectedTask != nil {
+		taskID := m.selectedTask.ID
+		cmds = append(cmds, m.reloadTask(taskID), m.loadTrackedTime(taskID), m.loadComments(taskID))
+	}
+	return tea.Batch(cmds...)
+}
+
+// reloadTask fetches the task with taskID again. A task deleted in the
+// meantime is left on screen until the user moves on.
+func (m AppModel) reloadTask(taskID string) tea.Cmd {
+	return func() tea.Msg {
+		task, err := m.taskRepo.GetByID(context.Background(), taskID)
+		if err != nil {
+			return nil
+		}
+		return TaskReloadedMsg{Task: task}
+	}
+}
+
 func (m AppModel) loadActiveTimeEntry() tea.Cmd {
 	return func() tea.Msg {
-		// This would call the repository in a real implementation
-		// For now, return nil to avoid compilation errors
-		return ActiveTimeEntryMsg{Entry: nil}
+		entry, err := m.timeEntryRepo.GetActive(context.Background())
+		if err != nil {
+			return ActiveTimeEntryMsg{Entry: nil}
+		}
+		return ActiveTimeEntryMsg{Entry: entry}
 	}
 }
 
@@ -372,28 +848,212 @@ func (m AppModel) loadInitialData() tea.Cmd {
 	return tea.Batch(
 		m.loadTasks(),
 		m.loadProjects(),
+		m.loadDashboardStats(),
 	)
 }
 
+// dashboardRecentTasks is how many recently updated tasks the dashboard lists
+const dashboardRecentTasks = 5
+
+func (m AppModel) loadDashboardStats() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		tasks, err := m.taskRepo.List(ctx, m.defaultTaskFilter())
+		if err != nil {
+			return ErrorMsg("Failed to load tasks: " + err.Error())
+		}
+
+		projects, err := m.projectRepo.List(ctx, domain.ProjectFilter{
+			Status: []domain.ProjectStatus{domain.ProjectStatusActive},
+		})
+		if err != nil {
+			return ErrorMsg("Failed to load projects: " + err.Error())
+		}
+
+		stats := &DashboardStats{
+			StatusCounts:   make(map[domain.TaskStatus]int),
+			ActiveProjects: len(projects),
+		}
+		for _, task := range tasks {
+			stats.StatusCounts[task.Status]++
+			if task.IsOverdue() {
+				stats.Overdue++
+			}
+		}
+
+		if entry, err := m.timeEntryRepo.GetActive(ctx); err == nil && entry != nil {
+			stats.TimerRunning = true
+		}
+
+		recentFilter := m.defaultTaskFilter()
+		recentFilter.SortBy = domain.SortByUpdated
+		recentFilter.Limit = dashboardRecentTasks
+		if stats.Recent, err = m.taskRepo.List(ctx, recentFilter); err != nil {
+			return ErrorMsg("Failed to load tasks: " + err.Error())
+		}
+
+		return DashboardStatsLoadedMsg{Stats: stats}
+	}
+}
+
+// defaultTaskFilter lists every task, leaving out archived projects' tasks
+// unless configured otherwise
+func (m AppModel) defaultTaskFilter() domain.TaskFilter {
+	return domain.TaskFilter{ExcludeArchivedProjects: !m.showArchived}
+}
+
 func (m AppModel) loadTasks() tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
-		tasks, err := m.taskRepo.List(ctx, domain.TaskFilter{})
+		filter := m.defaultTaskFilter()
+		filter.Status = m.taskList.StatusFilter()
+		tasks, err := m.taskRepo.ListWithSubtaskCounts(ctx, filter)
 		if err != nil {
 			return ErrorMsg("Failed to load tasks: " + err.Error())
 		}
-		return TaskListLoadedMsg{Tasks: tasks}
+		return newTaskListLoadedMsg(tasks)
 	}
 }
 
 func (m AppModel) loadTasksForProject(projectID string) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
-		tasks, err := m.taskRepo.List(ctx, domain.TaskFilter{ProjectID: projectID})
+		tasks, err := m.taskRepo.ListWithSubtaskCounts(ctx, domain.TaskFilter{
+			ProjectID: projectID,
+			Status:    m.taskList.StatusFilter(),
+		})
 		if err != nil {
 			return ErrorMsg("Failed to load tasks: " + err.Error())
 		}
-		return TaskListLoadedMsg{Tasks: tasks}
+		return newTaskListLoadedMsg(tasks)
+	}
+}
+
+// storeFilter saves the task list's status filter for the next session
+func (m AppModel) storeFilter(statuses []domain.TaskStatus) tea.Cmd {
+	if m.saveFilter == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := m.saveFilter(statuses); err != nil {
+			return ErrorMsg("Failed to save filter: " + err.Error())
+		}
+		return nil
+	}
+}
+
+// loadTrackedTime loads the total time tracked against a task
+func (m AppModel) loadTrackedTime(taskID string) tea.Cmd {
+	return func() tea.Msg {
+		tracked, err := m.timeService.GetTrackedTime(context.Background(), taskID)
+		if err != nil {
+			return ErrorMsg("Failed to load time entries: " + err.Error())
+		}
+		return TrackedTimeLoadedMsg{TaskID: taskID, Tracked: tracked}
+	}
+}
+
+// loadComments loads the comments left on a task
+func (m AppModel) loadComments(taskID string) tea.Cmd {
+	return func() tea.Msg {
+		comments, err := m.taskRepo.ListComments(context.Background(), taskID)
+		if err != nil {
+			return ErrorMsg("Failed to load comments: " + err.Error())
+		}
+		return CommentsLoadedMsg{TaskID: taskID, Comments: comments}
+	}
+}
+
+// notePreviewLines is how much of a linked note the task detail view shows
+const notePreviewLines = 8
+
+// loadNotePreview reads the start of a task's linked note
+func (m AppModel) loadNotePreview(task *domain.Task) tea.Cmd {
+	if !task.HasNote {
+		return nil
+	}
+	return func() tea.Msg {
+		return readNotePreview(task)
+	}
+}
+
+// readNotePreview reads the start of a task's linked note
+func readNotePreview(task *domain.Task) NotePreviewLoadedMsg {
+	msg := NotePreviewLoadedMsg{TaskID: task.ID}
+	if task.NotePath == nil {
+		msg.Err = fmt.Errorf("no note path recorded")
+		return msg
+	}
+
+	body, err := notes.ReadNoteBody(*task.NotePath)
+	if err != nil {
+		msg.Err = err
+		return msg
+	}
+	msg.Preview, msg.Truncated = notes.Preview(body, notePreviewLines)
+	return msg
+}
+
+// openNote suspends the TUI to edit a task's linked note in the configured
+// editor, $VISUAL, or $EDITOR, then reloads its preview
+func (m AppModel) openNote(task *domain.Task) tea.Cmd {
+	editor := m.editor
+	if editor == "" {
+		editor = os.Getenv("VISUAL")
+	}
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// The editor setting may carry arguments, such as "code --wait"
+	args := append(strings.Fields(editor), *task.NotePath)
+	cmd := exec.Command(args[0], args[1:]...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		if err != nil {
+			return ErrorMsg("Failed to open note: " + err.Error())
+		}
+		return readNotePreview(task)
+	})
+}
+
+// copyTaskID puts the task's reference, or its short ID, on the clipboard in
+// the form the CLI accepts
+func copyTaskID(task *domain.Task) tea.Cmd {
+	return func() tea.Msg {
+		id := task.DisplayID()
+		if err := clipboard.Copy(id); err != nil {
+			return ErrorMsg("Failed to copy task ID: " + err.Error())
+		}
+		return SuccessMsg("Copied " + id + " to the clipboard")
+	}
+}
+
+// loadTimerTasks loads the todo and in-progress tasks a timer can be started on
+func (m AppModel) loadTimerTasks() tea.Cmd {
+	return func() tea.Msg {
+		tasks, err := m.taskRepo.List(context.Background(), domain.TaskFilter{
+			Status: []domain.TaskStatus{domain.StatusTodo, domain.StatusDoing},
+		})
+		if err != nil {
+			return ErrorMsg("Failed to load tasks: " + err.Error())
+		}
+		return TimerTasksLoadedMsg{Tasks: tasks}
+	}
+}
+
+// startTimer starts time tracking on task
+func (m AppModel) startTimer(task *domain.Task) tea.Cmd {
+	return func() tea.Msg {
+		entry, err := m.timeService.StartTimeTracking(context.Background(), timeService.StartTimeEntryInput{
+			TaskID: task.ID,
+		})
+		if err != nil {
+			return ErrorMsg("Failed to start timer: " + err.Error())
+		}
+		return TimerStartedMsg{Entry: entry, Task: task}
 	}
 }
 
@@ -408,10 +1068,72 @@ func (m AppModel) loadProjects() tea.Cmd {
 	}
 }
 
-func (m AppModel) saveTask(task *domain.Task) tea.Cmd {
+// openNewTaskForm shows the template picker when templates are configured,
+// otherwise a blank task form
+func (m *AppModel) openNewTaskForm() {
+	if len(m.templates) > 0 {
+		m.templatePicker = NewTemplatePickerModel(m.templates)
+		m.templatePicker.SetSize(m.width, m.viewHeight())
+		m.currentView = TemplatePickerView
+		return
+	}
+	m.currentView = TaskFormView
+	m.taskForm = m.newTaskForm(nil)
+}
+
+// newTaskForm creates a task form with the known projects, loading task for
+// editing when it is non-nil
+func (m AppModel) newTaskForm(task *domain.Task) TaskFormModel {
+	form := NewTaskFormModel()
+	if task != nil {
+		form.LoadTask(task)
+	}
+	form.SetProjects(m.projects)
+	form.SetSize(m.width, m.viewHeight())
+	return form
+}
+
+func (m AppModel) createTask(task *domain.Task) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.taskService.AddTask(context.Background(), task); err != nil {
+			return ErrorMsg("Failed to create task: " + err.Error())
+		}
+		return SuccessMsg("Task created successfully")
+	}
+}
+
+// quickAddTask creates a task from a quick-add line such as
+// "Fix login #bug !high @website due:friday"
+func (m AppModel) quickAddTask(line string) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
-		if err := m.taskRepo.Update(ctx, task); err != nil {
+		input, projectName, err := taskService.ParseTaskSyntax(line)
+		if err != nil {
+			return ErrorMsg("Failed to add task: " + err.Error())
+		}
+
+		if projectName != "" {
+			project, err := m.projectRepo.GetByName(ctx, projectName)
+			if errors.Is(err, domain.ErrProjectNotFound) {
+				return ErrorMsg(fmt.Sprintf("Failed to add task: project %q not found", projectName))
+			}
+			if err != nil {
+				return ErrorMsg("Failed to add task: " + err.Error())
+			}
+			input.ProjectID = project.ID
+		}
+
+		task, err := m.taskService.CreateTask(ctx, input)
+		if err != nil {
+			return ErrorMsg("Failed to add task: " + err.Error())
+		}
+		return SuccessMsg("Added task: " + task.Title)
+	}
+}
+
+func (m AppModel) saveTask(task *domain.Task) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.taskService.SaveTask(context.Background(), task); err != nil {
 			return ErrorMsg("Failed to save task: " + err.Error())
 		}
 		return SuccessMsg("Task saved successfully")
@@ -429,5 +1151,19 @@ func (m AppModel) deleteTask(task *domain.Task) tea.Cmd {
 }
 
 func (m AppModel) renderHelp() string {
-	return "Help view - TODO: implement help content"
+	var b strings.Builder
+
+	b.WriteString(ui.HeaderStyle.Render("Help"))
+	b.WriteString("\n\n")
+
+	b.WriteString(ui.SubHeaderStyle.Render("Status:"))
+	b.WriteString("\n")
+	b.WriteString(ui.StatusLegend())
+	b.WriteString("\n\n")
+
+	b.WriteString(ui.SubHeaderStyle.Render("Priority:"))
+	b.WriteString("\n")
+	b.WriteString(ui.PriorityLegend())
+
+	return ui.BaseStyle.Render(b.String())
 }
\ No newline at end of file
@@ -5,7 +5,10 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/adriannajera/project-manager-cli/internal/domain"
 	"github.com/adriannajera/project-manager-cli/internal/ui"
 )
 
@@ -13,7 +16,28 @@ import (
 type DashboardModel struct {
 	selectedIndex int
 	menuItems     []DashboardItem
+	stats         *DashboardStats
 	keys          DashboardKeyMap
+
+	// quickAdd is the one-line task capture bar, open while adding is set
+	quickAdd textinput.Model
+	adding   bool
+
+	// Terminal size, zero until known
+	width  int
+	height int
+}
+
+// quickAddWidth is the width of the quick-add bar when the terminal has room
+const quickAddWidth = 60
+
+// DashboardStats summarizes the current state of the database
+type DashboardStats struct {
+	StatusCounts   map[domain.TaskStatus]int
+	Overdue        int
+	ActiveProjects int
+	TimerRunning   bool
+	Recent         []*domain.Task // most recently updated first
 }
 
 // DashboardItem represents a dashboard menu item
@@ -21,52 +45,53 @@ type DashboardItem struct {
 	Title       string
 	Description string
 	Action      string
-	Icon        string
 }
 
 // DashboardKeyMap defines key bindings for the dashboard
 type DashboardKeyMap struct {
-	Up     key.Binding
-	Down   key.Binding
-	Enter  key.Binding
-	Quit   key.Binding
-	Escape key.Binding
+	Up       key.Binding
+	Down     key.Binding
+	Enter    key.Binding
+	Quit     key.Binding
+	Escape   key.Binding
+	QuickAdd key.Binding
 }
 
 // NewDashboardModel creates a new dashboard model
 func NewDashboardModel() DashboardModel {
+	quickAdd := textinput.New()
+	quickAdd.Placeholder = "Fix login #bug !high @project due:friday"
+	quickAdd.CharLimit = 200
+	quickAdd.Width = quickAddWidth
+
 	return DashboardModel{
 		selectedIndex: 0,
+		quickAdd:      quickAdd,
 		menuItems: []DashboardItem{
 			{
 				Title:       "Tasks",
 				Description: "View and manage your tasks",
 				Action:      "tasks",
-				Icon:        "[T]",
 			},
 			{
 				Title:       "Projects",
 				Description: "Manage your projects",
 				Action:      "projects",
-				Icon:        "[P]",
 			},
 			{
 				Title:       "Time Tracking",
 				Description: "Track time spent on tasks",
 				Action:      "time",
-				Icon:        "[TM]",
 			},
 			{
 				Title:       "New Task",
 				Description: "Create a new task",
 				Action:      "new_task",
-				Icon:        "[+]",
 			},
 			{
 				Title:       "Reports",
 				Description: "View productivity reports",
 				Action:      "reports",
-				Icon:        "[R]",
 			},
 		},
 		keys: DashboardKeyMap{
@@ -90,15 +115,47 @@ func NewDashboardModel() DashboardModel {
 				key.WithKeys("esc"),
 				key.WithHelp("esc", "back"),
 			),
+			QuickAdd: key.NewBinding(
+				key.WithKeys("a"),
+				key.WithHelp("a", "quick add"),
+			),
 		},
 	}
 }
 
+// SetStats sets the summary shown above the menu
+func (m *DashboardModel) SetStats(stats *DashboardStats) {
+	m.stats = stats
+}
+
+// SetSize sets the terminal size the dashboard lays out to, narrowing the
+// quick-add bar to fit
+func (m *DashboardModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	extra := ui.FocusedInputStyle.GetHorizontalFrameSize() + lipgloss.Width(m.quickAdd.Prompt)
+	m.quickAdd.Width = inputWidth(width, quickAddWidth, extra)
+}
+
+// Adding reports whether the quick-add bar is open and taking typed text
+func (m DashboardModel) Adding() bool {
+	return m.adding
+}
+
 // Update handles dashboard updates
 func (m DashboardModel) Update(msg tea.Msg) (DashboardModel, tea.Cmd) {
+	if m.adding {
+		return m.updateQuickAdd(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
+		case key.Matches(msg, m.keys.QuickAdd):
+			m.adding = true
+			m.quickAdd.Reset()
+			return m, m.quickAdd.Focus()
+
 		case key.Matches(msg, m.keys.Up):
 			if m.selectedIndex > 0 {
 				m.selectedIndex--
@@ -120,6 +177,37 @@ func (m DashboardModel) Update(msg tea.Msg) (DashboardModel, tea.Cmd) {
 	return m, nil
 }
 
+// updateQuickAdd handles keys while the quick-add bar is open
+func (m DashboardModel) updateQuickAdd(msg tea.Msg) (DashboardModel, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(keyMsg, m.keys.Enter):
+			line := strings.TrimSpace(m.quickAdd.Value())
+			m.closeQuickAdd()
+			if line == "" {
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return QuickAddMsg{Line: line}
+			}
+
+		case key.Matches(keyMsg, m.keys.Escape):
+			m.closeQuickAdd()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.quickAdd, cmd = m.quickAdd.Update(msg)
+	return m, cmd
+}
+
+// closeQuickAdd hides the quick-add bar
+func (m *DashboardModel) closeQuickAdd() {
+	m.adding = false
+	m.quickAdd.Blur()
+}
+
 // View renders the dashboard
 func (m DashboardModel) View() string {
 	var b strings.Builder
@@ -128,6 +216,20 @@ func (m DashboardModel) View() string {
 	b.WriteString(ui.HeaderStyle.Render("Project Manager"))
 	b.WriteString("\n\n")
 
+	// Stats
+	if m.stats != nil {
+		b.WriteString(wrapText(m.renderStats(), contentWidth(m.width)))
+		b.WriteString("\n\n")
+	}
+
+	// Quick-add bar
+	if m.adding {
+		b.WriteString("Quick add:")
+		b.WriteString("\n")
+		b.WriteString(ui.FocusedInputStyle.Render(m.quickAdd.View()))
+		b.WriteString("\n\n")
+	}
+
 	// Subtitle
 	b.WriteString(ui.SubHeaderStyle.Render("Choose an action:"))
 	b.WriteString("\n\n")
@@ -139,7 +241,7 @@ func (m DashboardModel) View() string {
 			style = ui.TableSelectedStyle
 		}
 
-		itemView := fmt.Sprintf("%s %s", item.Icon, item.Title)
+		itemView := fmt.Sprintf("%s %s", ui.MenuIcon(item.Action), item.Title)
 		b.WriteString(style.Render(itemView))
 		b.WriteString("\n")
 
@@ -150,9 +252,64 @@ func (m DashboardModel) View() string {
 		b.WriteString("\n")
 	}
 
-	// Help footer
-	b.WriteString("\n")
-	b.WriteString(ui.HelpStyle.Render("↑/↓: navigate • enter: select • q: quit • ?: help"))
+	// Recently updated tasks, as many as fit below the menu
+	if m.stats != nil && len(m.stats.Recent) > 0 {
+		recent := m.stats.Recent
+		if rows := listRows(m.height, strings.Count(b.String(), "\n")+1); rows > 0 && len(recent) > rows {
+			recent = recent[:rows]
+		}
+
+		b.WriteString(ui.SubHeaderStyle.Render("Recent:"))
+		b.WriteString("\n")
+		for _, task := range recent {
+			line := fmt.Sprintf("%s %s", ui.FormatStatusIcon(string(task.Status)), task.Title)
+			line += ui.HelpStyle.Render(" " + ui.FormatDateTime(task.UpdatedAt))
+			b.WriteString(cutLine(line, contentWidth(m.width)))
+			b.WriteString("\n")
+		}
+	}
+
+	return ui.BaseStyle.Render(strings.TrimRight(b.String(), "\n"))
+}
+
+// HelpText returns the keybar for the dashboard
+func (m DashboardModel) HelpText() string {
+	if m.adding {
+		return "#tag !priority @project due:<date> • enter: add task • esc: cancel"
+	}
+	return "↑/↓: navigate • enter: select • a: quick add • q: quit • ?: help"
+}
+
+// renderStats renders the task, project, and timer summary line
+func (m DashboardModel) renderStats() string {
+	statuses := []domain.TaskStatus{
+		domain.StatusBacklog,
+		domain.StatusTodo,
+		domain.StatusDoing,
+		domain.StatusDone,
+		domain.StatusBlocked,
+	}
+
+	var parts []string
+	for _, status := range statuses {
+		style := ui.GetStatusStyle(string(status)).Strikethrough(false)
+		parts = append(parts, style.Render(fmt.Sprintf("%d %s", m.stats.StatusCounts[status], status)))
+	}
+
+	line := strings.Join(parts, "")
+
+	overdueStyle := ui.HelpStyle
+	if m.stats.Overdue > 0 {
+		overdueStyle = ui.ErrorStyle
+	}
+	line += "\n" + overdueStyle.Render(fmt.Sprintf("  %d overdue", m.stats.Overdue))
+	line += ui.HelpStyle.Render(fmt.Sprintf(" • %d active projects", m.stats.ActiveProjects))
+
+	if m.stats.TimerRunning {
+		line += ui.HelpStyle.Render(" • ") + ui.SuccessStyle.Render("timer running")
+	} else {
+		line += ui.HelpStyle.Render(" • no timer running")
+	}
 
-	return ui.BaseStyle.Render(b.String())
+	return line
 }
\ No newline at end of file
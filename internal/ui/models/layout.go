@@ -0,0 +1,111 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adriannajera/project-manager-cli/internal/ui"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// minWrapWidth is the narrowest text the views wrap or cut lines to;
+// narrower terminals get lines as they are rather than a column of single
+// words
+const minWrapWidth = 20
+
+// contentWidth returns the width inside a view's padding for a terminal
+// width, or zero while the width is unknown or too narrow to lay out to
+func contentWidth(width int) int {
+	width -= ui.BaseStyle.GetHorizontalFrameSize()
+	if width < minWrapWidth {
+		return 0
+	}
+	return width
+}
+
+// wrapText wraps text to width, leaving it as it is when width is zero
+func wrapText(text string, width int) string {
+	if width <= 0 {
+		return text
+	}
+	return lipgloss.NewStyle().Width(width).Render(text)
+}
+
+// cutLine cuts a line down to width cells, leaving it as it is when width
+// is zero
+func cutLine(line string, width int) string {
+	if width <= 0 {
+		return line
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(line)
+}
+
+// firstLine returns the first line of text, for one-line previews
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	return line
+}
+
+// inputWidth returns the width for a text input of at most max cells with
+// extra cells of prompt and framing around it, narrowed to fit the view
+func inputWidth(width, max, extra int) int {
+	width = contentWidth(width)
+	if width == 0 {
+		return max
+	}
+	// One more cell for the cursor at the end of the text
+	width -= extra + 1
+	if width > max {
+		return max
+	}
+	if width < 1 {
+		return 1
+	}
+	return width
+}
+
+// rowWidth returns the width a list row's text is cut to inside the row
+// padding, or zero when rows are left as they are
+func rowWidth(width int) int {
+	width = contentWidth(width)
+	if width == 0 {
+		return 0
+	}
+	return width - ui.TableRowStyle.GetHorizontalFrameSize()
+}
+
+// listRows returns the lines a view of the given height has left for list
+// rows once its padding and chrome lines are taken out, or zero while the
+// height is unknown
+func listRows(height, chrome int) int {
+	if height <= 0 {
+		return 0
+	}
+	rows := height - ui.BaseStyle.GetVerticalFrameSize() - chrome
+	if rows < 1 {
+		rows = 1
+	}
+	return rows
+}
+
+// listWindow returns the range [start, end) of count items to show in rows
+// lines, keeping selected near the middle. When not every item fits, one line
+// goes to position, such as "11-20 of 45". rows of zero shows every item.
+func listWindow(count, selected, rows int) (start, end int, position string) {
+	if rows <= 0 || count <= rows {
+		return 0, count, ""
+	}
+	if rows > 1 {
+		rows--
+	}
+
+	start = selected - rows/2
+	if start > count-rows {
+		start = count - rows
+	}
+	if start < 0 {
+		start = 0
+	}
+	end = start + rows
+	return start, end, fmt.Sprintf("%d-%d of %d", start+1, end, count)
+}
@@ -16,6 +16,10 @@ type ProjectListModel struct {
 	selectedIndex int
 	loading       bool
 	keys          ProjectListKeyMap
+
+	// Terminal size, zero until known; the list scrolls to fit the height
+	width  int
+	height int
 }
 
 // ProjectListKeyMap defines key bindings for the project list
@@ -145,40 +149,61 @@ func (m ProjectListModel) View() string {
 
 	if len(m.projects) == 0 {
 		b.WriteString(ui.HelpStyle.Render("No projects found. Press 'n' to create a new project."))
-		b.WriteString("\n\n")
-		b.WriteString(ui.HelpStyle.Render("n: new project • esc: back"))
 		return ui.BaseStyle.Render(b.String())
 	}
 
+	// Window the list to the height, leaving room for the header and the
+	// selected project's description
+	chrome := 2
+	if m.selectedIndex < len(m.projects) && m.projects[m.selectedIndex].Description != "" {
+		chrome++
+	}
+	start, end, position := listWindow(len(m.projects), m.selectedIndex, listRows(m.height, chrome))
+	width := rowWidth(m.width)
+
 	// Project list
-	for i, project := range m.projects {
+	for i := start; i < end; i++ {
+		project := m.projects[i]
 		style := ui.TableRowStyle
 		if i == m.selectedIndex {
 			style = ui.TableSelectedStyle
 		}
 
-		projectLine := fmt.Sprintf("[P] %s", project.Name)
+		projectLine := fmt.Sprintf("%s %s", ui.ProjectSwatch(project.Color), project.Name)
 
 		if project.Description != "" && i != m.selectedIndex {
 			projectLine += ui.HelpStyle.Render(fmt.Sprintf(" - %s", project.Description))
 		}
 
-		b.WriteString(style.Render(projectLine))
+		b.WriteString(style.Render(cutLine(projectLine, width)))
 		b.WriteString("\n")
 
 		// Show description for selected project
 		if i == m.selectedIndex && project.Description != "" {
-			b.WriteString(ui.HelpStyle.Render("  " + project.Description))
+			b.WriteString(ui.HelpStyle.Render(cutLine("  "+firstLine(project.Description), width)))
 			b.WriteString("\n")
 		}
 	}
 
-	// Help footer
-	b.WriteString("\n")
-	helpText := "up/down: navigate • enter: details • n: new • e: edit • d: delete • esc: back"
-	b.WriteString(ui.HelpStyle.Render(helpText))
+	if position != "" {
+		b.WriteString(ui.HelpStyle.Render(position))
+	}
+
+	return ui.BaseStyle.Render(strings.TrimRight(b.String(), "\n"))
+}
+
+// HelpText returns the keybar for the project list
+func (m ProjectListModel) HelpText() string {
+	if len(m.projects) == 0 {
+		return "n: new project • esc: back"
+	}
+	return "up/down: navigate • enter: details • n: new • e: edit • d: delete • esc: back"
+}
 
-	return ui.BaseStyle.Render(b.String())
+// SetSize sets the terminal size the list lays out to
+func (m *ProjectListModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
 }
 
 // LoadProjects sets the projects for the model
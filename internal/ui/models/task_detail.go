@@ -3,6 +3,7 @@ package models
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -14,6 +15,23 @@ import (
 type TaskDetailModel struct {
 	task *domain.Task
 	keys TaskDetailKeyMap
+
+	// Time tracked against the task, once loaded
+	tracked       time.Duration
+	trackedLoaded bool
+
+	// Preview of the linked note, once loaded
+	notePreview   string
+	noteTruncated bool
+	noteErr       string
+	noteLoaded    bool
+
+	// Comments left on the task, oldest first
+	comments []*domain.Comment
+
+	// Terminal size, zero until known; long fields wrap to the width
+	width  int
+	height int
 }
 
 // TaskDetailKeyMap defines key bindings for the task detail view
@@ -22,6 +40,8 @@ type TaskDetailKeyMap struct {
 	Edit   key.Binding
 	Delete key.Binding
 	Toggle key.Binding
+	Note   key.Binding
+	CopyID key.Binding
 }
 
 // NewTaskDetailModel creates a new task detail model
@@ -44,15 +64,80 @@ func NewTaskDetailModel() TaskDetailModel {
 				key.WithKeys("t"),
 				key.WithHelp("t", "toggle status"),
 			),
+			Note: key.NewBinding(
+				key.WithKeys("o"),
+				key.WithHelp("o", "open note"),
+			),
+			CopyID: key.NewBinding(
+				key.WithKeys("y"),
+				key.WithHelp("y", "copy ID"),
+			),
 		},
 	}
 }
 
 // SetTask sets the task to display
 func (m *TaskDetailModel) SetTask(task *domain.Task) {
+	if m.task == nil || task == nil || m.task.ID != task.ID {
+		m.trackedLoaded = false
+		m.noteLoaded = false
+		m.comments = nil
+	}
 	m.task = task
 }
 
+// SetSize sets the terminal size the view lays out to
+func (m *TaskDetailModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// wrap wraps text to the width inside the view's padding, leaving it as it
+// is while the width is unknown
+func (m TaskDetailModel) wrap(text string) string {
+	return wrapText(text, contentWidth(m.width))
+}
+
+// SetNotePreview records the linked note's preview for the task with taskID,
+// ignoring results for a task that is no longer shown. err is set when the
+// note could not be read.
+func (m *TaskDetailModel) SetNotePreview(taskID, preview string, truncated bool, err error) {
+	if m.task == nil || m.task.ID != taskID {
+		return
+	}
+	m.notePreview = preview
+	m.noteTruncated = truncated
+	m.noteErr = ""
+	if err != nil {
+		m.noteErr = err.Error()
+	}
+	m.noteLoaded = true
+}
+
+// canOpenNote reports whether the linked note was found and can be opened
+func (m TaskDetailModel) canOpenNote() bool {
+	return m.task.HasNote && m.noteLoaded && m.noteErr == ""
+}
+
+// SetTrackedTime records the time tracked against the task with taskID,
+// ignoring results for a task that is no longer shown
+func (m *TaskDetailModel) SetTrackedTime(taskID string, tracked time.Duration) {
+	if m.task == nil || m.task.ID != taskID {
+		return
+	}
+	m.tracked = tracked
+	m.trackedLoaded = true
+}
+
+// SetComments records the comments on the task with taskID, ignoring
+// results for a task that is no longer shown
+func (m *TaskDetailModel) SetComments(taskID string, comments []*domain.Comment) {
+	if m.task == nil || m.task.ID != taskID {
+		return
+	}
+	m.comments = comments
+}
+
 // Update handles task detail updates
 func (m TaskDetailModel) Update(msg tea.Msg) (TaskDetailModel, tea.Cmd) {
 	if m.task == nil {
@@ -78,6 +163,25 @@ func (m TaskDetailModel) Update(msg tea.Msg) (TaskDetailModel, tea.Cmd) {
 				}
 			}
 
+		case key.Matches(msg, m.keys.CopyID):
+			return m, func() tea.Msg {
+				return TaskActionMsg{
+					Action: "copy_id",
+					Task:   m.task,
+				}
+			}
+
+		case key.Matches(msg, m.keys.Note):
+			if !m.canOpenNote() {
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return TaskActionMsg{
+					Action: "open_note",
+					Task:   m.task,
+				}
+			}
+
 		case key.Matches(msg, m.keys.Toggle):
 			if m.task.Status == domain.StatusDone {
 				m.task.Status = domain.StatusTodo
@@ -111,15 +215,21 @@ func (m TaskDetailModel) View() string {
 	// Title
 	b.WriteString(ui.SubHeaderStyle.Render("Title:"))
 	b.WriteString("\n")
-	b.WriteString(m.task.Title)
+	title := m.task.Title
+	if m.task.Ref != "" {
+		title = ui.HelpStyle.Render(m.task.Ref) + " " + title
+	}
+	b.WriteString(m.wrap(title))
 	b.WriteString("\n\n")
 
 	// Status
 	b.WriteString(ui.SubHeaderStyle.Render("Status:"))
 	b.WriteString("\n")
-	b.WriteString(ui.FormatStatusIcon(string(m.task.Status)))
-	b.WriteString(" ")
-	b.WriteString(string(m.task.Status))
+	status := ui.FormatStatusIcon(string(m.task.Status)) + " " + string(m.task.Status)
+	if reason := m.task.BlockedReason(); reason != "" {
+		status += ui.HelpStyle.Render(" (" + reason + ")")
+	}
+	b.WriteString(m.wrap(status))
 	b.WriteString("\n\n")
 
 	// Priority
@@ -132,7 +242,15 @@ func (m TaskDetailModel) View() string {
 	if m.task.Description != "" {
 		b.WriteString(ui.SubHeaderStyle.Render("Description:"))
 		b.WriteString("\n")
-		b.WriteString(m.task.Description)
+		b.WriteString(m.wrap(m.task.Description))
+		b.WriteString("\n\n")
+	}
+
+	// Linked note
+	if m.task.HasNote {
+		b.WriteString(ui.SubHeaderStyle.Render("Note:"))
+		b.WriteString("\n")
+		b.WriteString(m.wrap(m.renderNote()))
 		b.WriteString("\n\n")
 	}
 
@@ -144,11 +262,22 @@ func (m TaskDetailModel) View() string {
 		b.WriteString("\n\n")
 	}
 
+	// Attached links and files
+	if attachments := m.task.Attachments(); len(attachments) > 0 {
+		b.WriteString(ui.SubHeaderStyle.Render("Attachments:"))
+		b.WriteString("\n")
+		for _, ref := range attachments {
+			b.WriteString(m.wrap(ref))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
 	// Tags
 	if len(m.task.Tags) > 0 {
 		b.WriteString(ui.SubHeaderStyle.Render("Tags:"))
 		b.WriteString("\n")
-		b.WriteString(ui.TagStyle.Render(strings.Join(m.task.Tags, ", ")))
+		b.WriteString(m.wrap(ui.TagStyle.Render(strings.Join(m.task.Tags, ", "))))
 		b.WriteString("\n\n")
 	}
 
@@ -160,19 +289,89 @@ func (m TaskDetailModel) View() string {
 		if m.task.IsOverdue() {
 			dueStyle = ui.ErrorStyle
 		}
-		b.WriteString(dueStyle.Render(m.task.DueDate.Format("2006-01-02")))
+		b.WriteString(dueStyle.Render(ui.FormatDate(*m.task.DueDate)))
 		b.WriteString("\n\n")
 	}
 
+	// Time tracked against the estimate
+	if m.task.Estimate > 0 || (m.trackedLoaded && m.tracked > 0) {
+		b.WriteString(ui.SubHeaderStyle.Render("Time:"))
+		b.WriteString("\n")
+		b.WriteString(m.renderTime())
+		b.WriteString("\n\n")
+	}
+
+	// Comments
+	if len(m.comments) > 0 {
+		b.WriteString(ui.SubHeaderStyle.Render(fmt.Sprintf("Comments (%d):", len(m.comments))))
+		b.WriteString("\n")
+		for _, c := range m.comments {
+			b.WriteString(m.wrap(ui.HelpStyle.Render(ui.FormatDateTime(c.CreatedAt)) + " " + c.Body))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
 	// Created/Updated
-	b.WriteString(ui.HelpStyle.Render(fmt.Sprintf("Created: %s", m.task.CreatedAt.Format("2006-01-02 15:04"))))
+	b.WriteString(ui.HelpStyle.Render("Created: " + ui.FormatDateTime(m.task.CreatedAt)))
 	b.WriteString("\n")
-	b.WriteString(ui.HelpStyle.Render(fmt.Sprintf("Updated: %s", m.task.UpdatedAt.Format("2006-01-02 15:04"))))
-	b.WriteString("\n\n")
-
-	// Help footer
-	helpText := "e: edit • d: delete • t: toggle status • esc: back"
-	b.WriteString(ui.HelpStyle.Render(helpText))
+	b.WriteString(ui.HelpStyle.Render("Updated: " + ui.FormatDateTime(m.task.UpdatedAt)))
 
 	return ui.BaseStyle.Render(b.String())
 }
+
+// renderTime renders tracked time and, when the task has an estimate, the
+// variance against it
+func (m TaskDetailModel) renderTime() string {
+	if !m.trackedLoaded {
+		return ui.HelpStyle.Render(fmt.Sprintf("Estimate: %s", formatHoursMinutes(m.task.Estimate)))
+	}
+
+	line := fmt.Sprintf("Tracked: %s", formatHoursMinutes(m.tracked))
+	if m.task.Estimate == 0 {
+		return line
+	}
+
+	line += fmt.Sprintf(" of %s estimated", formatHoursMinutes(m.task.Estimate))
+	variance := m.task.EstimateVariance(m.tracked)
+	switch {
+	case variance > 0:
+		return line + " " + ui.ErrorStyle.Render(fmt.Sprintf("(%s over)", formatHoursMinutes(variance)))
+	case variance < 0:
+		return line + " " + ui.SuccessStyle.Render(fmt.Sprintf("(%s left)", formatHoursMinutes(-variance)))
+	default:
+		return line
+	}
+}
+
+// renderNote renders the linked note's preview or why it is unavailable
+func (m TaskDetailModel) renderNote() string {
+	switch {
+	case !m.noteLoaded:
+		return ui.HelpStyle.Render("Loading note...")
+	case m.noteErr != "":
+		return ui.ErrorStyle.Render("Note unavailable: " + m.noteErr)
+	case m.notePreview == "":
+		return ui.HelpStyle.Render("(empty note, press o to open)")
+	case m.noteTruncated:
+		return m.notePreview + "\n" + ui.HelpStyle.Render("... press o to open the full note")
+	default:
+		return m.notePreview
+	}
+}
+
+// formatHoursMinutes formats a duration as hours and minutes
+func formatHoursMinutes(d time.Duration) string {
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// HelpText returns the keybar for the task detail view
+func (m TaskDetailModel) HelpText() string {
+	if m.task == nil {
+		return "esc: back"
+	}
+	if m.canOpenNote() {
+		return "e: edit • d: delete • t: toggle status • o: open note • y: copy ID • esc: back"
+	}
+	return "e: edit • d: delete • t: toggle status • y: copy ID • esc: back"
+}
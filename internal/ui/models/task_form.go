@@ -7,6 +7,7 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/adriannajera/project-manager-cli/internal/domain"
 	"github.com/adriannajera/project-manager-cli/internal/ui"
 )
@@ -20,19 +21,62 @@ type TaskFormModel struct {
 	changelistInput  textinput.Model
 	dueDateInput     textinput.Model
 
+	// Project selector; index 0 means no project, and -1 keeps a project
+	// the selector doesn't list, such as an archived one
+	projects     []*domain.Project
+	projectIndex int
+
+	priority domain.Priority
+
+	// Status selector, only shown when editing
+	statusIndex int
+
 	// Form state
 	focusedField int
 	task         *domain.Task
 	isEditing    bool
 	keys         TaskFormKeyMap
+
+	// Terminal size, zero until known
+	width  int
+	height int
 }
 
+// taskFormInputWidth is the width of the text inputs when the terminal has
+// room
+const taskFormInputWidth = 50
+
 // TaskFormKeyMap defines key bindings for the task form
 type TaskFormKeyMap struct {
-	Submit key.Binding
-	Cancel key.Binding
-	Next   key.Binding
-	Prev   key.Binding
+	Submit     key.Binding
+	Cancel     key.Binding
+	Next       key.Binding
+	Prev       key.Binding
+	OptionNext key.Binding
+	OptionPrev key.Binding
+}
+
+// Form field indices
+const (
+	fieldTitle = iota
+	fieldDescription
+	fieldTags
+	fieldChangelist
+	fieldDueDate
+	fieldProject
+	fieldPriority
+	fieldStatus
+
+	taskFormFieldCount
+)
+
+// taskFormStatuses lists the statuses offered by the status selector
+var taskFormStatuses = []domain.TaskStatus{
+	domain.StatusBacklog,
+	domain.StatusTodo,
+	domain.StatusDoing,
+	domain.StatusDone,
+	domain.StatusBlocked,
 }
 
 // NewTaskFormModel creates a new task form model
@@ -41,27 +85,27 @@ func NewTaskFormModel() TaskFormModel {
 	titleInput.Placeholder = "Enter task title..."
 	titleInput.Focus()
 	titleInput.CharLimit = 200
-	titleInput.Width = 50
+	titleInput.Width = taskFormInputWidth
 
 	descriptionInput := textinput.New()
 	descriptionInput.Placeholder = "Enter task description..."
 	descriptionInput.CharLimit = 500
-	descriptionInput.Width = 50
+	descriptionInput.Width = taskFormInputWidth
 
 	tagsInput := textinput.New()
 	tagsInput.Placeholder = "Enter tags (comma-separated)..."
 	tagsInput.CharLimit = 200
-	tagsInput.Width = 50
+	tagsInput.Width = taskFormInputWidth
 
 	changelistInput := textinput.New()
 	changelistInput.Placeholder = "Enter changelist (e.g., c/1234, CL/456)..."
 	changelistInput.CharLimit = 100
-	changelistInput.Width = 50
+	changelistInput.Width = taskFormInputWidth
 
 	dueDateInput := textinput.New()
 	dueDateInput.Placeholder = "Enter due date (YYYY-MM-DD)..."
 	dueDateInput.CharLimit = 10
-	dueDateInput.Width = 50
+	dueDateInput.Width = taskFormInputWidth
 
 	return TaskFormModel{
 		titleInput:       titleInput,
@@ -69,6 +113,7 @@ func NewTaskFormModel() TaskFormModel {
 		tagsInput:        tagsInput,
 		changelistInput:  changelistInput,
 		dueDateInput:     dueDateInput,
+		priority:         domain.PriorityNormal,
 		focusedField:     0,
 		keys: TaskFormKeyMap{
 			Submit: key.NewBinding(
@@ -87,10 +132,96 @@ func NewTaskFormModel() TaskFormModel {
 				key.WithKeys("shift+tab"),
 				key.WithHelp("shift+tab", "prev field"),
 			),
+			OptionNext: key.NewBinding(
+				key.WithKeys("right", " "),
+				key.WithHelp("→/space", "next option"),
+			),
+			OptionPrev: key.NewBinding(
+				key.WithKeys("left"),
+				key.WithHelp("←", "prev option"),
+			),
 		},
 	}
 }
 
+// SetSize sets the terminal size the form lays out to, narrowing the text
+// inputs to fit
+func (m *TaskFormModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+
+	inputs := []*textinput.Model{&m.titleInput, &m.descriptionInput, &m.tagsInput, &m.changelistInput, &m.dueDateInput}
+	for _, input := range inputs {
+		extra := ui.FocusedInputStyle.GetHorizontalFrameSize() + lipgloss.Width(input.Prompt)
+		input.Width = inputWidth(width, taskFormInputWidth, extra)
+	}
+}
+
+// SetProjects sets the projects offered by the project selector
+func (m *TaskFormModel) SetProjects(projects []*domain.Project) {
+	m.projects = projects
+	m.syncProjectIndex()
+}
+
+// syncProjectIndex selects the loaded task's project in the selector
+func (m *TaskFormModel) syncProjectIndex() {
+	m.projectIndex = 0
+	if m.task == nil || m.task.ProjectID == "" {
+		return
+	}
+	for i, project := range m.projects {
+		if project.ID == m.task.ProjectID {
+			m.projectIndex = i + 1
+			return
+		}
+	}
+	m.projectIndex = -1
+}
+
+// fieldCount returns the number of fields shown; status is only editable
+// on existing tasks
+func (m TaskFormModel) fieldCount() int {
+	if m.isEditing {
+		return taskFormFieldCount
+	}
+	return taskFormFieldCount - 1
+}
+
+// isOptionField reports whether the focused field is a selector rather than a text input
+func (m TaskFormModel) isOptionField() bool {
+	return m.focusedField == fieldProject || m.focusedField == fieldPriority || m.focusedField == fieldStatus
+}
+
+// cycleOption moves the focused selector by delta, wrapping around
+func (m *TaskFormModel) cycleOption(delta int) {
+	switch m.focusedField {
+	case fieldProject:
+		// Moving off an unlisted project starts from "no project"
+		if m.projectIndex < 0 {
+			m.projectIndex = 0
+		}
+		count := len(m.projects) + 1
+		m.projectIndex = (m.projectIndex + delta + count) % count
+	case fieldPriority:
+		count := int(domain.PriorityCritical) + 1
+		m.priority = domain.Priority((int(m.priority) + delta + count) % count)
+	case fieldStatus:
+		count := len(taskFormStatuses)
+		m.statusIndex = (m.statusIndex + delta + count) % count
+	}
+}
+
+// selectedProjectName returns the label for the current project selection
+func (m TaskFormModel) selectedProjectName() string {
+	if m.projectIndex < 0 {
+		return "(unchanged)"
+	}
+	if m.projectIndex == 0 || m.projectIndex > len(m.projects) {
+		return "(none)"
+	}
+	return m.projects[m.projectIndex-1].Name
+}
+
 // LoadTask loads an existing task into the form for editing
 func (m *TaskFormModel) LoadTask(task *domain.Task) {
 	m.task = task
@@ -98,6 +229,13 @@ func (m *TaskFormModel) LoadTask(task *domain.Task) {
 
 	m.titleInput.SetValue(task.Title)
 	m.descriptionInput.SetValue(task.Description)
+	m.priority = task.Priority
+
+	for i, status := range taskFormStatuses {
+		if status == task.Status {
+			m.statusIndex = i
+		}
+	}
 
 	if len(task.Tags) > 0 {
 		m.tagsInput.SetValue(strings.Join(task.Tags, ", "))
@@ -110,6 +248,34 @@ func (m *TaskFormModel) LoadTask(task *domain.Task) {
 	if task.DueDate != nil {
 		m.dueDateInput.SetValue(task.DueDate.Format("2006-01-02"))
 	}
+
+	m.syncProjectIndex()
+}
+
+// ApplyTemplate prefills a new task from the template called name. Projects
+// must be set first so the template's project can be selected. A template
+// with an unknown priority is refused and leaves the form alone.
+func (m *TaskFormModel) ApplyTemplate(name string, template domain.TaskTemplate) error {
+	priority, ok, err := template.ParsePriority(name)
+	if err != nil {
+		return err
+	}
+	if ok {
+		m.priority = priority
+	}
+
+	m.titleInput.SetValue(template.TitlePrefix)
+
+	if len(template.Tags) > 0 {
+		m.tagsInput.SetValue(strings.Join(template.Tags, ", "))
+	}
+
+	for i, project := range m.projects {
+		if project.Name == template.Project || project.ID == template.Project {
+			m.projectIndex = i + 1
+		}
+	}
+	return nil
 }
 
 // Update handles task form updates
@@ -129,30 +295,38 @@ func (m TaskFormModel) Update(msg tea.Msg) (TaskFormModel, tea.Cmd) {
 			}
 
 		case key.Matches(msg, m.keys.Next):
-			m.focusedField = (m.focusedField + 1) % 5
+			m.focusedField = (m.focusedField + 1) % m.fieldCount()
 			m.updateFocus()
 
 		case key.Matches(msg, m.keys.Prev):
-			m.focusedField = (m.focusedField - 1 + 5) % 5
+			m.focusedField = (m.focusedField - 1 + m.fieldCount()) % m.fieldCount()
 			m.updateFocus()
+
+		case m.isOptionField() && key.Matches(msg, m.keys.OptionNext):
+			m.cycleOption(1)
+			return m, nil
+
+		case m.isOptionField() && key.Matches(msg, m.keys.OptionPrev):
+			m.cycleOption(-1)
+			return m, nil
 		}
 	}
 
 	// Update the focused input
 	switch m.focusedField {
-	case 0:
+	case fieldTitle:
 		m.titleInput, cmd = m.titleInput.Update(msg)
 		cmds = append(cmds, cmd)
-	case 1:
+	case fieldDescription:
 		m.descriptionInput, cmd = m.descriptionInput.Update(msg)
 		cmds = append(cmds, cmd)
-	case 2:
+	case fieldTags:
 		m.tagsInput, cmd = m.tagsInput.Update(msg)
 		cmds = append(cmds, cmd)
-	case 3:
+	case fieldChangelist:
 		m.changelistInput, cmd = m.changelistInput.Update(msg)
 		cmds = append(cmds, cmd)
-	case 4:
+	case fieldDueDate:
 		m.dueDateInput, cmd = m.dueDateInput.Update(msg)
 		cmds = append(cmds, cmd)
 	}
@@ -175,7 +349,7 @@ func (m TaskFormModel) View() string {
 	// Title field
 	b.WriteString("Title:")
 	b.WriteString("\n")
-	if m.focusedField == 0 {
+	if m.focusedField == fieldTitle {
 		b.WriteString(ui.FocusedInputStyle.Render(m.titleInput.View()))
 	} else {
 		b.WriteString(ui.InputStyle.Render(m.titleInput.View()))
@@ -185,7 +359,7 @@ func (m TaskFormModel) View() string {
 	// Description field
 	b.WriteString("Description:")
 	b.WriteString("\n")
-	if m.focusedField == 1 {
+	if m.focusedField == fieldDescription {
 		b.WriteString(ui.FocusedInputStyle.Render(m.descriptionInput.View()))
 	} else {
 		b.WriteString(ui.InputStyle.Render(m.descriptionInput.View()))
@@ -195,7 +369,7 @@ func (m TaskFormModel) View() string {
 	// Tags field
 	b.WriteString("Tags (comma-separated):")
 	b.WriteString("\n")
-	if m.focusedField == 2 {
+	if m.focusedField == fieldTags {
 		b.WriteString(ui.FocusedInputStyle.Render(m.tagsInput.View()))
 	} else {
 		b.WriteString(ui.InputStyle.Render(m.tagsInput.View()))
@@ -205,7 +379,7 @@ func (m TaskFormModel) View() string {
 	// Changelist field
 	b.WriteString("Changelist (e.g., c/1234):")
 	b.WriteString("\n")
-	if m.focusedField == 3 {
+	if m.focusedField == fieldChangelist {
 		b.WriteString(ui.FocusedInputStyle.Render(m.changelistInput.View()))
 	} else {
 		b.WriteString(ui.InputStyle.Render(m.changelistInput.View()))
@@ -215,18 +389,59 @@ func (m TaskFormModel) View() string {
 	// Due date field
 	b.WriteString("Due Date (YYYY-MM-DD):")
 	b.WriteString("\n")
-	if m.focusedField == 4 {
+	if m.focusedField == fieldDueDate {
 		b.WriteString(ui.FocusedInputStyle.Render(m.dueDateInput.View()))
 	} else {
 		b.WriteString(ui.InputStyle.Render(m.dueDateInput.View()))
 	}
 	b.WriteString("\n\n")
 
-	// Help footer
-	helpText := "tab: next field • shift+tab: prev field • ctrl+s: save • esc: cancel"
-	b.WriteString(ui.HelpStyle.Render(helpText))
+	// Project selector
+	b.WriteString("Project (←/→ to change):")
+	b.WriteString("\n")
+	projectView := "< " + m.selectedProjectName() + " >"
+	if m.focusedField == fieldProject {
+		b.WriteString(ui.FocusedInputStyle.Render(projectView))
+	} else {
+		b.WriteString(ui.InputStyle.Render(projectView))
+	}
+	b.WriteString("\n\n")
 
-	return ui.BaseStyle.Render(b.String())
+	// Priority selector
+	b.WriteString("Priority (←/→ to change):")
+	b.WriteString("\n")
+	priorityView := "< " + ui.GetPriorityStyle(int(m.priority)).Render(m.priority.String()) + " >"
+	if m.focusedField == fieldPriority {
+		b.WriteString(ui.FocusedInputStyle.Render(priorityView))
+	} else {
+		b.WriteString(ui.InputStyle.Render(priorityView))
+	}
+	b.WriteString("\n\n")
+
+	// Status selector
+	if m.isEditing {
+		status := taskFormStatuses[m.statusIndex]
+		b.WriteString("Status (←/→ to change):")
+		b.WriteString("\n")
+		statusView := "< " + ui.GetStatusStyle(string(status)).Render(string(status)) + " >"
+		if m.focusedField == fieldStatus {
+			b.WriteString(ui.FocusedInputStyle.Render(statusView))
+		} else {
+			b.WriteString(ui.InputStyle.Render(statusView))
+		}
+		b.WriteString("\n\n")
+	}
+
+	return ui.BaseStyle.Render(strings.TrimRight(b.String(), "\n"))
+}
+
+// HelpText returns the keybar for the task form, including selector keys
+// when a selector is focused
+func (m TaskFormModel) HelpText() string {
+	if m.isOptionField() {
+		return "←/→: change • tab: next field • shift+tab: prev field • ctrl+s: save • esc: cancel"
+	}
+	return "tab: next field • shift+tab: prev field • ctrl+s: save • esc: cancel"
 }
 
 // updateFocus updates which field has focus
@@ -238,15 +453,15 @@ func (m *TaskFormModel) updateFocus() {
 	m.dueDateInput.Blur()
 
 	switch m.focusedField {
-	case 0:
+	case fieldTitle:
 		m.titleInput.Focus()
-	case 1:
+	case fieldDescription:
 		m.descriptionInput.Focus()
-	case 2:
+	case fieldTags:
 		m.tagsInput.Focus()
-	case 3:
+	case fieldChangelist:
 		m.changelistInput.Focus()
-	case 4:
+	case fieldDueDate:
 		m.dueDateInput.Focus()
 	}
 }
@@ -301,10 +516,34 @@ func (m TaskFormModel) handleSubmit() (TaskFormModel, tea.Cmd) {
 		}
 	}
 
+	task.Priority = m.priority
+
+	// Apply status change, keeping the completion timestamp in step
+	if m.isEditing {
+		if status := taskFormStatuses[m.statusIndex]; status != task.Status {
+			if status == domain.StatusDone {
+				task.Complete()
+			} else {
+				task.Status = status
+				task.CompletedAt = nil
+			}
+		}
+	}
+
+	// Apply project selection, keeping the task's project until the
+	// selector has projects to choose from or when it isn't listed
+	if len(m.projects) > 0 && m.projectIndex >= 0 && m.projectIndex <= len(m.projects) {
+		task.ProjectID = ""
+		if m.projectIndex > 0 {
+			task.ProjectID = m.projects[m.projectIndex-1].ID
+		}
+	}
+
 	task.UpdatedAt = time.Now()
 
+	isNew := !m.isEditing
 	return m, func() tea.Msg {
-		return TaskFormSubmitMsg{Task: task}
+		return TaskFormSubmitMsg{Task: task, IsNew: isNew}
 	}
 }
 
@@ -3,6 +3,7 @@ package models
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -17,6 +18,16 @@ type TaskListModel struct {
 	filter        domain.TaskFilter
 	loading       bool
 	keys          TaskListKeyMap
+
+	// Project colors keyed by project ID
+	projectColors map[string]string
+
+	// Number of direct subtasks keyed by task ID
+	subtaskCounts map[string]int
+
+	// Terminal size, zero until known; the list scrolls to fit the height
+	width  int
+	height int
 }
 
 // TaskListKeyMap defines key bindings for the task list
@@ -30,6 +41,13 @@ type TaskListKeyMap struct {
 	Toggle key.Binding
 	Filter key.Binding
 	Back   key.Binding
+	CopyID key.Binding
+
+	ClearFilter key.Binding
+
+	RaisePriority key.Binding
+	LowerPriority key.Binding
+	SetPriority   key.Binding
 }
 
 // NewTaskListModel creates a new task list model
@@ -70,12 +88,32 @@ func NewTaskListModel() TaskListModel {
 			),
 			Filter: key.NewBinding(
 				key.WithKeys("f"),
-				key.WithHelp("f", "filter"),
+				key.WithHelp("f", "filter by status"),
+			),
+			ClearFilter: key.NewBinding(
+				key.WithKeys("F"),
+				key.WithHelp("F", "clear filter"),
 			),
 			Back: key.NewBinding(
 				key.WithKeys("esc"),
 				key.WithHelp("esc", "back"),
 			),
+			CopyID: key.NewBinding(
+				key.WithKeys("y"),
+				key.WithHelp("y", "copy ID"),
+			),
+			RaisePriority: key.NewBinding(
+				key.WithKeys("+", "="),
+				key.WithHelp("+", "raise priority"),
+			),
+			LowerPriority: key.NewBinding(
+				key.WithKeys("-"),
+				key.WithHelp("-", "lower priority"),
+			),
+			SetPriority: key.NewBinding(
+				key.WithKeys("1", "2", "3", "4"),
+				key.WithHelp("1-4", "set priority"),
+			),
 		},
 	}
 }
@@ -84,6 +122,17 @@ func NewTaskListModel() TaskListModel {
 func (m TaskListModel) Update(msg tea.Msg) (TaskListModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// Filtering works on an empty list too, which a filter can leave
+		switch {
+		case key.Matches(msg, m.keys.Filter):
+			return m, m.setStatusFilter(nextStatusFilter(m.filter.Status))
+		case key.Matches(msg, m.keys.ClearFilter):
+			if len(m.filter.Status) == 0 {
+				return m, nil
+			}
+			return m, m.setStatusFilter(nil)
+		}
+
 		if len(m.tasks) == 0 {
 			switch {
 			case key.Matches(msg, m.keys.New):
@@ -155,10 +204,37 @@ func (m TaskListModel) Update(msg tea.Msg) (TaskListModel, tea.Cmd) {
 					}
 				}
 			}
+
+		case key.Matches(msg, m.keys.CopyID):
+			if m.selectedIndex < len(m.tasks) {
+				return m, func() tea.Msg {
+					return TaskActionMsg{
+						Action: "copy_id",
+						Task:   m.tasks[m.selectedIndex],
+					}
+				}
+			}
+
+		case key.Matches(msg, m.keys.RaisePriority):
+			if m.selectedIndex < len(m.tasks) {
+				return m, m.setPriority(m.tasks[m.selectedIndex].Priority + 1)
+			}
+
+		case key.Matches(msg, m.keys.LowerPriority):
+			if m.selectedIndex < len(m.tasks) {
+				return m, m.setPriority(m.tasks[m.selectedIndex].Priority - 1)
+			}
+
+		case key.Matches(msg, m.keys.SetPriority):
+			if m.selectedIndex < len(m.tasks) {
+				// Keys 1-4 map to low through critical
+				return m, m.setPriority(domain.Priority(msg.String()[0] - '1'))
+			}
 		}
 
 	case TaskListLoadedMsg:
 		m.tasks = msg.Tasks
+		m.subtaskCounts = msg.SubtaskCounts
 		m.loading = false
 		if m.selectedIndex >= len(m.tasks) && len(m.tasks) > 0 {
 			m.selectedIndex = len(m.tasks) - 1
@@ -174,6 +250,9 @@ func (m TaskListModel) View() string {
 
 	// Header
 	b.WriteString(ui.HeaderStyle.Render("Tasks"))
+	if label := m.statusFilterLabel(); label != "" {
+		b.WriteString(ui.HelpStyle.Render("showing " + label))
+	}
 	b.WriteString("\n\n")
 
 	if m.loading {
@@ -182,14 +261,26 @@ func (m TaskListModel) View() string {
 	}
 
 	if len(m.tasks) == 0 {
-		b.WriteString(ui.HelpStyle.Render("No tasks found. Press 'n' to create a new task."))
-		b.WriteString("\n\n")
-		b.WriteString(ui.HelpStyle.Render("n: new task • esc: back"))
+		if label := m.statusFilterLabel(); label != "" {
+			b.WriteString(ui.HelpStyle.Render(fmt.Sprintf("No %s tasks. Press 'f' to change the filter or 'F' to clear it.", label)))
+		} else {
+			b.WriteString(ui.HelpStyle.Render("No tasks found. Press 'n' to create a new task."))
+		}
 		return ui.BaseStyle.Render(b.String())
 	}
 
+	// Window the list to the height, leaving room for the header and the
+	// selected task's description
+	chrome := 2
+	if m.selectedIndex < len(m.tasks) && m.tasks[m.selectedIndex].Description != "" {
+		chrome++
+	}
+	start, end, position := listWindow(len(m.tasks), m.selectedIndex, listRows(m.height, chrome))
+	width := rowWidth(m.width)
+
 	// Task list
-	for i, task := range m.tasks {
+	for i := start; i < end; i++ {
+		task := m.tasks[i]
 		style := ui.TableRowStyle
 		if i == m.selectedIndex {
 			style = ui.TableSelectedStyle
@@ -205,6 +296,17 @@ func (m TaskListModel) View() string {
 			task.Title,
 		)
 
+		// Mark the owning project with its color
+		if color, ok := m.projectColors[task.ProjectID]; ok {
+			taskLine = ui.ProjectDot(color) + " " + taskLine
+		}
+
+		if count := m.subtaskCounts[task.ID]; count == 1 {
+			taskLine += ui.HelpStyle.Render(" (1 subtask)")
+		} else if count > 1 {
+			taskLine += ui.HelpStyle.Render(fmt.Sprintf(" (%d subtasks)", count))
+		}
+
 		// Add changelist if any
 		if task.Changelist != "" {
 			taskLine += ui.TagStyle.Render(fmt.Sprintf(" (%s)", task.Changelist))
@@ -222,25 +324,137 @@ func (m TaskListModel) View() string {
 			if task.IsOverdue() {
 				dueStyle = ui.ErrorStyle
 			}
-			taskLine += dueStyle.Render(fmt.Sprintf(" (due: %s)", task.DueDate.Format("Jan 2")))
+			taskLine += dueStyle.Render(fmt.Sprintf(" (due: %s)", ui.FormatDate(*task.DueDate)))
 		}
 
-		b.WriteString(style.Render(taskLine))
+		b.WriteString(style.Render(cutLine(taskLine, width)))
 		b.WriteString("\n")
 
 		// Show description for selected task
 		if i == m.selectedIndex && task.Description != "" {
-			b.WriteString(ui.HelpStyle.Render("  " + task.Description))
+			b.WriteString(ui.HelpStyle.Render(cutLine("  "+firstLine(task.Description), width)))
 			b.WriteString("\n")
 		}
 	}
 
-	// Help footer
-	b.WriteString("\n")
-	helpText := "↑/↓: navigate • enter: details • n: new • e: edit • t: toggle • d: delete • esc: back"
-	b.WriteString(ui.HelpStyle.Render(helpText))
+	if position != "" {
+		b.WriteString(ui.HelpStyle.Render(position))
+	}
+
+	return ui.BaseStyle.Render(strings.TrimRight(b.String(), "\n"))
+}
+
+// HelpText returns the keybar for the task list
+func (m TaskListModel) HelpText() string {
+	if len(m.tasks) == 0 {
+		return "n: new task • f: filter • F: clear filter • esc: back"
+	}
+	return "↑/↓: navigate • enter: details • n: new • e: edit • t: toggle • +/-/1-4: priority • f/F: filter • y: copy ID • d: delete • esc: back"
+}
+
+// taskListFilterStatuses lists the statuses f steps through, after showing
+// every status
+var taskListFilterStatuses = []domain.TaskStatus{
+	domain.StatusBacklog,
+	domain.StatusTodo,
+	domain.StatusDoing,
+	domain.StatusDone,
+	domain.StatusBlocked,
+}
+
+// nextStatusFilter returns the filter f moves to from current: each status
+// in turn, then back to every status
+func nextStatusFilter(current []domain.TaskStatus) []domain.TaskStatus {
+	if len(current) == 0 {
+		return []domain.TaskStatus{taskListFilterStatuses[0]}
+	}
+	for i, status := range taskListFilterStatuses {
+		if status == current[0] && i+1 < len(taskListFilterStatuses) {
+			return []domain.TaskStatus{taskListFilterStatuses[i+1]}
+		}
+	}
+	return nil
+}
+
+// setStatusFilter switches the list to the statuses, empty for all, and asks
+// for the tasks to be reloaded
+func (m *TaskListModel) setStatusFilter(statuses []domain.TaskStatus) tea.Cmd {
+	m.filter.Status = statuses
+	m.selectedIndex = 0
+	return func() tea.Msg {
+		return TaskFilterChangedMsg{Status: statuses}
+	}
+}
+
+// statusFilterLabel describes the status filter, such as "doing" or
+// "todo, doing", or returns an empty string when every status is shown
+func (m TaskListModel) statusFilterLabel() string {
+	names := make([]string, len(m.filter.Status))
+	for i, status := range m.filter.Status {
+		names[i] = string(status)
+	}
+	return strings.Join(names, ", ")
+}
 
-	return ui.BaseStyle.Render(b.String())
+// StatusFilter returns the statuses the list shows, or nil for all
+func (m TaskListModel) StatusFilter() []domain.TaskStatus {
+	return m.filter.Status
+}
+
+// SetStatusFilter sets the statuses the list shows without reloading it;
+// nil shows all
+func (m *TaskListModel) SetStatusFilter(statuses []domain.TaskStatus) {
+	m.filter.Status = statuses
+}
+
+// setPriority clamps priority to the valid range and emits an update for the
+// selected task when it changes
+func (m TaskListModel) setPriority(priority domain.Priority) tea.Cmd {
+	if priority < domain.PriorityLow {
+		priority = domain.PriorityLow
+	}
+	if priority > domain.PriorityCritical {
+		priority = domain.PriorityCritical
+	}
+
+	task := m.tasks[m.selectedIndex]
+	if task.Priority == priority {
+		return nil
+	}
+
+	task.Priority = priority
+	task.UpdatedAt = time.Now()
+	return func() tea.Msg {
+		return TaskActionMsg{
+			Action: "update",
+			Task:   task,
+		}
+	}
+}
+
+// SelectedTask returns the task under the cursor, or nil when the list is
+// empty
+func (m TaskListModel) SelectedTask() *domain.Task {
+	if m.selectedIndex >= len(m.tasks) {
+		return nil
+	}
+	return m.tasks[m.selectedIndex]
+}
+
+// SetSize sets the terminal size the list lays out to
+func (m *TaskListModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// SetProjects records project colors for marking tasks by project
+func (m *TaskListModel) SetProjects(projects []*domain.Project) {
+	m.projectColors = make(map[string]string, len(projects))
+	for _, project := range projects {
+		if project.Color != "" {
+			m.projectColors[project.ID] = project.Color
+		}
+	}
 }
 
 // LoadTasks sets the tasks for the model
@@ -254,4 +468,26 @@ func (m *TaskListModel) LoadTasks(tasks []*domain.Task) {
 // Message types
 type TaskListLoadedMsg struct {
 	Tasks []*domain.Task
-}
\ No newline at end of file
+	// SubtaskCounts holds the number of direct subtasks by task ID
+	SubtaskCounts map[string]int
+}
+
+// newTaskListLoadedMsg splits tasks listed with their subtask counts into a
+// TaskListLoadedMsg
+func newTaskListLoadedMsg(counted []*domain.TaskWithSubtaskCount) TaskListLoadedMsg {
+	msg := TaskListLoadedMsg{
+		Tasks:         make([]*domain.Task, 0, len(counted)),
+		SubtaskCounts: make(map[string]int, len(counted)),
+	}
+	for _, task := range counted {
+		msg.Tasks = append(msg.Tasks, task.Task)
+		msg.SubtaskCounts[task.ID] = task.SubtaskCount
+	}
+	return msg
+}
+
+// TaskFilterChangedMsg is sent when the task list's status filter changes;
+// Status is nil when every status is shown
+type TaskFilterChangedMsg struct {
+	Status []domain.TaskStatus
+}
@@ -0,0 +1,165 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+	"github.com/adriannajera/project-manager-cli/internal/ui"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TemplatePickerModel lets the user choose a task template before the task form
+type TemplatePickerModel struct {
+	// Template names in display order; index 0 is a blank task
+	names         []string
+	templates     map[string]domain.TaskTemplate
+	selectedIndex int
+	keys          TemplatePickerKeyMap
+
+	// Terminal size, zero until known; the list scrolls to fit the height
+	width  int
+	height int
+}
+
+// TemplatePickerKeyMap defines key bindings for the template picker
+type TemplatePickerKeyMap struct {
+	Up    key.Binding
+	Down  key.Binding
+	Enter key.Binding
+}
+
+// NewTemplatePickerModel creates a template picker offering the given templates
+func NewTemplatePickerModel(templates map[string]domain.TaskTemplate) TemplatePickerModel {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return TemplatePickerModel{
+		names:     append([]string{""}, names...),
+		templates: templates,
+		keys: TemplatePickerKeyMap{
+			Up: key.NewBinding(
+				key.WithKeys("up", "k"),
+				key.WithHelp("↑/k", "up"),
+			),
+			Down: key.NewBinding(
+				key.WithKeys("down", "j"),
+				key.WithHelp("↓/j", "down"),
+			),
+			Enter: key.NewBinding(
+				key.WithKeys("enter"),
+				key.WithHelp("enter", "select"),
+			),
+		},
+	}
+}
+
+// Update handles template picker updates
+func (m TemplatePickerModel) Update(msg tea.Msg) (TemplatePickerModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.keys.Up):
+			if m.selectedIndex > 0 {
+				m.selectedIndex--
+			}
+
+		case key.Matches(msg, m.keys.Down):
+			if m.selectedIndex < len(m.names)-1 {
+				m.selectedIndex++
+			}
+
+		case key.Matches(msg, m.keys.Enter):
+			selected := TemplateSelectedMsg{}
+			if name := m.names[m.selectedIndex]; name != "" {
+				template := m.templates[name]
+				selected.Name, selected.Template = name, &template
+			}
+			return m, func() tea.Msg {
+				return selected
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// View renders the template picker
+func (m TemplatePickerModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(ui.HeaderStyle.Render("New Task"))
+	b.WriteString("\n\n")
+	b.WriteString(ui.SubHeaderStyle.Render("Choose a template:"))
+	b.WriteString("\n\n")
+
+	// Window the list to the height, leaving room for the headers
+	start, end, position := listWindow(len(m.names), m.selectedIndex, listRows(m.height, 4))
+	width := contentWidth(m.width)
+
+	for i := start; i < end; i++ {
+		name := m.names[i]
+		style := ui.TableRowStyle
+		if i == m.selectedIndex {
+			style = ui.TableSelectedStyle
+		}
+
+		var line string
+		if name == "" {
+			line = style.Render("Blank task")
+		} else {
+			line = style.Render(name)
+			if summary := templateSummary(m.templates[name]); summary != "" {
+				line += ui.HelpStyle.Render("  " + summary)
+			}
+		}
+		b.WriteString(cutLine(line, width))
+		b.WriteString("\n")
+	}
+
+	if position != "" {
+		b.WriteString(ui.HelpStyle.Render(position))
+	}
+
+	return ui.BaseStyle.Render(strings.TrimRight(b.String(), "\n"))
+}
+
+// SetSize sets the terminal size the picker lays out to
+func (m *TemplatePickerModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// HelpText returns the keybar for the template picker
+func (m TemplatePickerModel) HelpText() string {
+	return "↑/↓: navigate • enter: select • esc: back"
+}
+
+// templateSummary describes the defaults a template applies
+func templateSummary(template domain.TaskTemplate) string {
+	var parts []string
+	if template.TitlePrefix != "" {
+		parts = append(parts, fmt.Sprintf("%q", template.TitlePrefix))
+	}
+	if len(template.Tags) > 0 {
+		parts = append(parts, "["+strings.Join(template.Tags, ", ")+"]")
+	}
+	if template.Priority != "" {
+		parts = append(parts, template.Priority)
+	}
+	if template.Project != "" {
+		parts = append(parts, "@"+template.Project)
+	}
+	return strings.Join(parts, " ")
+}
+
+// TemplateSelectedMsg is sent when a template is chosen; Template is nil for
+// a blank task
+type TemplateSelectedMsg struct {
+	Name     string
+	Template *domain.TaskTemplate
+}
@@ -0,0 +1,191 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+	"github.com/adriannajera/project-manager-cli/internal/ui"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TimerPickerModel lets the user search open tasks and start a timer on one
+type TimerPickerModel struct {
+	searchInput   textinput.Model
+	tasks         []*domain.Task
+	matches       []*domain.Task
+	selectedIndex int
+	loading       bool
+	keys          TimerPickerKeyMap
+
+	// Terminal size, zero until known; the matches scroll to fit the height
+	width  int
+	height int
+}
+
+// timerSearchWidth is the width of the search box when the terminal has room
+const timerSearchWidth = 50
+
+// TimerPickerKeyMap defines key bindings for the timer task picker
+type TimerPickerKeyMap struct {
+	Up    key.Binding
+	Down  key.Binding
+	Enter key.Binding
+}
+
+// NewTimerPickerModel creates a timer picker that waits for its tasks to load
+func NewTimerPickerModel() TimerPickerModel {
+	searchInput := textinput.New()
+	searchInput.Placeholder = "Type to search tasks..."
+	searchInput.Focus()
+	searchInput.CharLimit = 100
+	searchInput.Width = timerSearchWidth
+
+	return TimerPickerModel{
+		searchInput: searchInput,
+		loading:     true,
+		keys: TimerPickerKeyMap{
+			// Letters go to the search box, so only arrow keys navigate
+			Up: key.NewBinding(
+				key.WithKeys("up", "ctrl+p"),
+				key.WithHelp("↑", "up"),
+			),
+			Down: key.NewBinding(
+				key.WithKeys("down", "ctrl+n"),
+				key.WithHelp("↓", "down"),
+			),
+			Enter: key.NewBinding(
+				key.WithKeys("enter"),
+				key.WithHelp("enter", "start timer"),
+			),
+		},
+	}
+}
+
+// Update handles timer picker updates
+func (m TimerPickerModel) Update(msg tea.Msg) (TimerPickerModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Up):
+			if m.selectedIndex > 0 {
+				m.selectedIndex--
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.Down):
+			if m.selectedIndex < len(m.matches)-1 {
+				m.selectedIndex++
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.Enter):
+			if m.selectedIndex < len(m.matches) {
+				task := m.matches[m.selectedIndex]
+				return m, func() tea.Msg {
+					return TimerTaskSelectedMsg{Task: task}
+				}
+			}
+			return m, nil
+		}
+
+		var cmd tea.Cmd
+		m.searchInput, cmd = m.searchInput.Update(msg)
+		m.applySearch()
+		return m, cmd
+
+	case TimerTasksLoadedMsg:
+		m.tasks = msg.Tasks
+		m.loading = false
+		m.applySearch()
+	}
+
+	return m, nil
+}
+
+// applySearch narrows the task list to titles containing the search text
+func (m *TimerPickerModel) applySearch() {
+	query := strings.ToLower(strings.TrimSpace(m.searchInput.Value()))
+
+	m.matches = nil
+	for _, task := range m.tasks {
+		if query == "" || strings.Contains(strings.ToLower(task.Title), query) {
+			m.matches = append(m.matches, task)
+		}
+	}
+
+	if m.selectedIndex >= len(m.matches) {
+		m.selectedIndex = 0
+	}
+}
+
+// View renders the timer picker
+func (m TimerPickerModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(ui.HeaderStyle.Render("Start Timer"))
+	b.WriteString("\n\n")
+	b.WriteString(m.searchInput.View())
+	b.WriteString("\n\n")
+
+	switch {
+	case m.loading:
+		b.WriteString("Loading tasks...")
+	case len(m.tasks) == 0:
+		b.WriteString(ui.HelpStyle.Render("No todo or in-progress tasks to track."))
+	case len(m.matches) == 0:
+		b.WriteString(ui.HelpStyle.Render("No tasks match your search."))
+	}
+
+	// Window the matches to the height, leaving room for the header and the
+	// search box
+	start, end, position := listWindow(len(m.matches), m.selectedIndex, listRows(m.height, 4))
+	width := rowWidth(m.width)
+
+	for i := start; i < end; i++ {
+		task := m.matches[i]
+		style := ui.TableRowStyle
+		if i == m.selectedIndex {
+			style = ui.TableSelectedStyle
+		}
+		b.WriteString(style.Render(cutLine(ui.FormatStatusIcon(string(task.Status))+" "+task.Title, width)))
+		b.WriteString("\n")
+	}
+
+	if position != "" {
+		b.WriteString(ui.HelpStyle.Render(position))
+	}
+
+	return ui.BaseStyle.Render(strings.TrimRight(b.String(), "\n"))
+}
+
+// SetSize sets the terminal size the picker lays out to, narrowing the
+// search box to fit
+func (m *TimerPickerModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.searchInput.Width = inputWidth(width, timerSearchWidth, lipgloss.Width(m.searchInput.Prompt))
+}
+
+// HelpText returns the keybar for the timer picker
+func (m TimerPickerModel) HelpText() string {
+	return "type: search • ↑/↓: navigate • enter: start timer • esc: back"
+}
+
+// TimerTasksLoadedMsg carries the tasks a timer can be started on
+type TimerTasksLoadedMsg struct {
+	Tasks []*domain.Task
+}
+
+// TimerTaskSelectedMsg is sent when a task is chosen for a new timer
+type TimerTaskSelectedMsg struct {
+	Task *domain.Task
+}
+
+// TimerStartedMsg is sent once a timer has been started
+type TimerStartedMsg struct {
+	Entry *domain.TimeEntry
+	Task  *domain.Task
+}
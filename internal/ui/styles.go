@@ -103,6 +103,16 @@ var (
 			Foreground(mutedColor).
 			Faint(true)
 
+	// PaneStyle separates the task detail pane from the list beside it
+	PaneStyle = lipgloss.NewStyle().
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderLeft(true).
+			BorderForeground(mutedColor)
+
+	// Frame styles for the header and footer drawn around every view
+	FrameStyle = lipgloss.NewStyle().
+			Padding(0, 2)
+
 	// Error styles
 	ErrorStyle = lipgloss.NewStyle().
 			Foreground(errorColor).
@@ -160,33 +170,18 @@ func GetPriorityStyle(priority int) lipgloss.Style {
 // FormatStatusIcon returns a colored icon for the task status
 func FormatStatusIcon(status string) string {
 	switch status {
-	case "todo":
-		return TodoStyle.Render("[ ]")
 	case "doing":
-		return DoingStyle.Render("[~]")
+		return DoingStyle.Render(statusIcon(status))
 	case "done":
-		return DoneStyle.Render("[x]")
+		return DoneStyle.Render(statusIcon(status))
 	case "blocked":
-		return BlockedStyle.Render("[!]")
-	case "backlog":
-		return TodoStyle.Render("[-]")
+		return BlockedStyle.Render(statusIcon(status))
 	default:
-		return TodoStyle.Render("[ ]")
+		return TodoStyle.Render(statusIcon(status))
 	}
 }
 
 // FormatPriorityIcon returns a colored icon for the priority level
 func FormatPriorityIcon(priority int) string {
-	switch priority {
-	case 0: // Low
-		return LowPriorityStyle.Render("[LOW]")
-	case 1: // Normal
-		return NormalPriorityStyle.Render("[NORM]")
-	case 2: // High
-		return HighPriorityStyle.Render("[HIGH]")
-	case 3: // Critical
-		return CriticalPriorityStyle.Render("[CRIT]")
-	default:
-		return NormalPriorityStyle.Render("[NORM]")
-	}
+	return GetPriorityStyle(priority).Render(priorityIcon(priority))
 }
\ No newline at end of file
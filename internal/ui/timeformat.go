@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Default layouts, matching the date_format and time_format defaults
+const (
+	DefaultDateLayout = "2006-01-02"
+	DefaultTimeLayout = "15:04"
+)
+
+// dateLayout and timeLayout format dates and times everywhere pm shows them
+var (
+	dateLayout = DefaultDateLayout
+	timeLayout = DefaultTimeLayout
+)
+
+// SetTimeFormats sets the Go layouts used to show dates and times; an empty
+// layout keeps the default
+func SetTimeFormats(date, clock string) {
+	dateLayout = DefaultDateLayout
+	if date != "" {
+		dateLayout = date
+	}
+	timeLayout = DefaultTimeLayout
+	if clock != "" {
+		timeLayout = clock
+	}
+}
+
+// CheckTimeLayout rejects a layout with no Go reference-time elements in it,
+// such as "YYYY-MM-DD", which would print the same text for every time
+func CheckTimeLayout(layout string) error {
+	if strings.TrimSpace(layout) == "" {
+		return fmt.Errorf("format cannot be empty")
+	}
+	a := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	b := time.Date(2017, 11, 23, 8, 37, 49, 0, time.UTC)
+	if a.Format(layout) == b.Format(layout) {
+		return fmt.Errorf("%q is not a Go time layout (write the reference time Mon Jan 2 15:04:05 2006 the way it should look, e.g. 02/01/2006)", layout)
+	}
+	return nil
+}
+
+// DateTimeLayout returns the layout for a date followed by a time of day
+func DateTimeLayout() string {
+	return dateLayout + " " + timeLayout
+}
+
+// FormatDate formats the date part of t
+func FormatDate(t time.Time) string {
+	return t.Format(dateLayout)
+}
+
+// FormatDay formats the date part of t after its weekday, as in headings
+// that name a day
+func FormatDay(t time.Time) string {
+	return t.Format("Mon") + " " + FormatDate(t)
+}
+
+// FormatTime formats the time of day of t
+func FormatTime(t time.Time) string {
+	return t.Format(timeLayout)
+}
+
+// FormatDateTime formats t as a date and time of day
+func FormatDateTime(t time.Time) string {
+	return t.Format(DateTimeLayout())
+}
@@ -1,8 +1,12 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 	"github.com/adriannajera/project-manager-cli/internal/domain"
@@ -11,8 +15,114 @@ import (
 const (
 	configFileName = "config.yaml"
 	configDirName  = ".pm"
+	xdgDirName     = "pm"
+	dbFileName     = "tasks.db"
+	profilesDir    = "profiles"
+
+	// DefaultProfile names the configuration used when no profile is selected
+	DefaultProfile = "default"
 )
 
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// profileOverride is set by the --profile flag and wins over PM_PROFILE
+var profileOverride string
+
+// configDirOverride is set by the --config-dir flag and wins over the environment
+var configDirOverride string
+
+// SetConfigDir makes dir hold both the config file and the default database.
+// An empty dir restores the environment-based lookup.
+func SetConfigDir(dir string) {
+	configDirOverride = dir
+}
+
+// SetProfile selects the named profile for the next Load
+func SetProfile(name string) error {
+	if name != "" && !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q (use letters, digits, '-' and '_')", name)
+	}
+	profileOverride = name
+	return nil
+}
+
+// ActiveProfile returns the selected profile from the --profile flag or
+// PM_PROFILE, or an empty string for the default configuration
+func ActiveProfile() string {
+	name := profileOverride
+	if name == "" {
+		name = os.Getenv("PM_PROFILE")
+	}
+	if name == DefaultProfile {
+		return ""
+	}
+	return name
+}
+
+// ConfigDir returns the directory holding config.yaml. It is, in order of
+// precedence, the --config-dir flag, PM_CONFIG_DIR, $XDG_CONFIG_HOME/pm, or
+// ~/.pm.
+func ConfigDir() string {
+	if dir := explicitDir(); dir != "" {
+		return dir
+	}
+	if dir := xdgDir("XDG_CONFIG_HOME"); dir != "" {
+		return dir
+	}
+	return homeDir()
+}
+
+// DataDir returns the directory holding the default database. An explicit
+// config dir keeps all state together; otherwise $XDG_DATA_HOME/pm is used
+// when set, falling back to the config dir.
+func DataDir() string {
+	if dir := explicitDir(); dir != "" {
+		return dir
+	}
+	if dir := xdgDir("XDG_DATA_HOME"); dir != "" {
+		return dir
+	}
+	return ConfigDir()
+}
+
+// xdgDir returns $env/pm when env is set. An existing ~/.pm wins until the
+// XDG directory is created, so setting XDG variables doesn't hide the
+// config and database of an earlier install.
+func xdgDir(env string) string {
+	xdg := os.Getenv(env)
+	if xdg == "" {
+		return ""
+	}
+	dir := filepath.Join(xdg, xdgDirName)
+	if !isDir(dir) && isDir(homeDir()) {
+		return homeDir()
+	}
+	return dir
+}
+
+// isDir reports whether path is an existing directory
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// explicitDir returns the config dir chosen by flag or PM_CONFIG_DIR, if any
+func explicitDir() string {
+	if configDirOverride != "" {
+		return configDirOverride
+	}
+	return os.Getenv("PM_CONFIG_DIR")
+}
+
+// homeDir returns ~/.pm, or ./.pm when the home directory is unknown
+func homeDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".", configDirName)
+	}
+	return filepath.Join(home, configDirName)
+}
+
 // Load loads the configuration from the default locations
 func Load() (*domain.Config, error) {
 	configPath := getConfigPath()
@@ -23,7 +133,7 @@ func Load() (*domain.Config, error) {
 		if err := Save(defaultConfig); err != nil {
 			return nil, err
 		}
-		return defaultConfig, nil
+		return resolve(defaultConfig)
 	}
 
 	// Load existing config
@@ -37,16 +147,37 @@ func Load() (*domain.Config, error) {
 		return nil, err
 	}
 
+	return resolve(&config)
+}
+
+// resolve applies the active profile and environment overrides to a loaded
+// config
+func resolve(config *domain.Config) (*domain.Config, error) {
+	if name := ActiveProfile(); name != "" {
+		if !profileNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid profile name %q (use letters, digits, '-' and '_')", name)
+		}
+
+		profile := config.Profiles[name]
+		config.ActiveProfile = name
+		config.DatabasePath = profile.DatabasePath
+		if config.DatabasePath == "" {
+			config.DatabasePath = filepath.Join(profilesDir, name+".db")
+		}
+		if profile.DefaultProject != "" {
+			config.DefaultProject = profile.DefaultProject
+		}
+	}
+
 	// Override database path with PM_DB_PATH environment variable if set
 	if dbPath := os.Getenv("PM_DB_PATH"); dbPath != "" {
 		config.DatabasePath = dbPath
 	} else if !filepath.IsAbs(config.DatabasePath) {
-		// Ensure database path is absolute if using config file path
-		homeDir, _ := os.UserHomeDir()
-		config.DatabasePath = filepath.Join(homeDir, configDirName, config.DatabasePath)
+		// Relative database paths live in the data directory
+		config.DatabasePath = filepath.Join(DataDir(), config.DatabasePath)
 	}
 
-	return &config, nil
+	return config, nil
 }
 
 // Save saves the configuration to the default location
@@ -58,6 +189,10 @@ func Save(config *domain.Config) error {
 		return err
 	}
 
+	if config.ActiveProfile != "" {
+		config = foldProfile(config, configPath)
+	}
+
 	data, err := yaml.Marshal(config)
 	if err != nil {
 		return err
@@ -66,15 +201,100 @@ func Save(config *domain.Config) error {
 	return os.WriteFile(configPath, data, 0644)
 }
 
-// getConfigPath returns the path to the configuration file
-func getConfigPath() string {
-	homeDir, err := os.UserHomeDir()
+// foldProfile moves profile-specific settings back into the profile section
+// so saving under a profile leaves the default configuration untouched
+func foldProfile(config *domain.Config, configPath string) *domain.Config {
+	folded := *config
+	folded.Profiles = make(map[string]domain.Profile, len(config.Profiles)+1)
+	for name, profile := range config.Profiles {
+		folded.Profiles[name] = profile
+	}
+
+	profile := folded.Profiles[config.ActiveProfile]
+	profile.DefaultProject = config.DefaultProject
+	folded.Profiles[config.ActiveProfile] = profile
+
+	// Restore the top-level values the profile replaced
+	var base domain.Config
+	if data, err := os.ReadFile(configPath); err == nil && yaml.Unmarshal(data, &base) == nil {
+		folded.DatabasePath = base.DatabasePath
+		folded.DefaultProject = base.DefaultProject
+	}
+
+	return &folded
+}
+
+// ListProfiles returns the default profile plus every profile defined in the
+// config or with a database in the profiles directory, sorted by name
+func ListProfiles(config *domain.Config) ([]string, error) {
+	seen := map[string]bool{DefaultProfile: true}
+	for name := range config.Profiles {
+		seen[name] = true
+	}
+
+	files, err := filepath.Glob(filepath.Join(DataDir(), profilesDir, "*.db"))
 	if err != nil {
-		// Fallback to current directory
-		return filepath.Join(".", configDirName, configFileName)
+		return nil, fmt.Errorf("failed to list profile databases: %w", err)
+	}
+	for _, file := range files {
+		seen[strings.TrimSuffix(filepath.Base(file), ".db")] = true
 	}
 
-	return filepath.Join(homeDir, configDirName, configFileName)
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// getConfigPath returns the path to the configuration file
+func getConfigPath() string {
+	return filepath.Join(ConfigDir(), configFileName)
+}
+
+// Path returns the path of the configuration file
+func Path() string {
+	return getConfigPath()
+}
+
+// Exists reports whether the configuration file has been written yet
+func Exists() bool {
+	_, err := os.Stat(getConfigPath())
+	return err == nil
+}
+
+// Default returns the configuration written on first run
+func Default() *domain.Config {
+	return getDefaultConfig()
+}
+
+// ThemePresets are the named color themes offered during setup
+var ThemePresets = map[string]domain.Theme{
+	"default": {
+		Primary:   "#3b82f6",
+		Secondary: "#64748b",
+		Success:   "#10b981",
+		Warning:   "#f59e0b",
+		Error:     "#ef4444",
+		Muted:     "#6b7280",
+	},
+	"solarized": {
+		Primary:   "#268bd2",
+		Secondary: "#93a1a1",
+		Success:   "#859900",
+		Warning:   "#b58900",
+		Error:     "#dc322f",
+		Muted:     "#586e75",
+	},
+	"monochrome": {
+		Primary:   "#ffffff",
+		Secondary: "#a3a3a3",
+		Success:   "#d4d4d4",
+		Warning:   "#e5e5e5",
+		Error:     "#ffffff",
+		Muted:     "#737373",
+	},
 }
 
 // getDefaultConfig returns the default configuration
@@ -82,8 +302,7 @@ func getDefaultConfig() *domain.Config {
 	// Check for PM_DB_PATH environment variable first
 	dbPath := os.Getenv("PM_DB_PATH")
 	if dbPath == "" {
-		homeDir, _ := os.UserHomeDir()
-		dbPath = filepath.Join(homeDir, configDirName, "tasks.db")
+		dbPath = filepath.Join(DataDir(), dbFileName)
 	}
 
 	return &domain.Config{
@@ -92,14 +311,10 @@ func getDefaultConfig() *domain.Config {
 		GitIntegration: true,
 		TimeFormat:     "15:04",
 		DateFormat:     "2006-01-02",
-		Theme: domain.Theme{
-			Primary:   "#3b82f6",
-			Secondary: "#64748b",
-			Success:   "#10b981",
-			Warning:   "#f59e0b",
-			Error:     "#ef4444",
-			Muted:     "#6b7280",
-		},
+		IconStyle:      "ascii",
+		MaxTimerHours:  12,
+		WeekStart:      "monday",
+		Theme:          ThemePresets["default"],
 		Aliases: map[string]string{
 			"ls":   "list",
 			"new":  "add",
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+)
+
+func TestDirectoryResolution(t *testing.T) {
+	tests := []struct {
+		name       string
+		override   string
+		env        map[string]string
+		dirs       []string
+		wantConfig string
+		wantData   string
+	}{
+		{
+			name:       "home default",
+			env:        map[string]string{"HOME": "/home/u"},
+			wantConfig: "/home/u/.pm",
+			wantData:   "/home/u/.pm",
+		},
+		{
+			name:       "xdg config and data",
+			env:        map[string]string{"HOME": "/home/u", "XDG_CONFIG_HOME": "/xdg/config", "XDG_DATA_HOME": "/xdg/data"},
+			wantConfig: "/xdg/config/pm",
+			wantData:   "/xdg/data/pm",
+		},
+		{
+			name:       "xdg config only",
+			env:        map[string]string{"HOME": "/home/u", "XDG_CONFIG_HOME": "/xdg/config"},
+			wantConfig: "/xdg/config/pm",
+			wantData:   "/xdg/config/pm",
+		},
+		{
+			name:       "existing home dir beats missing xdg dirs",
+			env:        map[string]string{"HOME": "/home/u", "XDG_CONFIG_HOME": "/xdg/config", "XDG_DATA_HOME": "/xdg/data"},
+			dirs:       []string{"/home/u/.pm"},
+			wantConfig: "/home/u/.pm",
+			wantData:   "/home/u/.pm",
+		},
+		{
+			name:       "existing xdg dir beats home dir",
+			env:        map[string]string{"HOME": "/home/u", "XDG_CONFIG_HOME": "/xdg/config", "XDG_DATA_HOME": "/xdg/data"},
+			dirs:       []string{"/home/u/.pm", "/xdg/config/pm"},
+			wantConfig: "/xdg/config/pm",
+			wantData:   "/home/u/.pm",
+		},
+		{
+			name:       "env dir beats xdg",
+			env:        map[string]string{"PM_CONFIG_DIR": "/state", "XDG_CONFIG_HOME": "/xdg/config", "XDG_DATA_HOME": "/xdg/data"},
+			wantConfig: "/state",
+			wantData:   "/state",
+		},
+		{
+			name:       "flag beats env",
+			override:   "/flag",
+			env:        map[string]string{"PM_CONFIG_DIR": "/state"},
+			wantConfig: "/flag",
+			wantData:   "/flag",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Paths are rooted in a temporary directory so cases can create them
+			root := t.TempDir()
+			abs := func(path string) string {
+				return filepath.Join(root, filepath.FromSlash(path))
+			}
+			for _, key := range []string{"PM_CONFIG_DIR", "XDG_CONFIG_HOME", "XDG_DATA_HOME"} {
+				t.Setenv(key, "")
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, abs(value))
+			}
+			for _, dir := range tt.dirs {
+				if err := os.MkdirAll(abs(dir), 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.override != "" {
+				SetConfigDir(abs(tt.override))
+			}
+			defer SetConfigDir("")
+
+			if got := ConfigDir(); got != abs(tt.wantConfig) {
+				t.Errorf("ConfigDir() = %q, want %q", got, abs(tt.wantConfig))
+			}
+			if got := DataDir(); got != abs(tt.wantData) {
+				t.Errorf("DataDir() = %q, want %q", got, abs(tt.wantData))
+			}
+		})
+	}
+}
+
+func TestProfileUsesOwnDatabaseAndKeepsDefaults(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("PM_DB_PATH", "")
+	t.Setenv("PM_PROFILE", "")
+	SetConfigDir(dir)
+	defer SetConfigDir("")
+
+	// First load writes the default config
+	if _, err := Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if err := SetProfile("work"); err != nil {
+		t.Fatalf("SetProfile() error = %v", err)
+	}
+	defer SetProfile("")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() with profile error = %v", err)
+	}
+	if want := filepath.Join(dir, "profiles", "work.db"); cfg.DatabasePath != want {
+		t.Errorf("DatabasePath = %q, want %q", cfg.DatabasePath, want)
+	}
+
+	cfg.DefaultProject = "backend"
+	if err := Save(cfg); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if err := SetProfile(""); err != nil {
+		t.Fatalf("SetProfile() error = %v", err)
+	}
+	base, err := Load()
+	if err != nil {
+		t.Fatalf("Load() without profile error = %v", err)
+	}
+	if want := filepath.Join(dir, "tasks.db"); base.DatabasePath != want {
+		t.Errorf("default DatabasePath = %q, want %q", base.DatabasePath, want)
+	}
+	if base.DefaultProject != "" {
+		t.Errorf("default DefaultProject = %q, want empty", base.DefaultProject)
+	}
+	if got := base.Profiles["work"].DefaultProject; got != "backend" {
+		t.Errorf("work profile DefaultProject = %q, want backend", got)
+	}
+
+	names, err := ListProfiles(base)
+	if err != nil {
+		t.Fatalf("ListProfiles() error = %v", err)
+	}
+	if len(names) != 2 || names[0] != "default" || names[1] != "work" {
+		t.Errorf("ListProfiles() = %v, want [default work]", names)
+	}
+}
+
+func TestSetProfileRejectsPaths(t *testing.T) {
+	if err := SetProfile("../work"); err == nil {
+		t.Error("SetProfile(\"../work\") succeeded, want error")
+	}
+}
+
+func TestTUIStateRoundTrip(t *testing.T) {
+	SetConfigDir(t.TempDir())
+	defer SetConfigDir("")
+
+	state, err := LoadTUIState()
+	if err != nil {
+		t.Fatalf("LoadTUIState() before saving error = %v", err)
+	}
+	if len(state.StatusFilter) != 0 {
+		t.Errorf("StatusFilter = %v before saving, want empty", state.StatusFilter)
+	}
+
+	state.StatusFilter = []domain.TaskStatus{domain.StatusDoing}
+	if err := SaveTUIState(state); err != nil {
+		t.Fatalf("SaveTUIState() error = %v", err)
+	}
+
+	loaded, err := LoadTUIState()
+	if err != nil {
+		t.Fatalf("LoadTUIState() error = %v", err)
+	}
+	if len(loaded.StatusFilter) != 1 || loaded.StatusFilter[0] != domain.StatusDoing {
+		t.Errorf("StatusFilter = %v, want [doing]", loaded.StatusFilter)
+	}
+}
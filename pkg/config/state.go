@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/adriannajera/project-manager-cli/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// stateFileName holds what the TUI remembers between sessions, kept apart
+// from config.yaml so the TUI never rewrites the user's settings
+const stateFileName = "tui_state.yaml"
+
+// TUIState is what the TUI remembers between sessions
+type TUIState struct {
+	// StatusFilter lists the statuses the task list shows; empty shows all
+	StatusFilter []domain.TaskStatus `yaml:"status_filter,omitempty"`
+}
+
+// LoadTUIState reads the TUI state, returning an empty state when none has
+// been saved yet
+func LoadTUIState() (*TUIState, error) {
+	state := &TUIState{}
+
+	data, err := os.ReadFile(statePath())
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TUI state: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse TUI state: %w", err)
+	}
+	return state, nil
+}
+
+// SaveTUIState writes the TUI state for the next session
+func SaveTUIState(state *TUIState) error {
+	path := statePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode TUI state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write TUI state: %w", err)
+	}
+	return nil
+}
+
+// statePath returns the path to the TUI state file
+func statePath() string {
+	return filepath.Join(ConfigDir(), stateFileName)
+}